@@ -0,0 +1,150 @@
+package katalis
+
+import (
+	"cmp"
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// Number constrains the result types of the numeric aggregations.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Count returns the number of records satisfying pred, or of all records
+// when pred is nil. Like the other aggregations it decodes on one worker per
+// CPU, so analytical one-offs don't pay for the codec serially.
+func Count[KT, VT any](db DB[KT, VT], pred func(key KT, val VT) bool) (int, error) {
+	return aggregate(db, 0,
+		func(acc int, key KT, val VT) int {
+			if pred == nil || pred(key, val) {
+				acc++
+			}
+			return acc
+		},
+		func(a, b int) int { return a + b })
+}
+
+// Sum returns the sum of extract over all records.
+func Sum[KT, VT any, N Number](db DB[KT, VT], extract func(key KT, val VT) N) (N, error) {
+	return aggregate(db, N(0),
+		func(acc N, key KT, val VT) N { return acc + extract(key, val) },
+		func(a, b N) N { return a + b })
+}
+
+// Avg returns the mean of extract over all records, 0 when the store is
+// empty.
+func Avg[KT, VT any, N Number](db DB[KT, VT], extract func(key KT, val VT) N) (float64, error) {
+	type part struct {
+		sum float64
+		n   int
+	}
+	p, err := aggregate(db, part{},
+		func(acc part, key KT, val VT) part {
+			return part{sum: acc.sum + float64(extract(key, val)), n: acc.n + 1}
+		},
+		func(a, b part) part { return part{sum: a.sum + b.sum, n: a.n + b.n} })
+	if err != nil || p.n == 0 {
+		return 0, err
+	}
+	return p.sum / float64(p.n), nil
+}
+
+// Min returns the smallest value of extract over all records, reporting
+// false when the store is empty.
+func Min[KT, VT any, O cmp.Ordered](db DB[KT, VT], extract func(key KT, val VT) O) (O, bool, error) {
+	return extreme(db, extract, func(a, b O) bool { return a < b })
+}
+
+// Max returns the largest value of extract over all records, reporting false
+// when the store is empty.
+func Max[KT, VT any, O cmp.Ordered](db DB[KT, VT], extract func(key KT, val VT) O) (O, bool, error) {
+	return extreme(db, extract, func(a, b O) bool { return a > b })
+}
+
+// extreme folds extract with the given ordering, tracking whether any record
+// was seen at all.
+func extreme[KT, VT any, O cmp.Ordered](db DB[KT, VT], extract func(key KT, val VT) O, better func(a, b O) bool) (O, bool, error) {
+	type best struct {
+		v  O
+		ok bool
+	}
+	pick := func(a, b best) best {
+		switch {
+		case !a.ok:
+			return b
+		case !b.ok:
+			return a
+		case better(b.v, a.v):
+			return b
+		}
+		return a
+	}
+
+	b, err := aggregate(db, best{},
+		func(acc best, key KT, val VT) best {
+			return pick(acc, best{v: extract(key, val), ok: true})
+		},
+		pick)
+	return b.v, b.ok, err
+}
+
+// aggregate fans the raw records out to one decoding worker per CPU, folds
+// each shard with fold and combines the partial accumulators with merge.
+// Records that fail to decode fail the aggregation: a silently skipped
+// record would make every numeric result quietly wrong.
+func aggregate[KT, VT, A any](db DB[KT, VT], zero A, fold func(A, KT, VT) A, merge func(A, A) A) (A, error) {
+	if err := db.closedErr(); err != nil {
+		return zero, err
+	}
+
+	type pair struct{ kb, vb []byte }
+	var (
+		workers = runtime.GOMAXPROCS(0)
+		pairs   = make(chan pair, workers*4)
+		parts   = make([]A, workers)
+		errs    = make([]error, workers)
+		wg      sync.WaitGroup
+	)
+
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			acc := zero
+			for p := range pairs {
+				key, err := db.keyCodec.Decode(p.kb)
+				if err != nil {
+					errs[w] = errors.Join(errs[w], err)
+					continue
+				}
+				val, err := db.valCodec.Decode(p.vb)
+				if err != nil {
+					errs[w] = errors.Join(errs[w], err)
+					continue
+				}
+				acc = fold(acc, key, val)
+			}
+			parts[w] = acc
+		}()
+	}
+
+	for kb, vb := range db.RawItems() {
+		pairs <- pair{kb: slices.Clone(kb), vb: slices.Clone(vb)}
+	}
+	close(pairs)
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return zero, err
+	}
+	acc := parts[0]
+	for _, p := range parts[1:] {
+		acc = merge(acc, p)
+	}
+	return acc, nil
+}
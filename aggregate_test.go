@@ -0,0 +1,105 @@
+package katalis_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAggDB(t *testing.T) katalis.DB[string, int] {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	for i := 1; i <= 100; i++ {
+		require.NoError(t, db.Put(fmt.Sprintf("k%03d", i), i))
+	}
+	return db
+}
+
+func TestCount(t *testing.T) {
+	db := newAggDB(t)
+
+	n, err := katalis.Count(db, func(k string, v int) bool { return v%2 == 0 })
+	require.NoError(t, err)
+	assert.Equal(t, 50, n)
+
+	// A nil predicate counts everything.
+	n, err = katalis.Count[string, int](db, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 100, n)
+}
+
+func TestSum(t *testing.T) {
+	db := newAggDB(t)
+
+	sum, err := katalis.Sum(db, func(k string, v int) int { return v })
+	require.NoError(t, err)
+	assert.Equal(t, 5050, sum)
+
+	// Predicates fold into the extractor.
+	sum, err = katalis.Sum(db, func(k string, v int) int {
+		if strings.HasPrefix(k, "k00") {
+			return v
+		}
+		return 0
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 45, sum)
+}
+
+func TestAvg(t *testing.T) {
+	db := newAggDB(t)
+
+	avg, err := katalis.Avg(db, func(k string, v int) int { return v })
+	require.NoError(t, err)
+	assert.InDelta(t, 50.5, avg, 1e-9)
+}
+
+func TestMinMax(t *testing.T) {
+	db := newAggDB(t)
+
+	lo, ok, err := katalis.Min(db, func(k string, v int) int { return v })
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 1, lo)
+
+	hi, ok, err := katalis.Max(db, func(k string, v int) int { return v })
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 100, hi)
+
+	// Extractors are not limited to numbers.
+	last, ok, err := katalis.Max(db, func(k string, v int) string { return k })
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "k100", last)
+}
+
+func TestAggregateEmptyStore(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	n, err := katalis.Count[string, int](db, nil)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	avg, err := katalis.Avg(db, func(k string, v int) int { return v })
+	require.NoError(t, err)
+	assert.Zero(t, avg)
+
+	_, ok, err := katalis.Min(db, func(k string, v int) int { return v })
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
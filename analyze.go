@@ -0,0 +1,138 @@
+package katalis
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"math/bits"
+	"slices"
+)
+
+// analyzeTopN is how many of the largest values an AnalyzeReport keeps.
+const analyzeTopN = 10
+
+// SizeHistogram counts sizes in power-of-two buckets: Buckets[i] is the
+// number of sizes in [2^(i-1)+1, 2^i] bytes, with Buckets[0] counting empty
+// and single-byte sizes.
+type SizeHistogram struct {
+	Buckets [32]uint64
+}
+
+// add records one size in the histogram.
+func (h *SizeHistogram) add(size int) {
+	if size <= 1 {
+		h.Buckets[0]++
+		return
+	}
+	h.Buckets[bits.Len(uint(size-1))]++
+}
+
+// RecordSize identifies a record by its encoded key together with its value
+// size.
+type RecordSize struct {
+	Key       []byte
+	ValueSize int
+}
+
+// AnalyzeReport summarizes the size distribution of a store's records and
+// how much smaller the values would be if compressed.
+type AnalyzeReport struct {
+	// Records is the number of records walked.
+	Records uint64
+	// KeyBytes and ValueBytes are the total encoded sizes.
+	KeyBytes   uint64
+	ValueBytes uint64
+	// KeySizes and ValueSizes distribute the sizes over power-of-two
+	// buckets.
+	KeySizes   SizeHistogram
+	ValueSizes SizeHistogram
+	// Largest lists the records with the biggest values, in descending
+	// value-size order, at most ten of them.
+	Largest []RecordSize
+	// CompressedValueBytes is the total size of the values after DEFLATE
+	// compression, the evidence for or against a compression wrapper.
+	CompressedValueBytes uint64
+}
+
+// CompressionSavings returns the fraction of value bytes compression would
+// save, between 0 and 1. It is 0 for an empty store.
+func (r AnalyzeReport) CompressionSavings() float64 {
+	if r.ValueBytes == 0 {
+		return 0
+	}
+	return 1 - float64(r.CompressedValueBytes)/float64(r.ValueBytes)
+}
+
+// Analyze walks every record computing key and value size histograms, the
+// largest values, and how much space compressing the values would save. Each
+// value is actually run through DEFLATE, so on a big store this is a
+// CPU-heavy full scan; cancel ctx to stop early and discard the partial
+// result.
+func (db DB[KT, VT]) Analyze(ctx context.Context) (AnalyzeReport, error) {
+	if err := db.closedErr(); err != nil {
+		return AnalyzeReport{}, err
+	}
+
+	var (
+		report AnalyzeReport
+		buf    bytes.Buffer
+	)
+	fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return AnalyzeReport{}, err
+	}
+
+	it := db.DB.Items()
+	for {
+		if err := ctx.Err(); err != nil {
+			return AnalyzeReport{}, err
+		}
+
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			return report, nil
+		}
+		if err != nil {
+			return AnalyzeReport{}, err
+		}
+		if !db.inView(kb) {
+			continue
+		}
+
+		report.Records++
+		report.KeyBytes += uint64(len(kb))
+		report.ValueBytes += uint64(len(vb))
+		report.KeySizes.add(len(kb))
+		report.ValueSizes.add(len(vb))
+
+		buf.Reset()
+		fw.Reset(&buf)
+		if _, err := fw.Write(vb); err != nil {
+			return AnalyzeReport{}, err
+		}
+		if err := fw.Close(); err != nil {
+			return AnalyzeReport{}, err
+		}
+		report.CompressedValueBytes += uint64(buf.Len())
+
+		report.Largest = topRecords(report.Largest, kb, len(vb))
+	}
+}
+
+// topRecords inserts the record in the descending top-N list, dropping the
+// smallest entry once the list is full.
+func topRecords(top []RecordSize, kb []byte, size int) []RecordSize {
+	if len(top) == analyzeTopN && size <= top[len(top)-1].ValueSize {
+		return top
+	}
+
+	rec := RecordSize{Key: bytes.Clone(kb), ValueSize: size}
+	i, _ := slices.BinarySearchFunc(top, rec, func(a, b RecordSize) int {
+		return b.ValueSize - a.ValueSize
+	})
+	top = slices.Insert(top, i, rec)
+	if len(top) > analyzeTopN {
+		top = top[:analyzeTopN]
+	}
+	return top
+}
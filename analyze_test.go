@@ -0,0 +1,74 @@
+package katalis_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("small", "x"))
+	require.NoError(t, db.Put("medium", strings.Repeat("y", 100)))
+	require.NoError(t, db.Put("big", strings.Repeat("z", 10000)))
+
+	report, err := db.Analyze(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(3), report.Records)
+	assert.Equal(t, uint64(len("small")+len("medium")+len("big")), report.KeyBytes)
+	assert.Equal(t, uint64(1+100+10000), report.ValueBytes)
+
+	// Sizes 1, 100 and 10000 land in buckets 0, 7 and 14.
+	assert.Equal(t, uint64(1), report.ValueSizes.Buckets[0])
+	assert.Equal(t, uint64(1), report.ValueSizes.Buckets[7])
+	assert.Equal(t, uint64(1), report.ValueSizes.Buckets[14])
+
+	// The largest values come first.
+	require.Len(t, report.Largest, 3)
+	assert.Equal(t, []byte("big"), report.Largest[0].Key)
+	assert.Equal(t, 10000, report.Largest[0].ValueSize)
+	assert.Equal(t, []byte("medium"), report.Largest[1].Key)
+
+	// Highly repetitive values compress well.
+	assert.Less(t, report.CompressedValueBytes, report.ValueBytes)
+	assert.Greater(t, report.CompressionSavings(), 0.5)
+}
+
+func TestAnalyzeTopN(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 20 {
+		require.NoError(t, db.Put(i, strings.Repeat("v", i+1)))
+	}
+
+	report, err := db.Analyze(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, report.Largest, 10)
+	assert.Equal(t, 20, report.Largest[0].ValueSize)
+	assert.Equal(t, 11, report.Largest[9].ValueSize)
+}
+
+func TestAnalyzeEmptyAndClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	report, err := db.Analyze(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, report.Records)
+	assert.Zero(t, report.CompressionSavings())
+
+	require.NoError(t, db.Close())
+	_, err = db.Analyze(context.Background())
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+}
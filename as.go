@@ -0,0 +1,49 @@
+package katalis
+
+import "reflect"
+
+// As returns a codec for a defined type over a common primitive, e.g.
+// `type UserID string` or `type Version uint64`, sparing the three-line
+// wrapper codec each such type would otherwise need. The encoding matches the
+// corresponding primitive codec. The optional variadic parameter allows type
+// inference from a value.
+func As[T ~string | ~int64 | ~uint64 | ~[]byte](_ ...T) (a AsCodec[T]) { return }
+
+// AsCodec is the generic codec returned by As.
+type AsCodec[T ~string | ~int64 | ~uint64 | ~[]byte] struct{}
+
+// Encode serializes the value with the codec of its underlying type.
+func (ac AsCodec[T]) Encode(t T) ([]byte, error) {
+	switch v := reflect.ValueOf(t); v.Kind() {
+	case reflect.Int64:
+		return Int64Codec.Encode(v.Int())
+	case reflect.Uint64:
+		return Uint64Codec.Encode(v.Uint())
+	case reflect.Slice:
+		return v.Bytes(), nil
+	default:
+		return []byte(v.String()), nil
+	}
+}
+
+// Decode deserializes the value with the codec of its underlying type.
+func (ac AsCodec[T]) Decode(b []byte) (t T, err error) {
+	v := reflect.ValueOf(&t).Elem()
+
+	switch v.Kind() {
+	case reflect.Int64:
+		i, err := Int64Codec.Decode(b)
+		v.SetInt(i)
+		return t, err
+	case reflect.Uint64:
+		u, err := Uint64Codec.Decode(b)
+		v.SetUint(u)
+		return t, err
+	case reflect.Slice:
+		v.SetBytes(b)
+		return t, nil
+	default:
+		v.SetString(string(b))
+		return t, nil
+	}
+}
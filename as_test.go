@@ -0,0 +1,75 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	userID  string
+	version uint64
+	offset  int64
+	blob    []byte
+)
+
+func TestAsCodecString(t *testing.T) {
+	codec := katalis.As[userID]()
+
+	encoded, err := codec.Encode(userID("user-42"))
+	require.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, userID("user-42"), decoded)
+}
+
+func TestAsCodecUint64(t *testing.T) {
+	codec := katalis.As[version]()
+
+	encoded, err := codec.Encode(version(7))
+	require.NoError(t, err)
+	assert.Len(t, encoded, 8)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, version(7), decoded)
+}
+
+func TestAsCodecInt64(t *testing.T) {
+	codec := katalis.As[offset]()
+
+	encoded, err := codec.Encode(offset(-99))
+	require.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, offset(-99), decoded)
+}
+
+func TestAsCodecBytes(t *testing.T) {
+	codec := katalis.As[blob]()
+
+	encoded, err := codec.Encode(blob{1, 2, 3})
+	require.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, blob{1, 2, 3}, decoded)
+}
+
+func TestAsCodecInDB(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.As[userID](), katalis.As[version]())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("alice", 3))
+
+	val, err := db.Get("alice")
+	require.NoError(t, err)
+	assert.Equal(t, version(3), val)
+}
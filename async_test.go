@@ -0,0 +1,70 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutAsync(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	dones := make([]<-chan error, 0, 100)
+	for i := range 100 {
+		dones = append(dones, db.PutAsync(fmt.Sprintf("key-%d", i), i))
+	}
+	for _, done := range dones {
+		require.NoError(t, <-done)
+	}
+
+	for i := range 100 {
+		val, err := db.Get(fmt.Sprintf("key-%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
+
+func TestPutAsyncFlushedByClose(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	done := db.PutAsync("key", 42)
+	require.NoError(t, db.Close())
+	require.NoError(t, <-done)
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	val, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+}
+
+func TestPutAsyncAfterClose(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	assert.ErrorIs(t, <-db.PutAsync("key", 1), katalis.ErrClosed)
+}
+
+func TestPutAsyncReportsErrors(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.StringCodec,
+		katalis.WithMaxValueSize(4),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.ErrorIs(t, <-db.PutAsync("key", "way too large"), katalis.ErrTooLarge)
+}
@@ -0,0 +1,82 @@
+package katalis
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"time"
+)
+
+// CodecFor returns the predefined codec for type T, falling back to gob for
+// structs and other composite types. It returns an error for types that
+// cannot be encoded at all, such as channels and functions.
+func CodecFor[T any]() (Codec[T], error) {
+	var t T
+
+	switch any(t).(type) {
+	case string:
+		return any(StringCodec).(Codec[T]), nil
+	case []byte:
+		return any(BytesCodec).(Codec[T]), nil
+	case int:
+		return any(IntCodec).(Codec[T]), nil
+	case int16:
+		return any(Int16Codec).(Codec[T]), nil
+	case int32:
+		return any(Int32Codec).(Codec[T]), nil
+	case int64:
+		return any(Int64Codec).(Codec[T]), nil
+	case uint:
+		return any(UintCodec).(Codec[T]), nil
+	case uint16:
+		return any(Uint16Codec).(Codec[T]), nil
+	case uint32:
+		return any(Uint32Codec).(Codec[T]), nil
+	case uint64:
+		return any(Uint64Codec).(Codec[T]), nil
+	case float32:
+		return any(Float32Codec).(Codec[T]), nil
+	case float64:
+		return any(Float64Codec).(Codec[T]), nil
+	case bool:
+		return any(BoolCodec).(Codec[T]), nil
+	case complex64:
+		return any(Complex64Codec).(Codec[T]), nil
+	case complex128:
+		return any(Complex128Codec).(Codec[T]), nil
+	case time.Time:
+		return any(TimeCodec).(Codec[T]), nil
+	case time.Duration:
+		return any(DurationCodec).(Codec[T]), nil
+	case netip.Addr:
+		return any(AddrCodec).(Codec[T]), nil
+	case netip.Prefix:
+		return any(PrefixCodec).(Codec[T]), nil
+	case *big.Int:
+		return any(BigIntCodec).(Codec[T]), nil
+	case *big.Rat:
+		return any(BigRatCodec).(Codec[T]), nil
+	}
+
+	switch reflect.TypeOf(&t).Elem().Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return nil, fmt.Errorf("katalis: no codec for type %T", t)
+	}
+	return GobCodec[T]{}, nil
+}
+
+// OpenAuto is like Open but selects the key and value codecs automatically
+// with CodecFor.
+func OpenAuto[KT, VT any](path string) (db DB[KT, VT], err error) {
+	keyCodec, err := CodecFor[KT]()
+	if err != nil {
+		return db, err
+	}
+
+	valCodec, err := CodecFor[VT]()
+	if err != nil {
+		return db, err
+	}
+	return Open(path, keyCodec, valCodec)
+}
@@ -0,0 +1,85 @@
+package katalis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecFor(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		codec, err := katalis.CodecFor[string]()
+		require.NoError(t, err)
+
+		b, err := codec.Encode("hello")
+		require.NoError(t, err)
+
+		s, err := codec.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", s)
+	})
+
+	t.Run("time", func(t *testing.T) {
+		codec, err := katalis.CodecFor[time.Time]()
+		require.NoError(t, err)
+
+		now := time.Now()
+		b, err := codec.Encode(now)
+		require.NoError(t, err)
+
+		decoded, err := codec.Decode(b)
+		require.NoError(t, err)
+		assert.True(t, now.Equal(decoded))
+	})
+
+	t.Run("struct falls back to gob", func(t *testing.T) {
+		type point struct{ X, Y int }
+
+		codec, err := katalis.CodecFor[point]()
+		require.NoError(t, err)
+
+		b, err := codec.Encode(point{X: 1, Y: 2})
+		require.NoError(t, err)
+
+		decoded, err := codec.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, point{X: 1, Y: 2}, decoded)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := katalis.CodecFor[chan int]()
+		assert.Error(t, err)
+
+		_, err = katalis.CodecFor[func()]()
+		assert.Error(t, err)
+	})
+}
+
+func TestOpenAuto(t *testing.T) {
+	dir := t.TempDir()
+
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	db, err := katalis.OpenAuto[string, user](dir + "/test.db")
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("alice", user{Name: "Alice", Age: 30}))
+
+	val, err := db.Get("alice")
+	require.NoError(t, err)
+	assert.Equal(t, user{Name: "Alice", Age: 30}, val)
+}
+
+func TestOpenAutoUnsupported(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := katalis.OpenAuto[chan int, string](dir + "/test.db")
+	assert.Error(t, err)
+}
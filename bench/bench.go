@@ -0,0 +1,110 @@
+// Package bench is a reproducible harness for measuring katalis throughput
+// and latency percentiles across codecs, value sizes and open options. It
+// backs the go test -bench suites and emits machine-readable results, so
+// performance claims can be checked in-tree instead of argued about.
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Result is the outcome of one measured operation loop.
+type Result struct {
+	// Name identifies the measurement, e.g. "put/gob/1KiB".
+	Name string `json:"name"`
+	// Ops is how many operations ran.
+	Ops int `json:"ops"`
+	// TotalNanos is the wall time of the whole loop.
+	TotalNanos int64 `json:"total_nanos"`
+	// OpsPerSec is the resulting throughput.
+	OpsPerSec float64 `json:"ops_per_sec"`
+	// P50Nanos, P90Nanos and P99Nanos are per-operation latency percentiles.
+	P50Nanos int64 `json:"p50_nanos"`
+	P90Nanos int64 `json:"p90_nanos"`
+	P99Nanos int64 `json:"p99_nanos"`
+}
+
+// Measure runs op n times, recording per-call latency, and aggregates the
+// run into a Result. It stops at the first error.
+func Measure(name string, n int, op func(i int) error) (Result, error) {
+	if n <= 0 {
+		return Result{}, fmt.Errorf("bench: op count must be positive, got %d", n)
+	}
+
+	lats := make([]time.Duration, n)
+	start := time.Now()
+	for i := range n {
+		opStart := time.Now()
+		if err := op(i); err != nil {
+			return Result{}, fmt.Errorf("bench: %s op %d: %w", name, i, err)
+		}
+		lats[i] = time.Since(opStart)
+	}
+	total := time.Since(start)
+
+	slices.Sort(lats)
+	return Result{
+		Name:       name,
+		Ops:        n,
+		TotalNanos: total.Nanoseconds(),
+		OpsPerSec:  float64(n) / total.Seconds(),
+		P50Nanos:   percentile(lats, 0.50).Nanoseconds(),
+		P90Nanos:   percentile(lats, 0.90).Nanoseconds(),
+		P99Nanos:   percentile(lats, 0.99).Nanoseconds(),
+	}, nil
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// Suite measures Put, Get and Fold over n entries produced by gen, which maps
+// an index to the key-value pair to use. The store is left populated.
+func Suite[KT, VT any](db katalis.DB[KT, VT], gen func(i int) (KT, VT), n int) ([]Result, error) {
+	var results []Result
+
+	put, err := Measure("put", n, func(i int) error {
+		key, val := gen(i)
+		return db.Put(key, val)
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, put)
+
+	get, err := Measure("get", n, func(i int) error {
+		key, _ := gen(i)
+		_, err := db.Get(key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, get)
+
+	fold, err := Measure("fold", 1, func(int) error {
+		return db.Fold(func(KT, VT, error) error { return nil })
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(results, fold), nil
+}
+
+// WriteJSON emits the results as indented JSON, one array for the whole run.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
@@ -0,0 +1,152 @@
+package bench_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/bench"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasure(t *testing.T) {
+	res, err := bench.Measure("noop", 100, func(int) error { return nil })
+	require.NoError(t, err)
+
+	assert.Equal(t, "noop", res.Name)
+	assert.Equal(t, 100, res.Ops)
+	assert.Positive(t, res.OpsPerSec)
+	assert.LessOrEqual(t, res.P50Nanos, res.P99Nanos)
+}
+
+func TestMeasureStopsOnError(t *testing.T) {
+	calls := 0
+	_, err := bench.Measure("failing", 10, func(i int) error {
+		calls++
+		if i == 3 {
+			return fmt.Errorf("boom")
+		}
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, 4, calls)
+}
+
+func TestSuiteWritesJSON(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	results, err := bench.Suite(db, func(i int) (int, string) {
+		return i, "value"
+	}, 50)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, bench.WriteJSON(&buf, results))
+
+	var decoded []bench.Result
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, results, decoded)
+}
+
+// benchValue returns a value of the given size for throughput benchmarks.
+func benchValue(size int) []byte {
+	return bytes.Repeat([]byte{'v'}, size)
+}
+
+func BenchmarkPut(b *testing.B) {
+	for _, size := range []int{16, 256, 4096} {
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			db, err := katalis.Open(b.TempDir()+"/bench.db", katalis.IntCodec, katalis.BytesCodec)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer db.Close()
+
+			val := benchValue(size)
+			b.SetBytes(int64(size))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := db.Put(i, val); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	for _, size := range []int{16, 4096} {
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			db, err := katalis.Open(b.TempDir()+"/bench.db", katalis.IntCodec, katalis.BytesCodec)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer db.Close()
+
+			const keys = 1024
+			val := benchValue(size)
+			for i := range keys {
+				if err := db.Put(i, val); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.SetBytes(int64(size))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := db.Get(i % keys); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPutGob(b *testing.B) {
+	type record struct {
+		Name  string
+		Count int
+	}
+
+	db, err := katalis.Open(b.TempDir()+"/bench.db", katalis.IntCodec, katalis.Gob[record]())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := db.Put(i, record{Name: "bench", Count: i}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFold(b *testing.B) {
+	db, err := katalis.Open(b.TempDir()+"/bench.db", katalis.IntCodec, katalis.BytesCodec)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	val := benchValue(64)
+	for i := range 4096 {
+		if err := db.Put(i, val); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := db.Fold(func(int, []byte, error) error { return nil })
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
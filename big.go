@@ -0,0 +1,41 @@
+package katalis
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Codecs for math/big types, encoded in their gob binary form. They spare
+// callers from wrapping arbitrary-precision values in GobCodec one at a time.
+var (
+	BigIntCodec = bigIntCodec{}
+	BigRatCodec = bigRatCodec{}
+)
+
+type bigIntCodec struct{}
+
+func (bc bigIntCodec) Encode(i *big.Int) ([]byte, error) {
+	if i == nil {
+		return nil, fmt.Errorf("katalis: cannot encode nil *big.Int")
+	}
+	return i.GobEncode()
+}
+
+func (bc bigIntCodec) Decode(b []byte) (*big.Int, error) {
+	i := new(big.Int)
+	return i, i.GobDecode(b)
+}
+
+type bigRatCodec struct{}
+
+func (bc bigRatCodec) Encode(r *big.Rat) ([]byte, error) {
+	if r == nil {
+		return nil, fmt.Errorf("katalis: cannot encode nil *big.Rat")
+	}
+	return r.GobEncode()
+}
+
+func (bc bigRatCodec) Decode(b []byte) (*big.Rat, error) {
+	r := new(big.Rat)
+	return r, r.GobDecode(b)
+}
@@ -0,0 +1,69 @@
+package katalis_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigIntCodec(t *testing.T) {
+	huge, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+
+	tests := []struct {
+		name  string
+		input *big.Int
+	}{
+		{"zero", big.NewInt(0)},
+		{"negative", big.NewInt(-42)},
+		{"beyond uint64", huge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := katalis.BigIntCodec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := katalis.BigIntCodec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Zero(t, tt.input.Cmp(decoded))
+		})
+	}
+}
+
+func TestBigIntCodecNil(t *testing.T) {
+	_, err := katalis.BigIntCodec.Encode(nil)
+	assert.Error(t, err)
+}
+
+func TestBigRatCodec(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *big.Rat
+	}{
+		{"zero", big.NewRat(0, 1)},
+		{"simple", big.NewRat(22, 7)},
+		{"negative", big.NewRat(-1, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := katalis.BigRatCodec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := katalis.BigRatCodec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Zero(t, tt.input.Cmp(decoded))
+		})
+	}
+}
+
+func TestBigRatCodecNil(t *testing.T) {
+	_, err := katalis.BigRatCodec.Encode(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package katalis
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// WithBloomFilter maintains an in-memory bloom filter over the keys, sized
+// for the expected number of keys and false-positive rate, so Has and Get of
+// absent keys return without touching disk. The filter is rebuilt from the
+// store on open and updated on every write, whichever API performs it —
+// transactions and the wrapper types included; deletes leave it untouched, which
+// only ever costs a spurious disk lookup, never a wrong answer. Worth it for
+// workloads dominated by negative lookups.
+func WithBloomFilter(expectedKeys int, fpRate float64) OpenOption {
+	return func(c *openConfig) {
+		c.bloom = newBloomFilter(expectedKeys, fpRate)
+	}
+}
+
+// bloomFilter is a classic bloom filter using double hashing over a single
+// 64-bit FNV-1a hash, safe for concurrent use.
+type bloomFilter struct {
+	mu   sync.RWMutex
+	bits []uint64
+	m    uint64 // number of bits
+	k    int    // number of hash functions
+}
+
+func newBloomFilter(n int, fp float64) *bloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	if fp <= 0 || fp >= 1 {
+		fp = 0.01
+	}
+
+	m := uint64(math.Ceil(-float64(n) * math.Log(fp) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+	k := max(1, int(math.Round(float64(m)/float64(n)*math.Ln2)))
+
+	return &bloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// indexes derives the k bit positions for b.
+func (f *bloomFilter) indexes(b []byte, visit func(idx uint64)) {
+	h := fnv.New64a()
+	h.Write(b)
+	sum := h.Sum64()
+
+	h1, h2 := sum&0xffffffff, sum>>32
+	for i := range uint64(f.k) {
+		visit((h1 + i*h2) % f.m)
+	}
+}
+
+func (f *bloomFilter) add(b []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.indexes(b, func(idx uint64) {
+		f.bits[idx/64] |= 1 << (idx % 64)
+	})
+}
+
+// mayContain reports whether b might be in the set. False means definitely
+// absent.
+func (f *bloomFilter) mayContain(b []byte) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	contained := true
+	f.indexes(b, func(idx uint64) {
+		if f.bits[idx/64]&(1<<(idx%64)) == 0 {
+			contained = false
+		}
+	})
+	return contained
+}
+
+// initBloom seeds the filter with every key already in the store.
+func (db *DB[KT, VT]) initBloom(f *bloomFilter) error {
+	db.bloom = f
+
+	it := db.DB.Items()
+	for {
+		kb, _, err := it.Next()
+		if isTerminate(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		f.add(kb)
+	}
+}
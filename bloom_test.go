@@ -0,0 +1,111 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilterLookups(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.IntCodec,
+		katalis.WithBloomFilter(1000, 0.01),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 100 {
+		require.NoError(t, db.Put(fmt.Sprintf("key-%d", i), i))
+	}
+
+	// Present keys still resolve through the filter.
+	for i := range 100 {
+		has, err := db.Has(fmt.Sprintf("key-%d", i))
+		require.NoError(t, err)
+		assert.True(t, has)
+
+		val, err := db.Get(fmt.Sprintf("key-%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+
+	// Absent keys answer negatively, mostly without disk access.
+	for i := 100; i < 200; i++ {
+		has, err := db.Has(fmt.Sprintf("key-%d", i))
+		require.NoError(t, err)
+		assert.False(t, has)
+	}
+}
+
+func TestBloomFilterRebuiltOnOpen(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("persisted", 42))
+	require.NoError(t, db.Close())
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec, katalis.WithBloomFilter(100, 0.01))
+	require.NoError(t, err)
+	defer db.Close()
+
+	val, err := db.Get("persisted")
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+}
+
+func TestBloomFilterDeleteStaysCorrect(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.IntCodec,
+		katalis.WithBloomFilter(100, 0.01),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 1))
+	require.NoError(t, db.Del("key"))
+
+	// The filter still says maybe-present, the store must say no.
+	has, err := db.Has("key")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestBloomFilterSeesAllWritePaths(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.IntCodec,
+		katalis.WithBloomFilter(100, 0.01),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Keys written behind Put's back must still be visible through the
+	// filter: a miss here is a silent false negative.
+	require.NoError(t, db.Tx(func(tx *katalis.Tx[string, int]) error {
+		return tx.Put("staged", 1)
+	}))
+	require.NoError(t, db.RawPut([]byte("raw"), []byte("x")))
+
+	val, err := db.Get("staged")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	has, err := db.Has("staged")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// StringCodec encodes "raw" to the same bytes RawPut stored it under, so
+	// the typed lookup goes through the filter.
+	has, err = db.Has("raw")
+	require.NoError(t, err)
+	assert.True(t, has)
+}
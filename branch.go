@@ -0,0 +1,195 @@
+package katalis
+
+import (
+	"iter"
+	"os"
+)
+
+// Delta record tags. Every record in a branch's delta store starts with one
+// of these, so a branch can shadow a parent record with a new value or with
+// its deletion.
+const (
+	branchPut       = 0
+	branchTombstone = 1
+)
+
+// BranchDB is a writable copy-on-write branch of a DB. Reads fall through to
+// the parent for keys the branch never touched, while writes and deletions
+// are tracked as deltas in a sibling store, leaving the parent untouched
+// until MergeBranch promotes them. The typical use is staging a risky bulk
+// edit: branch, mutate, validate, then merge or drop.
+//
+// A branch observes later parent writes to keys it did not shadow; it is a
+// delta, not a point-in-time snapshot. For a frozen view see Snapshot.
+type BranchDB[KT, VT any] struct {
+	parent DB[KT, VT]
+	delta  DB[[]byte, []byte] // encoded key -> tag byte + encoded value
+}
+
+// Branch opens the named copy-on-write branch of the DB, creating it on
+// first use. The deltas live in a sibling store at path + ".branch-" + name,
+// so a branch survives process restarts until it is merged or dropped. Only
+// one handle per branch may be open at a time.
+func (db DB[KT, VT]) Branch(name string) (BranchDB[KT, VT], error) {
+	if err := db.closedErr(); err != nil {
+		return BranchDB[KT, VT]{}, err
+	}
+
+	delta, err := Open(db.path+".branch-"+name, BytesCodec, BytesCodec)
+	if err != nil {
+		return BranchDB[KT, VT]{}, err
+	}
+	return BranchDB[KT, VT]{parent: db, delta: delta}, nil
+}
+
+// Get returns the value for the given key as seen by the branch: the staged
+// value if the branch wrote it, nothing if the branch deleted it, the
+// parent's value otherwise.
+func (b BranchDB[KT, VT]) Get(key KT) (res VT, err error) {
+	kb, err := b.parent.keyCodec.Encode(key)
+	if err != nil {
+		return res, err
+	}
+
+	rec, err := b.delta.Get(kb)
+	if err != nil {
+		return res, err
+	}
+	switch {
+	case len(rec) == 0:
+		return b.parent.Get(key)
+	case rec[0] == branchTombstone:
+		// Deleted on the branch: decode the zero record, exactly like a
+		// Get on a key the parent never had.
+		return b.parent.valCodec.Decode(nil)
+	default:
+		return b.parent.valCodec.Decode(rec[1:])
+	}
+}
+
+// Has returns true if the key exists as seen by the branch.
+func (b BranchDB[KT, VT]) Has(key KT) (bool, error) {
+	kb, err := b.parent.keyCodec.Encode(key)
+	if err != nil {
+		return false, err
+	}
+
+	rec, err := b.delta.Get(kb)
+	if err != nil {
+		return false, err
+	}
+	if len(rec) > 0 {
+		return rec[0] != branchTombstone, nil
+	}
+	return b.parent.Has(key)
+}
+
+// Put stages the value for the given key on the branch. The parent is not
+// modified.
+func (b BranchDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := b.parent.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := b.parent.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+	return b.delta.Put(kb, append([]byte{branchPut}, vb...))
+}
+
+// Del stages the deletion of the given key on the branch. The parent is not
+// modified.
+func (b BranchDB[KT, VT]) Del(key KT) error {
+	kb, err := b.parent.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+	return b.delta.Put(kb, []byte{branchTombstone})
+}
+
+// Items returns an iterator over the branch's merged view: staged writes
+// shadow the parent's records and staged deletions hide them. Iteration
+// order is not specified. Errors terminate the iteration silently; use
+// AllItems on the parent if errors matter.
+func (b BranchDB[KT, VT]) Items() iter.Seq2[KT, VT] {
+	return func(yield func(KT, VT) bool) {
+		shadowed := make(map[string]bool)
+		for kb, rec := range b.delta.Items() {
+			shadowed[string(kb)] = true
+			if rec[0] == branchTombstone {
+				continue
+			}
+
+			key, err := b.parent.keyCodec.Decode(kb)
+			if err != nil {
+				return
+			}
+			val, err := b.parent.valCodec.Decode(rec[1:])
+			if err != nil {
+				return
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+
+		for kb, vb := range b.parent.RawItems() {
+			if shadowed[string(kb)] {
+				continue
+			}
+
+			key, err := b.parent.keyCodec.Decode(kb)
+			if err != nil {
+				return
+			}
+			val, err := b.parent.valCodec.Decode(vb)
+			if err != nil {
+				return
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+	}
+}
+
+// MergeBranch promotes the branch into the parent, applying every staged
+// write and deletion, then removes the delta store. The branch handle is
+// unusable afterwards. Deltas are applied one by one, so readers of the
+// parent may observe a partially merged state while the merge runs.
+func (b BranchDB[KT, VT]) MergeBranch() error {
+	for kb, rec := range b.delta.Items() {
+		if rec[0] == branchTombstone {
+			if err := b.parent.rawDel(kb); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := b.parent.rawPut(kb, rec[1:]); err != nil {
+			return err
+		}
+	}
+
+	if err := b.parent.DB.Sync(); err != nil {
+		return err
+	}
+	return b.DropBranch()
+}
+
+// DropBranch discards every staged delta and removes the delta store. The
+// branch handle is unusable afterwards; the parent is left as it was.
+func (b BranchDB[KT, VT]) DropBranch() error {
+	path := b.delta.path
+	if err := b.delta.Close(); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// Close closes the branch without merging or dropping it; the staged deltas
+// remain on disk and reopen with the next Branch call of the same name.
+func (b BranchDB[KT, VT]) Close() error {
+	return b.delta.Close()
+}
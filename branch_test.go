@@ -0,0 +1,151 @@
+package katalis_test
+
+import (
+	"maps"
+	"os"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchShadowsParent(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	br, err := db.Branch("edit")
+	require.NoError(t, err)
+	defer br.Close()
+
+	require.NoError(t, br.Put("a", 10))
+	require.NoError(t, br.Put("c", 3))
+	require.NoError(t, br.Del("b"))
+
+	// The branch sees its own deltas over the parent's data.
+	v, err := br.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 10, v)
+	v, err = br.Get("c")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	has, err := br.Has("b")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// The parent is untouched.
+	v, err = db.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	has, err = db.Has("b")
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = db.Has("c")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestBranchItems(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	br, err := db.Branch("edit")
+	require.NoError(t, err)
+	defer br.Close()
+
+	require.NoError(t, br.Put("a", 10))
+	require.NoError(t, br.Del("b"))
+	require.NoError(t, br.Put("c", 3))
+
+	got := maps.Collect(br.Items())
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, got)
+}
+
+func TestBranchMerge(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	br, err := db.Branch("edit")
+	require.NoError(t, err)
+	require.NoError(t, br.Put("a", 10))
+	require.NoError(t, br.Del("b"))
+	require.NoError(t, br.Put("c", 3))
+
+	require.NoError(t, br.MergeBranch())
+
+	got := maps.Collect(db.Items())
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, got)
+
+	// The delta store is gone.
+	_, err = os.Stat(db.Path() + ".branch-edit")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBranchDrop(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+
+	br, err := db.Branch("edit")
+	require.NoError(t, err)
+	require.NoError(t, br.Put("a", 10))
+	require.NoError(t, br.DropBranch())
+
+	v, err := db.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	_, err = os.Stat(db.Path() + ".branch-edit")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBranchReopens(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	br, err := db.Branch("edit")
+	require.NoError(t, err)
+	require.NoError(t, br.Put("a", 10))
+	require.NoError(t, br.Close())
+
+	// Deltas staged before Close survive a reopen of the same branch.
+	br, err = db.Branch("edit")
+	require.NoError(t, err)
+	defer br.DropBranch()
+
+	v, err := br.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 10, v)
+}
+
+func TestBranchSeesLaterParentWrites(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	br, err := db.Branch("edit")
+	require.NoError(t, err)
+	defer br.DropBranch()
+
+	require.NoError(t, db.Put("a", 1))
+
+	v, err := br.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
@@ -0,0 +1,83 @@
+package katalis
+
+import "slices"
+
+// bulkFlushSize is how many buffered bytes a BulkLoader accumulates before
+// writing them out in one pass.
+const bulkFlushSize = 8 << 20
+
+// BulkLoader ingests large initial imports far faster than repeated Put
+// calls: entries are encoded up front, buffered in big batches, written in
+// one pass per batch and fsynced exactly once on Close. Entries may arrive
+// in any order and later entries for the same key win. The loader skips the
+// per-write quota and rate-limit checks, so use it for initial imports, not
+// steady-state traffic. It is not safe for concurrent use. For the writes to
+// really bypass per-write fsyncs, open the store with WithDurability(Never)
+// or Interval.
+type BulkLoader[KT, VT any] struct {
+	db      DB[KT, VT]
+	pending map[string][]byte // encoded key -> encoded value, last wins
+	size    int
+}
+
+// BulkLoader returns a loader writing into db.
+func (db DB[KT, VT]) BulkLoader() *BulkLoader[KT, VT] {
+	return &BulkLoader[KT, VT]{
+		db:      db,
+		pending: make(map[string][]byte),
+	}
+}
+
+// Add buffers one entry, flushing the batch to disk when it grows past the
+// internal buffer size.
+func (l *BulkLoader[KT, VT]) Add(key KT, val VT) error {
+	if err := l.db.closedErr(); err != nil {
+		return err
+	}
+
+	kb, err := l.db.keyCodec.Encode(key)
+	if err != nil {
+		return opErr("bulkload", key, KindEncodeKey, err)
+	}
+	vb, err := l.db.valCodec.Encode(val)
+	if err != nil {
+		return opErr("bulkload", key, KindEncodeValue, err)
+	}
+	if err := l.db.checkLimits(key, kb, vb); err != nil {
+		return err
+	}
+
+	ks := string(kb)
+	if old, ok := l.pending[ks]; ok {
+		l.size -= len(ks) + len(old)
+	}
+	l.pending[ks] = slices.Clone(vb)
+	l.size += len(ks) + len(vb)
+
+	if l.size >= bulkFlushSize {
+		return l.Flush()
+	}
+	return nil
+}
+
+// Flush writes the buffered entries without syncing. It is called
+// automatically when the buffer fills up; most callers only need Close.
+func (l *BulkLoader[KT, VT]) Flush() error {
+	for ks, vb := range l.pending {
+		kb := []byte(ks)
+		if err := l.db.rawPut(kb, vb); err != nil {
+			return opErr("bulkload", ks, KindIO, err)
+		}
+		delete(l.pending, ks)
+		l.size -= len(ks) + len(vb)
+	}
+	return nil
+}
+
+// Close flushes whatever is still buffered and fsyncs once.
+func (l *BulkLoader[KT, VT]) Close() error {
+	if err := l.Flush(); err != nil {
+		return err
+	}
+	return l.db.DB.Sync()
+}
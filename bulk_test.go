@@ -0,0 +1,65 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkLoader(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec, katalis.WithDurability(katalis.Never))
+	require.NoError(t, err)
+
+	loader := db.BulkLoader()
+	for i := range 1000 {
+		require.NoError(t, loader.Add(fmt.Sprintf("key-%d", i), i))
+	}
+	require.NoError(t, loader.Close())
+	require.NoError(t, db.Close())
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, uint32(1000), db.Count())
+
+	val, err := db.Get("key-512")
+	require.NoError(t, err)
+	assert.Equal(t, 512, val)
+}
+
+func TestBulkLoaderLastWriteWins(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	loader := db.BulkLoader()
+	require.NoError(t, loader.Add("key", 1))
+	require.NoError(t, loader.Add("key", 2))
+	require.NoError(t, loader.Close())
+
+	val, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 2, val)
+	assert.Equal(t, uint32(1), db.Count())
+}
+
+func TestBulkLoaderRespectsLimits(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.StringCodec,
+		katalis.WithMaxValueSize(4),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	loader := db.BulkLoader()
+	assert.ErrorIs(t, loader.Add("key", "way too large"), katalis.ErrTooLarge)
+	require.NoError(t, loader.Close())
+}
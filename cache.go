@@ -0,0 +1,186 @@
+package katalis
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is a DB with a bounded approximate on-disk footprint. When a Put
+// pushes the total size of the stored records past the budget, the least
+// recently used entries are evicted until the cache fits again.
+type Cache[KT, VT any] struct {
+	db DB[KT, VT]
+
+	mu       sync.Mutex
+	maxBytes int64
+	total    int64
+	order    *list.List // front is most recently used
+	items    map[string]*list.Element
+}
+
+// cacheEntry is the bookkeeping record kept per key in the eviction list.
+type cacheEntry struct {
+	key  string
+	size int64
+}
+
+// OpenCache opens a DB at the given path that evicts least-recently-used
+// entries once the approximate on-disk footprint exceeds maxBytes. Entries
+// already stored at open count towards the budget, but their access order is
+// unknown and therefore arbitrary. The cache must be closed after use, by
+// calling Close.
+func OpenCache[KT, VT any](path string, keyCodec Codec[KT], valCodec Codec[VT], maxBytes int64) (*Cache[KT, VT], error) {
+	db, err := Open(path, keyCodec, valCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cache[KT, VT]{
+		db:       db,
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+
+	// Account for the records already on disk.
+	it := db.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			break
+		}
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		c.track(string(kb), entrySize(kb, vb))
+	}
+	return c, nil
+}
+
+// Put stores the value for the given key and evicts old entries if the cache
+// exceeds its size budget.
+func (c *Cache[KT, VT]) Put(key KT, val VT) error {
+	kb, err := c.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := c.db.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+
+	if err := c.db.rawPut(kb, vb); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.track(string(kb), entrySize(kb, vb))
+	return c.evict()
+}
+
+// Get returns the value for the given key and marks it as recently used.
+func (c *Cache[KT, VT]) Get(key KT) (res VT, err error) {
+	kb, err := c.db.keyCodec.Encode(key)
+	if err != nil {
+		return res, err
+	}
+
+	b, err := c.db.DB.Get(kb)
+	if err != nil {
+		return res, err
+	}
+
+	c.mu.Lock()
+	if el, ok := c.items[string(kb)]; ok {
+		c.order.MoveToFront(el)
+	}
+	c.mu.Unlock()
+
+	return c.db.valCodec.Decode(b)
+}
+
+// Has returns true if the cache contains the given key, without refreshing
+// its position in the eviction order.
+func (c *Cache[KT, VT]) Has(key KT) (bool, error) {
+	return c.db.Has(key)
+}
+
+// Del deletes the value for the given key.
+func (c *Cache[KT, VT]) Del(key KT) error {
+	kb, err := c.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	if err := c.db.rawDel(kb); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[string(kb)]; ok {
+		c.remove(el)
+	}
+	return nil
+}
+
+// Size returns the approximate on-disk footprint of the cached entries.
+func (c *Cache[KT, VT]) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// Close closes the underlying DB.
+func (c *Cache[KT, VT]) Close() error {
+	return c.db.Close()
+}
+
+// track records or refreshes the bookkeeping entry for an encoded key. The
+// caller must hold c.mu, except during OpenCache before the cache is shared.
+func (c *Cache[KT, VT]) track(key string, size int64) {
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		c.total += size - entry.size
+		entry.size = size
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, size: size})
+	c.total += size
+}
+
+// evict removes least-recently-used entries until the cache fits its budget.
+// The caller must hold c.mu.
+func (c *Cache[KT, VT]) evict() error {
+	for c.total > c.maxBytes {
+		el := c.order.Back()
+		if el == nil {
+			return nil
+		}
+
+		if err := c.db.rawDel([]byte(el.Value.(*cacheEntry).key)); err != nil {
+			return err
+		}
+		c.remove(el)
+	}
+	return nil
+}
+
+// remove drops a bookkeeping entry. The caller must hold c.mu.
+func (c *Cache[KT, VT]) remove(el *list.Element) {
+	entry := el.Value.(*cacheEntry)
+	c.order.Remove(el)
+	delete(c.items, entry.key)
+	c.total -= entry.size
+}
+
+// entrySize approximates the on-disk footprint of a record.
+func entrySize(kb, vb []byte) int64 {
+	return int64(len(kb) + len(vb))
+}
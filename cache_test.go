@@ -0,0 +1,82 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachePutAndGet(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := katalis.OpenCache(dir+"/cache.db", katalis.StringCodec, katalis.StringCodec, 1<<20)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.Put("k", "v"))
+
+	val, err := c.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, "v", val)
+}
+
+func TestCacheEvictsLRU(t *testing.T) {
+	dir := t.TempDir()
+
+	// Each entry is ~104 bytes, so the budget holds roughly 5 entries.
+	c, err := katalis.OpenCache(dir+"/cache.db", katalis.StringCodec, katalis.BytesCodec, 520)
+	require.NoError(t, err)
+	defer c.Close()
+
+	val := make([]byte, 100)
+	for i := range 5 {
+		require.NoError(t, c.Put(fmt.Sprintf("key%d", i), val))
+	}
+
+	// Touch key0 so key1 becomes the eviction candidate.
+	_, err = c.Get("key0")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Put("key5", val))
+
+	has, err := c.Has("key0")
+	require.NoError(t, err)
+	assert.True(t, has, "recently used entry should survive")
+
+	has, err = c.Has("key1")
+	require.NoError(t, err)
+	assert.False(t, has, "least recently used entry should be evicted")
+}
+
+func TestCacheSize(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := katalis.OpenCache(dir+"/cache.db", katalis.StringCodec, katalis.BytesCodec, 1<<20)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.Put("k", make([]byte, 99)))
+	assert.Equal(t, int64(100), c.Size())
+
+	require.NoError(t, c.Del("k"))
+	assert.Zero(t, c.Size())
+}
+
+func TestCacheAccountsExistingEntries(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/cache.db"
+
+	c, err := katalis.OpenCache(path, katalis.StringCodec, katalis.BytesCodec, 1<<20)
+	require.NoError(t, err)
+	require.NoError(t, c.Put("k", make([]byte, 99)))
+	require.NoError(t, c.Close())
+
+	c, err = katalis.OpenCache(path, katalis.StringCodec, katalis.BytesCodec, 1<<20)
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, int64(100), c.Size())
+}
@@ -0,0 +1,136 @@
+package katalis
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CachedDB wraps a DB with an in-process LRU cache of decoded values, so hot
+// keys skip both the disk read and the codec on repeated lookups. Writes go
+// through to the DB and refresh the cached entry, deletes invalidate it.
+//
+// The cache only sees operations performed through the wrapper: mutating the
+// underlying DB directly leaves stale entries behind.
+type CachedDB[KT, VT any] struct {
+	DB[KT, VT]
+
+	mu    sync.Mutex
+	max   int
+	order *list.List // front is most recently used
+	items map[string]*list.Element
+}
+
+// memEntry is the cached decoded value kept per encoded key.
+type memEntry[VT any] struct {
+	key string
+	val VT
+}
+
+// Cached wraps the DB with an in-memory read-through and write-through cache
+// holding up to maxEntries decoded values.
+func Cached[KT, VT any](db DB[KT, VT], maxEntries int) *CachedDB[KT, VT] {
+	return &CachedDB[KT, VT]{
+		DB:    db,
+		max:   maxEntries,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached value for the given key, reading through to the DB
+// and caching the decoded result on a miss.
+func (c *CachedDB[KT, VT]) Get(key KT) (res VT, err error) {
+	kb, err := c.keyCodec.Encode(key)
+	if err != nil {
+		return res, err
+	}
+
+	c.mu.Lock()
+	if el, ok := c.items[string(kb)]; ok {
+		c.order.MoveToFront(el)
+		res = el.Value.(*memEntry[VT]).val
+		c.mu.Unlock()
+		return res, nil
+	}
+	c.mu.Unlock()
+
+	b, err := c.DB.DB.Get(kb)
+	if err != nil {
+		return res, err
+	}
+
+	if res, err = c.valCodec.Decode(b); err != nil {
+		return res, err
+	}
+
+	c.store(string(kb), res)
+	return res, nil
+}
+
+// Put writes the value through to the DB and refreshes the cached entry.
+func (c *CachedDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := c.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := c.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DB.rawPut(kb, vb); err != nil {
+		return err
+	}
+
+	c.store(string(kb), val)
+	return nil
+}
+
+// Del deletes the value from the DB and invalidates the cached entry.
+func (c *CachedDB[KT, VT]) Del(key KT) error {
+	kb, err := c.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DB.rawDel(kb); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[string(kb)]; ok {
+		c.order.Remove(el)
+		delete(c.items, string(kb))
+	}
+	return nil
+}
+
+// Len returns the number of values currently cached in memory.
+func (c *CachedDB[KT, VT]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// store inserts or refreshes a cached value, evicting the least recently
+// used entry when the cache is full.
+func (c *CachedDB[KT, VT]) store(key string, val VT) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*memEntry[VT]).val = val
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&memEntry[VT]{key: key, val: val})
+	for len(c.items) > c.max {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.items, el.Value.(*memEntry[VT]).key)
+	}
+}
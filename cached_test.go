@@ -0,0 +1,92 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedReadThrough(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("k", 1))
+
+	c := katalis.Cached(db, 10)
+
+	val, err := c.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 1, c.Len())
+
+	// Served from cache even if the underlying record changes behind its back.
+	require.NoError(t, db.Put("k", 2))
+
+	val, err = c.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func TestCachedWriteThrough(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c := katalis.Cached(db, 10)
+	require.NoError(t, c.Put("k", 42))
+
+	// The write reached the underlying DB.
+	val, err := db.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+
+	val, err = c.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+}
+
+func TestCachedDelInvalidates(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c := katalis.Cached(db, 10)
+	require.NoError(t, c.Put("k", 42))
+	require.NoError(t, c.Del("k"))
+
+	assert.Zero(t, c.Len())
+
+	has, err := c.Has("k")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestCachedEvictsLRU(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c := katalis.Cached(db, 3)
+	for i := range 5 {
+		require.NoError(t, c.Put(fmt.Sprintf("key%d", i), i))
+	}
+
+	assert.Equal(t, 3, c.Len())
+
+	// Evicted entries are still readable from disk.
+	val, err := c.Get("key0")
+	require.NoError(t, err)
+	assert.Equal(t, 0, val)
+}
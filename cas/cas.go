@@ -0,0 +1,171 @@
+// Package cas implements a content-addressable blob store on top of katalis.
+// Blobs are keyed by the SHA-256 of their content, so identical data is
+// stored once, with reference counting and a garbage collector that drops
+// blobs nothing references anymore.
+package cas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Ref is the SHA-256 content address of a blob.
+type Ref [sha256.Size]byte
+
+// String returns the hexadecimal form of the ref.
+func (r Ref) String() string {
+	return hex.EncodeToString(r[:])
+}
+
+// ParseRef parses the hexadecimal form of a ref.
+func ParseRef(s string) (r Ref, err error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return r, err
+	}
+	if len(b) != sha256.Size {
+		return r, fmt.Errorf("cas: ref must be %d bytes, got %d", sha256.Size, len(b))
+	}
+	return Ref(b), nil
+}
+
+// ErrNotFound is returned when no blob is stored under the given ref.
+var ErrNotFound = errors.New("cas: blob not found")
+
+// Store is a content-addressable blob store backed by a katalis DB.
+type Store struct {
+	db katalis.DB[[]byte, []byte]
+}
+
+// Open opens or creates a blob store at the given path. The store must be
+// closed after use, by calling Close.
+func Open(path string) (*Store, error) {
+	db, err := katalis.Open(path, katalis.BytesCodec, katalis.BytesCodec)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying DB.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put stores the blob and returns its content address. Storing the same
+// content again does not duplicate the blob, it only increments its
+// reference count.
+func (s *Store) Put(data []byte) (Ref, error) {
+	ref := Ref(sha256.Sum256(data))
+
+	count, err := s.refCount(ref)
+	if err != nil {
+		return ref, err
+	}
+
+	if count == 0 {
+		if err := s.db.Put(blobKey(ref), data); err != nil {
+			return ref, err
+		}
+	}
+	return ref, s.setRefCount(ref, count+1)
+}
+
+// Get returns the blob stored under the given ref, or ErrNotFound.
+func (s *Store) Get(ref Ref) ([]byte, error) {
+	has, err := s.db.Has(blobKey(ref))
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotFound
+	}
+	return s.db.Get(blobKey(ref))
+}
+
+// Release decrements the reference count of the blob. Once the count reaches
+// zero the blob becomes eligible for collection by GC.
+func (s *Store) Release(ref Ref) error {
+	count, err := s.refCount(ref)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return s.setRefCount(ref, count-1)
+}
+
+// GC deletes all blobs whose reference count has dropped to zero and returns
+// how many were removed.
+func (s *Store) GC() (removed int, err error) {
+	var dead []Ref
+
+	err = s.db.Fold(func(key, val []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		if len(key) == sha256.Size+1 && key[0] == countPrefix {
+			count, err := katalis.Uint64Codec.Decode(val)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				dead = append(dead, Ref(key[1:]))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ref := range dead {
+		if err := s.db.Del(blobKey(ref)); err != nil {
+			return removed, err
+		}
+		if err := s.db.Del(countKey(ref)); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+const (
+	blobPrefix  = 'b'
+	countPrefix = 'r'
+)
+
+func blobKey(ref Ref) []byte {
+	return append([]byte{blobPrefix}, ref[:]...)
+}
+
+func countKey(ref Ref) []byte {
+	return append([]byte{countPrefix}, ref[:]...)
+}
+
+func (s *Store) refCount(ref Ref) (uint64, error) {
+	has, err := s.db.Has(countKey(ref))
+	if err != nil || !has {
+		return 0, err
+	}
+
+	b, err := s.db.Get(countKey(ref))
+	if err != nil {
+		return 0, err
+	}
+	return katalis.Uint64Codec.Decode(b)
+}
+
+func (s *Store) setRefCount(ref Ref, count uint64) error {
+	b, err := katalis.Uint64Codec.Encode(count)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(countKey(ref), b)
+}
@@ -0,0 +1,112 @@
+package cas_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis/cas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openStore(t *testing.T) *cas.Store {
+	t.Helper()
+
+	s, err := cas.Open(t.TempDir() + "/cas.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestPutAndGet(t *testing.T) {
+	s := openStore(t)
+
+	ref, err := s.Put([]byte("hello"))
+	require.NoError(t, err)
+
+	data, err := s.Get(ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), data)
+}
+
+func TestPutDeduplicates(t *testing.T) {
+	s := openStore(t)
+
+	ref1, err := s.Put([]byte("same"))
+	require.NoError(t, err)
+
+	ref2, err := s.Put([]byte("same"))
+	require.NoError(t, err)
+
+	assert.Equal(t, ref1, ref2)
+}
+
+func TestGetMissing(t *testing.T) {
+	s := openStore(t)
+
+	_, err := s.Get(cas.Ref{})
+	assert.ErrorIs(t, err, cas.ErrNotFound)
+}
+
+func TestReleaseAndGC(t *testing.T) {
+	s := openStore(t)
+
+	ref, err := s.Put([]byte("doomed"))
+	require.NoError(t, err)
+
+	kept, err := s.Put([]byte("kept"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Release(ref))
+
+	removed, err := s.GC()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, err = s.Get(ref)
+	assert.ErrorIs(t, err, cas.ErrNotFound)
+
+	data, err := s.Get(kept)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("kept"), data)
+}
+
+func TestGCKeepsReferencedDuplicates(t *testing.T) {
+	s := openStore(t)
+
+	ref, err := s.Put([]byte("twice"))
+	require.NoError(t, err)
+
+	_, err = s.Put([]byte("twice"))
+	require.NoError(t, err)
+
+	// One of the two references is gone, the blob must survive GC.
+	require.NoError(t, s.Release(ref))
+
+	removed, err := s.GC()
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	_, err = s.Get(ref)
+	require.NoError(t, err)
+}
+
+func TestReleaseMissing(t *testing.T) {
+	s := openStore(t)
+
+	err := s.Release(cas.Ref{})
+	assert.ErrorIs(t, err, cas.ErrNotFound)
+}
+
+func TestRefString(t *testing.T) {
+	s := openStore(t)
+
+	ref, err := s.Put([]byte("hex me"))
+	require.NoError(t, err)
+
+	parsed, err := cas.ParseRef(ref.String())
+	require.NoError(t, err)
+	assert.Equal(t, ref, parsed)
+
+	_, err = cas.ParseRef("nothex")
+	assert.Error(t, err)
+}
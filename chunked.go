@@ -0,0 +1,30 @@
+package katalis
+
+import "iter"
+
+// ItemsChunked returns an iterator over the records grouped into slices of
+// at most n entries, so consumers can feed downstream batch APIs without a
+// manual batching loop. The last chunk may be shorter; records that fail to
+// decode are silently skipped like in Items. Each yielded slice is reused
+// for the next chunk, so consumers that keep one must copy it.
+func (db DB[KT, VT]) ItemsChunked(n int) iter.Seq[[]Entry[KT, VT]] {
+	if n <= 0 || db.closedErr() != nil {
+		return func(yield func([]Entry[KT, VT]) bool) {}
+	}
+
+	return func(yield func([]Entry[KT, VT]) bool) {
+		chunk := make([]Entry[KT, VT], 0, n)
+		for key, val := range db.Items() {
+			chunk = append(chunk, Entry[KT, VT]{Key: key, Value: val})
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = chunk[:0]
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
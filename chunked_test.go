@@ -0,0 +1,78 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsChunked(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 10 {
+		require.NoError(t, db.Put(fmt.Sprintf("key%02d", i), i))
+	}
+
+	seen := make(map[string]int)
+	var sizes []int
+	for chunk := range db.ItemsChunked(3) {
+		sizes = append(sizes, len(chunk))
+		for _, e := range chunk {
+			seen[e.Key] = e.Value
+		}
+	}
+
+	// 10 records in chunks of 3: three full chunks and one of a single
+	// entry, order unspecified.
+	assert.Len(t, sizes, 4)
+	assert.Len(t, seen, 10)
+	for i := range 10 {
+		assert.Equal(t, i, seen[fmt.Sprintf("key%02d", i)])
+	}
+	full := 0
+	for _, n := range sizes {
+		assert.LessOrEqual(t, n, 3)
+		if n == 3 {
+			full++
+		}
+	}
+	assert.Equal(t, 3, full)
+}
+
+func TestItemsChunkedEarlyStop(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 10 {
+		require.NoError(t, db.Put(fmt.Sprintf("key%d", i), i))
+	}
+
+	chunks := 0
+	for range db.ItemsChunked(2) {
+		chunks++
+		break
+	}
+	assert.Equal(t, 1, chunks)
+}
+
+func TestItemsChunkedDegenerate(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Put("a", 1))
+
+	for range db.ItemsChunked(0) {
+		t.Fatal("chunk size 0 must yield nothing")
+	}
+
+	require.NoError(t, db.Close())
+	for range db.ItemsChunked(5) {
+		t.Fatal("ItemsChunked yielded on a closed DB")
+	}
+}
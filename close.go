@@ -0,0 +1,60 @@
+package katalis
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClosed is returned by operations on a DB that was already closed or
+// never opened successfully.
+var ErrClosed = errors.New("katalis: database closed")
+
+// Close flushes pending writes and closes the store. It is safe to call more
+// than once: every call after the first is a no-op returning nil. Once closed,
+// all operations on the DB return ErrClosed.
+func (db DB[KT, VT]) Close() error {
+	if db.DB == nil || db.closed.Swap(true) {
+		return nil
+	}
+	// Stop the background compaction scheduler and sync loop, then flush
+	// writes still queued by PutAsync before syncing.
+	db.stopCompactor()
+	db.stopSyncLoop()
+	db.shutdownAsync()
+	if err := db.DB.Sync(); err != nil {
+		return err
+	}
+	return db.DB.Close()
+}
+
+// CloseContext is like Close but gives up waiting when ctx expires, returning
+// its error. The flush keeps running in the background either way, so a
+// timed-out CloseContext still leaves the store shutting down cleanly.
+func (db DB[KT, VT]) CloseContext(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- db.Close()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsOpen reports whether the DB is usable: opened successfully and not yet
+// closed.
+func (db DB[KT, VT]) IsOpen() bool {
+	return db.closedErr() == nil
+}
+
+// closedErr returns ErrClosed if the DB is closed or was never opened, nil
+// otherwise.
+func (db DB[KT, VT]) closedErr() error {
+	if db.DB == nil || db.closed.Load() {
+		return ErrClosed
+	}
+	return nil
+}
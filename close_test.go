@@ -0,0 +1,116 @@
+package katalis_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseIdempotent(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("key", 42))
+
+	require.NoError(t, db.Close())
+	require.NoError(t, db.Close())
+}
+
+func TestOperationsAfterClose(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("key", 42))
+	require.NoError(t, db.Close())
+
+	_, err = db.Get("key")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+	assert.ErrorIs(t, db.Put("key", 1), katalis.ErrClosed)
+	assert.ErrorIs(t, db.Del("key"), katalis.ErrClosed)
+
+	_, err = db.Has("key")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+
+	err = db.Fold(func(key string, val int, err error) error { return err })
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+
+	for range db.Items() {
+		t.Fatal("Items yielded on a closed DB")
+	}
+
+	for _, err := range db.AllItems() {
+		assert.ErrorIs(t, err, katalis.ErrClosed)
+	}
+}
+
+func TestIsOpen(t *testing.T) {
+	var zero katalis.DB[string, int]
+	assert.False(t, zero.IsOpen())
+
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	assert.True(t, db.IsOpen())
+
+	require.NoError(t, db.Close())
+	assert.False(t, db.IsOpen())
+}
+
+func TestErrClosedEverywhere(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("key", 42))
+	require.NoError(t, db.Close())
+
+	_, err = db.Compact(context.Background(), nil)
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+	_, err = db.ReclaimableBytes()
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+
+	assert.ErrorIs(t, db.PutLarge("big", strings.NewReader("data")), katalis.ErrClosed)
+	_, err = db.GetLarge("big")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+	assert.ErrorIs(t, db.DelLarge("big"), katalis.ErrClosed)
+	_, err = db.NewWriter("big")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+	_, err = db.NewReader("big")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+
+	_, err = db.Lock("key")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+	_, _, err = db.TryLock("key")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+
+	assert.ErrorIs(t, db.WriteBackup(&strings.Builder{}), katalis.ErrClosed)
+	assert.ErrorIs(t, db.Snapshot(t.TempDir()+"/snap.db"), katalis.ErrClosed)
+
+	err = db.Tx(func(tx *katalis.Tx[string, int]) error {
+		t.Fatal("Tx callback ran on a closed DB")
+		return nil
+	})
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+
+	_, err = db.Verify(context.Background())
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+}
+
+func TestOperationsOnZeroDB(t *testing.T) {
+	var db katalis.DB[string, int]
+
+	assert.NotPanics(t, func() {
+		_, err := db.Get("key")
+		assert.ErrorIs(t, err, katalis.ErrClosed)
+		assert.ErrorIs(t, db.Put("key", 1), katalis.ErrClosed)
+		assert.NoError(t, db.Close())
+	})
+}
+
+func TestCloseContext(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("key", 42))
+
+	require.NoError(t, db.CloseContext(context.Background()))
+	assert.ErrorIs(t, db.Put("key", 1), katalis.ErrClosed)
+}
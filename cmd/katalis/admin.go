@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/NicoNex/katalis"
+)
+
+// progressRound keeps the compaction progress line readable.
+const progressRound = 100 * time.Millisecond
+
+// writeJSON renders v for the -json scripting output of the admin commands.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func cmdStats(db katalis.DB[[]byte, []byte], args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "machine-readable output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	size, err := db.FileSize()
+	if err != nil {
+		return err
+	}
+	reclaimable, err := db.ReclaimableBytes()
+	if err != nil {
+		return err
+	}
+
+	stats := struct {
+		Records          uint32 `json:"records"`
+		FileSizeBytes    int64  `json:"file_size_bytes"`
+		ReclaimableBytes int64  `json:"reclaimable_bytes"`
+	}{
+		Records:          db.Count(),
+		FileSizeBytes:    size,
+		ReclaimableBytes: reclaimable,
+	}
+
+	if *asJSON {
+		return writeJSON(stdout, stats)
+	}
+	_, err = fmt.Fprintf(stdout, "records:     %d\nfile size:   %d bytes\nreclaimable: %d bytes\n",
+		stats.Records, stats.FileSizeBytes, stats.ReclaimableBytes)
+	return err
+}
+
+func cmdVerify(db katalis.DB[[]byte, []byte], args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "machine-readable output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := db.Verify(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		out := struct {
+			Ok        bool     `json:"ok"`
+			Records   uint64   `json:"records"`
+			Corrupted uint64   `json:"corrupted"`
+			Errors    []string `json:"errors,omitempty"`
+		}{
+			Ok:        report.Ok(),
+			Records:   report.Records,
+			Corrupted: report.Corrupted,
+		}
+		for _, e := range report.Errors {
+			out.Errors = append(out.Errors, e.Error())
+		}
+		if err := writeJSON(stdout, out); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(stdout, "records:   %d\ncorrupted: %d\n", report.Records, report.Corrupted)
+		for _, e := range report.Errors {
+			fmt.Fprintln(stdout, " ", e)
+		}
+	}
+
+	if !report.Ok() {
+		return fmt.Errorf("store has %d corrupted records", report.Corrupted)
+	}
+	return nil
+}
+
+func cmdCompact(db katalis.DB[[]byte, []byte], args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("compact", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "machine-readable output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := db.Compact(context.Background(), func(p katalis.CompactionProgress) {
+		fmt.Fprintf(os.Stderr, "\rcompacting... %s elapsed, %d bytes on disk", p.Elapsed.Round(progressRound), p.FileSize)
+	})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return writeJSON(stdout, struct {
+			CompactedSegments int `json:"compacted_segments"`
+			ReclaimedRecords  int `json:"reclaimed_records"`
+			ReclaimedBytes    int `json:"reclaimed_bytes"`
+		}{
+			CompactedSegments: result.CompactedSegments,
+			ReclaimedRecords:  result.ReclaimedRecords,
+			ReclaimedBytes:    result.ReclaimedBytes,
+		})
+	}
+	_, err = fmt.Fprintf(stdout, "compacted segments: %d\nreclaimed records:  %d\nreclaimed bytes:    %d\n",
+		result.CompactedSegments, result.ReclaimedRecords, result.ReclaimedBytes)
+	return err
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+	require.NoError(t, run([]string{"-db", db, "put", "a", "1"}, nil))
+	require.NoError(t, run([]string{"-db", db, "put", "b", "2"}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", db, "stats"}, &out))
+	assert.Contains(t, out.String(), "records:     2")
+
+	out.Reset()
+	require.NoError(t, run([]string{"-db", db, "stats", "-json"}, &out))
+
+	var stats struct {
+		Records       uint32 `json:"records"`
+		FileSizeBytes int64  `json:"file_size_bytes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &stats))
+	assert.Equal(t, uint32(2), stats.Records)
+	assert.Positive(t, stats.FileSizeBytes)
+}
+
+func TestVerify(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+	require.NoError(t, run([]string{"-db", db, "put", "a", "1"}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", db, "verify", "-json"}, &out))
+
+	var report struct {
+		Ok      bool   `json:"ok"`
+		Records uint64 `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &report))
+	assert.True(t, report.Ok)
+	assert.Equal(t, uint64(1), report.Records)
+}
+
+func TestCompact(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+	for _, kv := range [][2]string{{"a", "1"}, {"b", "2"}, {"c", "3"}} {
+		require.NoError(t, run([]string{"-db", db, "put", kv[0], kv[1]}, nil))
+	}
+	require.NoError(t, run([]string{"-db", db, "del", "a"}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", db, "compact", "-json"}, &out))
+
+	var result struct {
+		CompactedSegments int `json:"compacted_segments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &result))
+	assert.GreaterOrEqual(t, result.CompactedSegments, 0)
+}
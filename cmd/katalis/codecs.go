@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/NicoNex/katalis"
+)
+
+// cliCodec translates between command-line strings and the bytes stored in
+// the DB: parse turns an argument into stored bytes, render does the reverse
+// for display.
+type cliCodec struct {
+	parse  func(s string) ([]byte, error)
+	render func(b []byte) (string, error)
+}
+
+var cliCodecs = map[string]cliCodec{
+	"string": {
+		parse:  func(s string) ([]byte, error) { return []byte(s), nil },
+		render: func(b []byte) (string, error) { return string(b), nil },
+	},
+	"int64": {
+		parse: func(s string) ([]byte, error) {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return katalis.Int64Codec.Encode(i)
+		},
+		render: func(b []byte) (string, error) {
+			i, err := katalis.Int64Codec.Decode(b)
+			if err != nil {
+				return "", err
+			}
+			return strconv.FormatInt(i, 10), nil
+		},
+	},
+	"hex": {
+		parse:  hex.DecodeString,
+		render: func(b []byte) (string, error) { return hex.EncodeToString(b), nil },
+	},
+	"gob": {
+		parse: func(string) ([]byte, error) {
+			return nil, fmt.Errorf("the gob codec is display-only")
+		},
+		render: renderGob,
+	},
+}
+
+func codecByName(name string) (cliCodec, error) {
+	c, ok := cliCodecs[name]
+	if !ok {
+		return cliCodec{}, fmt.Errorf("unknown codec %q, available: %v", name, codecNames())
+	}
+	return c, nil
+}
+
+func codecNames() []string {
+	names := make([]string, 0, len(cliCodecs))
+	for name := range cliCodecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderGob best-effort decodes a gob blob into one of the common shapes and
+// dumps it as JSON, falling back to hex when none matches. Gob needs the
+// concrete Go type to decode, which the CLI cannot know.
+func renderGob(b []byte) (string, error) {
+	try := func(v any) (string, bool) {
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(v); err != nil {
+			return "", false
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(out), true
+	}
+
+	if s, ok := try(new(string)); ok {
+		return s, nil
+	}
+	if s, ok := try(new(int64)); ok {
+		return s, nil
+	}
+	if s, ok := try(new(float64)); ok {
+		return s, nil
+	}
+	if s, ok := try(new(bool)); ok {
+		return s, nil
+	}
+	if s, ok := try(new([]string)); ok {
+		return s, nil
+	}
+	if s, ok := try(new(map[string]string)); ok {
+		return s, nil
+	}
+	return hex.EncodeToString(b), nil
+}
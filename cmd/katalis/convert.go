@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/NicoNex/katalis"
+	badger "github.com/dgraph-io/badger/v4"
+	"github.com/syndtr/goleveldb/leveldb"
+	bolt "go.etcd.io/bbolt"
+)
+
+// convertSource streams the records of a foreign embedded store.
+type convertSource interface {
+	iterate(fn func(key, val []byte) error) error
+	close() error
+}
+
+func cmdConvert(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	var (
+		from   = flags.String("from", "", "source store type: bolt, badger or leveldb")
+		bucket = flags.String("bucket", "", "bucket to convert (bolt only)")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("usage: convert -from bolt|badger|leveldb [-bucket <name>] <source> <destination>")
+	}
+	srcPath, dstPath := flags.Arg(0), flags.Arg(1)
+
+	var (
+		src convertSource
+		err error
+	)
+	switch *from {
+	case "bolt":
+		src, err = openBoltSource(srcPath, *bucket)
+	case "badger":
+		src, err = openBadgerSource(srcPath)
+	case "leveldb":
+		src, err = openLevelDBSource(srcPath)
+	case "":
+		return fmt.Errorf("missing -from flag")
+	default:
+		return fmt.Errorf("unknown source store %q, available: bolt, badger, leveldb", *from)
+	}
+	if err != nil {
+		return err
+	}
+	defer src.close()
+
+	dst, err := katalis.Open(dstPath, katalis.BytesCodec, katalis.BytesCodec)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	loader := dst.BulkLoader()
+	var n int
+	err = src.iterate(func(key, val []byte) error {
+		if err := loader.Add(key, val); err != nil {
+			return err
+		}
+		n++
+		progress("converted", n, false)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := loader.Close(); err != nil {
+		return err
+	}
+
+	progress("converted", n, true)
+	_, err = fmt.Fprintf(stdout, "converted %d records\n", n)
+	return err
+}
+
+type boltSource struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+func openBoltSource(path, bucket string) (convertSource, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("bolt sources need the -bucket flag")
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return boltSource{db: db, bucket: []byte(bucket)}, nil
+}
+
+func (s boltSource) iterate(fn func(key, val []byte) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b == nil {
+			return fmt.Errorf("bucket %q not found", s.bucket)
+		}
+		return b.ForEach(fn)
+	})
+}
+
+func (s boltSource) close() error { return s.db.Close() }
+
+type badgerSource struct {
+	db *badger.DB
+}
+
+func openBadgerSource(path string) (convertSource, error) {
+	db, err := badger.Open(badger.DefaultOptions(path).WithReadOnly(true).WithLogger(nil))
+	if err != nil {
+		return nil, err
+	}
+	return badgerSource{db: db}, nil
+}
+
+func (s badgerSource) iterate(fn func(key, val []byte) error) error {
+	return s.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if err := fn(item.KeyCopy(nil), val); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s badgerSource) close() error { return s.db.Close() }
+
+type levelDBSource struct {
+	db *leveldb.DB
+}
+
+func openLevelDBSource(path string) (convertSource, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return levelDBSource{db: db}, nil
+}
+
+func (s levelDBSource) iterate(fn func(key, val []byte) error) error {
+	it := s.db.NewIterator(nil, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (s levelDBSource) close() error { return s.db.Close() }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestConvertBolt(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := dir+"/src.bolt", dir+"/dst.db"
+
+	bdb, err := bolt.Open(src, 0600, nil)
+	require.NoError(t, err)
+	require.NoError(t, bdb.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("users"))
+		if err != nil {
+			return err
+		}
+		for i := range 10 {
+			k := fmt.Sprintf("user:%d", i)
+			if err := b.Put([]byte(k), []byte("val"+k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	require.NoError(t, bdb.Close())
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"convert", "-from", "bolt", "-bucket", "users", src, dst}, &out))
+	assert.Contains(t, out.String(), "converted 10 records")
+
+	out.Reset()
+	require.NoError(t, run([]string{"-db", dst, "get", "user:7"}, &out))
+	assert.Equal(t, "valuser:7\n", out.String())
+}
+
+func TestConvertBoltMissingBucket(t *testing.T) {
+	dir := t.TempDir()
+	src := dir + "/src.bolt"
+
+	bdb, err := bolt.Open(src, 0600, nil)
+	require.NoError(t, err)
+	require.NoError(t, bdb.Close())
+
+	err = run([]string{"convert", "-from", "bolt", src, dir + "/dst.db"}, nil)
+	assert.ErrorContains(t, err, "-bucket")
+}
+
+func TestConvertLevelDB(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := dir+"/src.ldb", dir+"/dst.db"
+
+	ldb, err := leveldb.OpenFile(src, nil)
+	require.NoError(t, err)
+	require.NoError(t, ldb.Put([]byte("alpha"), []byte("1"), nil))
+	require.NoError(t, ldb.Put([]byte("beta"), []byte("2"), nil))
+	require.NoError(t, ldb.Close())
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"convert", "-from", "leveldb", src, dst}, &out))
+	assert.Contains(t, out.String(), "converted 2 records")
+
+	out.Reset()
+	require.NoError(t, run([]string{"-db", dst, "get", "beta"}, &out))
+	assert.Equal(t, "2\n", out.String())
+}
+
+func TestConvertBadArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	err := run([]string{"convert", dir + "/src", dir + "/dst"}, nil)
+	assert.ErrorContains(t, err, "missing -from")
+
+	err = run([]string{"convert", "-from", "rocksdb", dir + "/src", dir + "/dst"}, nil)
+	assert.ErrorContains(t, err, "unknown source store")
+
+	err = run([]string{"convert", "-from", "leveldb", dir + "/src"}, nil)
+	assert.ErrorContains(t, err, "usage: convert")
+}
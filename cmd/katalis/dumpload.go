@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/NicoNex/katalis"
+)
+
+// progressEvery is how many records pass between progress updates on stderr.
+const progressEvery = 100_000
+
+// dumpRecord is one line of the jsonl dump format, with key and value in
+// base64 so arbitrary bytes survive the trip.
+type dumpRecord struct {
+	Key   string `json:"k"`
+	Value string `json:"v"`
+}
+
+func cmdDump(db katalis.DB[[]byte, []byte], args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("dump", flag.ContinueOnError)
+	var (
+		format = flags.String("format", "jsonl", "dump format: jsonl or backup")
+		gz     = flags.Bool("gzip", false, "gzip-compress the output")
+		out    = flags.String("o", "", "output file, stdout when empty")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if *gz {
+		zw := gzip.NewWriter(w)
+		defer zw.Close()
+		w = zw
+	}
+
+	switch *format {
+	case "jsonl":
+		return dumpJSONL(db, w)
+	case "backup":
+		return db.WriteBackup(w)
+	default:
+		return fmt.Errorf("unknown dump format %q", *format)
+	}
+}
+
+func dumpJSONL(db katalis.DB[[]byte, []byte], w io.Writer) error {
+	enc := json.NewEncoder(w)
+	var n int
+	for kb, vb := range db.RawItems() {
+		err := enc.Encode(dumpRecord{
+			Key:   base64.StdEncoding.EncodeToString(kb),
+			Value: base64.StdEncoding.EncodeToString(vb),
+		})
+		if err != nil {
+			return err
+		}
+		n++
+		progress("dumped", n, false)
+	}
+	progress("dumped", n, true)
+	return nil
+}
+
+func cmdLoad(db katalis.DB[[]byte, []byte], args []string) error {
+	flags := flag.NewFlagSet("load", flag.ContinueOnError)
+	var (
+		format = flags.String("format", "jsonl", "input format: jsonl or backup")
+		gz     = flags.Bool("gzip", false, "gzip-decompress the input")
+		in     = flags.String("i", "", "input file, stdin when empty")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	if *gz {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	switch *format {
+	case "jsonl":
+		return loadJSONL(db, r)
+	case "backup":
+		return loadBackup(db, r)
+	default:
+		return fmt.Errorf("unknown load format %q", *format)
+	}
+}
+
+func loadJSONL(db katalis.DB[[]byte, []byte], r io.Reader) error {
+	var n int
+	dec := json.NewDecoder(r)
+	for {
+		var rec dumpRecord
+		if err := dec.Decode(&rec); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		kb, err := base64.StdEncoding.DecodeString(rec.Key)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", n+1, err)
+		}
+		vb, err := base64.StdEncoding.DecodeString(rec.Value)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", n+1, err)
+		}
+		if err := db.Put(kb, vb); err != nil {
+			return err
+		}
+		n++
+		progress("loaded", n, false)
+	}
+	progress("loaded", n, true)
+	return db.Sync()
+}
+
+// loadBackup applies a stream written by WriteBackup: a sequence of records,
+// each a 4-byte big-endian length-prefixed key followed by the value framed
+// the same way.
+func loadBackup(db katalis.DB[[]byte, []byte], r io.Reader) error {
+	br := bufio.NewReader(r)
+	var n int
+	for {
+		kb, err := readFrame(br)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("record %d: %w", n+1, err)
+		}
+		vb, err := readFrame(br)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", n+1, err)
+		}
+		if err := db.Put(kb, vb); err != nil {
+			return err
+		}
+		n++
+		progress("loaded", n, false)
+	}
+	progress("loaded", n, true)
+	return db.Sync()
+}
+
+func readFrame(r *bufio.Reader) ([]byte, error) {
+	var lenb [4]byte
+	if _, err := io.ReadFull(r, lenb[:]); err != nil {
+		return nil, err
+	}
+	b := make([]byte, binary.BigEndian.Uint32(lenb[:]))
+	if _, err := io.ReadFull(r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// progress keeps the user company during large dumps and loads, updating a
+// counter on stderr every progressEvery records and on completion.
+func progress(verb string, n int, done bool) {
+	if done && n >= progressEvery {
+		fmt.Fprintf(os.Stderr, "\r%s %d records\n", verb, n)
+		return
+	}
+	if !done && n%progressEvery == 0 {
+		fmt.Fprintf(os.Stderr, "\r%s %d records...", verb, n)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpLoadJSONL(t *testing.T) {
+	src := t.TempDir() + "/src.db"
+	dst := t.TempDir() + "/dst.db"
+	dump := t.TempDir() + "/dump.jsonl"
+
+	require.NoError(t, run([]string{"-db", src, "put", "a", "1"}, nil))
+	require.NoError(t, run([]string{"-db", src, "put", "b", "2"}, nil))
+
+	require.NoError(t, run([]string{"-db", src, "dump", "-o", dump}, nil))
+	require.NoError(t, run([]string{"-db", dst, "load", "-i", dump}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", dst, "get", "a"}, &out))
+	assert.Equal(t, "1\n", out.String())
+
+	out.Reset()
+	require.NoError(t, run([]string{"-db", dst, "get", "b"}, &out))
+	assert.Equal(t, "2\n", out.String())
+}
+
+func TestDumpLoadGzipBackup(t *testing.T) {
+	src := t.TempDir() + "/src.db"
+	dst := t.TempDir() + "/dst.db"
+	dump := t.TempDir() + "/dump.bin.gz"
+
+	require.NoError(t, run([]string{"-db", src, "put", "key", "value"}, nil))
+
+	require.NoError(t, run([]string{"-db", src, "dump", "-format", "backup", "-gzip", "-o", dump}, nil))
+	require.NoError(t, run([]string{"-db", dst, "load", "-format", "backup", "-gzip", "-i", dump}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", dst, "get", "key"}, &out))
+	assert.Equal(t, "value\n", out.String())
+}
+
+func TestDumpUnknownFormat(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+	assert.ErrorContains(t, run([]string{"-db", db, "dump", "-format", "xml"}, nil), "unknown dump format")
+}
@@ -0,0 +1,204 @@
+// Command katalis inspects and edits a katalis store from the shell, so
+// debugging a production DB no longer requires a throwaway Go program:
+//
+//	katalis -db ./data get user:42
+//	katalis -db ./data -vc int64 put user:42 7
+//	katalis -db ./data del user:42
+//	katalis -db ./data ls -prefix user:
+//
+// The -kc and -vc flags select how keys and values are parsed and rendered;
+// see codecs.go for the available names.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/NicoNex/katalis"
+)
+
+const usage = `usage: katalis -db <dir> [-kc <codec>] [-vc <codec>] <command> [args]
+
+commands:
+  get <key>          print the value for key
+  put <key> <value>  set the value for key
+  del <key>          delete key
+  ls [-prefix <p>]   list keys, optionally only those with the given prefix
+  shell              start an interactive session against the store
+  dump [-format jsonl|backup] [-gzip] [-o <file>]  export the whole store
+  load [-format jsonl|backup] [-gzip] [-i <file>]  import a previous dump
+  stats [-json]      print store statistics
+  verify [-json]     check every record decodes, exit non-zero otherwise
+  compact [-json]    trigger a compaction and report what it reclaimed
+  serve [-addr <a>]  expose the store over HTTP, see the server/httpapi docs
+  convert -from bolt|badger|leveldb [-bucket <name>] <source> <destination>
+                     stream the records of another embedded store into katalis
+
+codecs (for -kc and -vc): %s
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "katalis:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("katalis", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), usage, strings.Join(codecNames(), ", "))
+		flags.PrintDefaults()
+	}
+	var (
+		path  = flags.String("db", "", "path of the store directory")
+		kcSel = flags.String("kc", "string", "key codec")
+		vcSel = flags.String("vc", "string", "value codec")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 {
+		flags.Usage()
+		return fmt.Errorf("missing command")
+	}
+
+	// convert reads a foreign store and opens its own destination, so it does
+	// not use the -db flag.
+	if flags.Arg(0) == "convert" {
+		return cmdConvert(flags.Args()[1:], stdout)
+	}
+
+	if *path == "" {
+		return fmt.Errorf("missing -db flag")
+	}
+
+	kc, err := codecByName(*kcSel)
+	if err != nil {
+		return err
+	}
+	vc, err := codecByName(*vcSel)
+	if err != nil {
+		return err
+	}
+
+	db, err := katalis.Open(*path, katalis.BytesCodec, katalis.BytesCodec)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	cmd, rest := flags.Arg(0), flags.Args()[1:]
+	switch cmd {
+	case "get":
+		return cmdGet(db, kc, vc, rest, stdout)
+	case "put":
+		return cmdPut(db, kc, vc, rest)
+	case "del":
+		return cmdDel(db, kc, rest)
+	case "ls":
+		return cmdLs(db, kc, rest, stdout)
+	case "shell":
+		return cmdShell(db, kc, vc, rest, stdout)
+	case "dump":
+		return cmdDump(db, rest, stdout)
+	case "load":
+		return cmdLoad(db, rest)
+	case "stats":
+		return cmdStats(db, rest, stdout)
+	case "verify":
+		return cmdVerify(db, rest, stdout)
+	case "compact":
+		return cmdCompact(db, rest, stdout)
+	case "serve":
+		return cmdServe(db, rest, stdout)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func cmdGet(db katalis.DB[[]byte, []byte], kc, vc cliCodec, args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: get <key>")
+	}
+
+	kb, err := kc.parse(args[0])
+	if err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+
+	has, err := db.Has(kb)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("key %q not found", args[0])
+	}
+
+	vb, err := db.Get(kb)
+	if err != nil {
+		return err
+	}
+	rendered, err := vc.render(vb)
+	if err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+	_, err = fmt.Fprintln(stdout, rendered)
+	return err
+}
+
+func cmdPut(db katalis.DB[[]byte, []byte], kc, vc cliCodec, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: put <key> <value>")
+	}
+
+	kb, err := kc.parse(args[0])
+	if err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+	vb, err := vc.parse(args[1])
+	if err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+	return db.Put(kb, vb)
+}
+
+func cmdDel(db katalis.DB[[]byte, []byte], kc cliCodec, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: del <key>")
+	}
+
+	kb, err := kc.parse(args[0])
+	if err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+	return db.Del(kb)
+}
+
+func cmdLs(db katalis.DB[[]byte, []byte], kc cliCodec, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("ls", flag.ContinueOnError)
+	prefix := flags.String("prefix", "", "only list keys starting with this prefix")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	for entry, err := range db.AllItems() {
+		if err != nil {
+			return err
+		}
+		rendered, err := kc.render(entry.Key)
+		if err != nil {
+			return fmt.Errorf("key: %w", err)
+		}
+		if !strings.HasPrefix(rendered, *prefix) {
+			continue
+		}
+		if _, err := fmt.Fprintln(stdout, rendered); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutGetDelLs(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+
+	require.NoError(t, run([]string{"-db", db, "put", "user:1", "ada"}, nil))
+	require.NoError(t, run([]string{"-db", db, "put", "user:2", "grace"}, nil))
+	require.NoError(t, run([]string{"-db", db, "put", "group:1", "admins"}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", db, "get", "user:1"}, &out))
+	assert.Equal(t, "ada\n", out.String())
+
+	out.Reset()
+	require.NoError(t, run([]string{"-db", db, "ls", "-prefix", "user:"}, &out))
+	assert.ElementsMatch(t,
+		[]string{"user:1", "user:2"},
+		strings.Fields(out.String()),
+	)
+
+	require.NoError(t, run([]string{"-db", db, "del", "user:1"}, nil))
+	err := run([]string{"-db", db, "get", "user:1"}, nil)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestInt64Codec(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+
+	require.NoError(t, run([]string{"-db", db, "-vc", "int64", "put", "counter", "42"}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", db, "-vc", "int64", "get", "counter"}, &out))
+	assert.Equal(t, "42\n", out.String())
+}
+
+func TestHexCodec(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+
+	require.NoError(t, run([]string{"-db", db, "-kc", "hex", "put", "deadbeef", "value"}, nil))
+
+	var out strings.Builder
+	require.NoError(t, run([]string{"-db", db, "-kc", "hex", "get", "deadbeef"}, &out))
+	assert.Equal(t, "value\n", out.String())
+}
+
+func TestErrors(t *testing.T) {
+	db := t.TempDir() + "/test.db"
+
+	assert.ErrorContains(t, run([]string{"get", "key"}, nil), "missing -db")
+	assert.ErrorContains(t, run([]string{"-db", db}, nil), "missing command")
+	assert.ErrorContains(t, run([]string{"-db", db, "frobnicate"}, nil), "unknown command")
+	assert.ErrorContains(t, run([]string{"-db", db, "-kc", "nope", "get", "k"}, nil), "unknown codec")
+	assert.ErrorContains(t, run([]string{"-db", db, "-vc", "gob", "put", "k", "v"}, nil), "display-only")
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/httpapi"
+)
+
+func cmdServe(db katalis.DB[[]byte, []byte], args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":7333", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "serving on %s\n", ln.Addr())
+	return http.Serve(ln, httpapi.New(db, katalis.BytesCodec, katalis.BytesCodec))
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/NicoNex/katalis"
+	"golang.org/x/term"
+)
+
+const shellHelp = `commands:
+  get <key>            print the value for key
+  put <key> <value>    set the value for key
+  put <key>            set a multi-line value, terminated by a line with a single .
+  del <key>            delete key
+  ls [prefix]          list keys, optionally filtered by prefix
+  codec key|value <c>  switch the key or value codec (string, int64, hex, gob)
+  history              print the commands of this session
+  help                 print this help
+  quit                 leave the shell
+`
+
+// lineReader abstracts where shell input comes from: a raw terminal with
+// editing and history when stdin is a TTY, a plain buffered reader otherwise
+// (pipes, tests).
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+type bufioLineReader struct{ r *bufio.Reader }
+
+func (b bufioLineReader) ReadLine() (string, error) {
+	line, err := b.r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// shellState carries the session: current codecs and command history.
+type shellState struct {
+	db      katalis.DB[[]byte, []byte]
+	kc, vc  cliCodec
+	history []string
+}
+
+func cmdShell(db katalis.DB[[]byte, []byte], kc, vc cliCodec, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("shell", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		return runShellTerminal(db, kc, vc, fd)
+	}
+	return runShell(db, kc, vc, bufioLineReader{r: bufio.NewReader(os.Stdin)}, stdout)
+}
+
+// runShellTerminal runs the shell on a raw terminal, with line editing,
+// per-session history on the arrow keys and tab completion of command names.
+func runShellTerminal(db katalis.DB[[]byte, []byte], kc, vc cliCodec, fd int) error {
+	old, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, old)
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "katalis> ")
+	t.AutoCompleteCallback = completeShellCommand
+
+	return runShell(db, kc, vc, terminalLineReader{t: t}, t)
+}
+
+type terminalLineReader struct{ t *term.Terminal }
+
+func (t terminalLineReader) ReadLine() (string, error) { return t.t.ReadLine() }
+
+// completeShellCommand tab-completes the command word at the start of the
+// line.
+func completeShellCommand(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' || strings.ContainsRune(line[:pos], ' ') {
+		return "", 0, false
+	}
+
+	var matches []string
+	for _, cmd := range []string{"get", "put", "del", "ls", "codec", "history", "help", "quit"} {
+		if strings.HasPrefix(cmd, line[:pos]) {
+			matches = append(matches, cmd)
+		}
+	}
+	if len(matches) != 1 {
+		return "", 0, false
+	}
+	completed := matches[0] + " " + line[pos:]
+	return completed, len(matches[0]) + 1, true
+}
+
+func runShell(db katalis.DB[[]byte, []byte], kc, vc cliCodec, in lineReader, out io.Writer) error {
+	s := &shellState{db: db, kc: kc, vc: vc}
+	for {
+		line, err := in.ReadLine()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		s.history = append(s.history, line)
+
+		quit, err := s.exec(line, in, out)
+		if quit {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintln(out, "error:", err)
+		}
+	}
+}
+
+// exec runs one shell command. It reports whether the session should end.
+func (s *shellState) exec(line string, in lineReader, out io.Writer) (quit bool, err error) {
+	fields := strings.SplitN(line, " ", 3)
+	cmd := fields[0]
+	args := fields[1:]
+
+	switch cmd {
+	case "quit", "exit":
+		return true, nil
+
+	case "help":
+		fmt.Fprint(out, shellHelp)
+		return false, nil
+
+	case "history":
+		for _, h := range s.history {
+			fmt.Fprintln(out, h)
+		}
+		return false, nil
+
+	case "get":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: get <key>")
+		}
+		return false, cmdGet(s.db, s.kc, s.vc, args, out)
+
+	case "put":
+		switch len(args) {
+		case 1:
+			// Multi-line mode: read value lines until a lone dot.
+			val, err := readMultiLine(in, out)
+			if err != nil {
+				return false, err
+			}
+			return false, cmdPut(s.db, s.kc, s.vc, []string{args[0], val})
+		case 2:
+			return false, cmdPut(s.db, s.kc, s.vc, args)
+		default:
+			return false, fmt.Errorf("usage: put <key> [value]")
+		}
+
+	case "del":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: del <key>")
+		}
+		return false, cmdDel(s.db, s.kc, args)
+
+	case "ls":
+		lsArgs := []string{}
+		if len(args) > 0 {
+			lsArgs = []string{"-prefix", args[0]}
+		}
+		return false, cmdLs(s.db, s.kc, lsArgs, out)
+
+	case "codec":
+		if len(args) != 2 {
+			return false, fmt.Errorf("usage: codec key|value <name>")
+		}
+		c, err := codecByName(args[1])
+		if err != nil {
+			return false, err
+		}
+		switch args[0] {
+		case "key":
+			s.kc = c
+		case "value":
+			s.vc = c
+		default:
+			return false, fmt.Errorf("usage: codec key|value <name>")
+		}
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unknown command %q, try help", cmd)
+	}
+}
+
+// readMultiLine collects lines until one holding a single dot, mirroring how
+// mail clients end a message body.
+func readMultiLine(in lineReader, out io.Writer) (string, error) {
+	fmt.Fprintln(out, "enter value, end with a line containing only '.'")
+
+	var lines []string
+	for {
+		line, err := in.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if line == "." {
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, line)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shellSession feeds the given lines to the shell and returns its output.
+func shellSession(t *testing.T, db katalis.DB[[]byte, []byte], input string) string {
+	t.Helper()
+
+	var out strings.Builder
+	in := bufioLineReader{r: bufio.NewReader(strings.NewReader(input))}
+	require.NoError(t, runShell(db, cliCodecs["string"], cliCodecs["string"], in, &out))
+	return out.String()
+}
+
+func TestShellPutGetDel(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	out := shellSession(t, db, "put name ada\nget name\ndel name\nquit\n")
+	assert.Contains(t, out, "ada")
+
+	has, err := db.Has([]byte("name"))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestShellMultiLinePut(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	shellSession(t, db, "put note\nline one\nline two\n.\nquit\n")
+
+	val, err := db.Get([]byte("note"))
+	require.NoError(t, err)
+	assert.Equal(t, "line one\nline two", string(val))
+}
+
+func TestShellCodecSwitch(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	out := shellSession(t, db, "codec value int64\nput counter 42\nget counter\nquit\n")
+	assert.Contains(t, out, "42")
+
+	val, err := db.Get([]byte("counter"))
+	require.NoError(t, err)
+	assert.Len(t, val, 8)
+}
+
+func TestShellLsAndHistory(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	out := shellSession(t, db, "put user:1 a\nput group:1 b\nls user:\nhistory\nquit\n")
+	assert.Contains(t, out, "user:1")
+	assert.Contains(t, out, "put group:1 b")
+}
+
+func TestShellUnknownCommand(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	out := shellSession(t, db, "frobnicate\nquit\n")
+	assert.Contains(t, out, "unknown command")
+}
+
+func TestCompleteShellCommand(t *testing.T) {
+	line, pos, ok := completeShellCommand("hi", 2, '\t')
+	assert.True(t, ok)
+	assert.Equal(t, "history ", line)
+	assert.Equal(t, 8, pos)
+
+	// Ambiguous prefix: matches both help and history.
+	_, _, ok = completeShellCommand("h", 1, '\t')
+	assert.False(t, ok)
+
+	// No completion mid-argument.
+	_, _, ok = completeShellCommand("get us", 6, '\t')
+	assert.False(t, ok)
+}
@@ -0,0 +1,320 @@
+package katalis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+)
+
+// Predefined codecs for common Go types. These codecs handle encoding and
+// decoding of primitive types to/from byte slices for database storage.
+var (
+	UintCodec   = uintCodec{}
+	Uint16Codec = uint16Codec{}
+	Uint32Codec = uint32Codec{}
+	Uint64Codec = uint64Codec{}
+
+	IntCodec   = intCodec{}
+	Int16Codec = int16Codec{}
+	Int32Codec = int32Codec{}
+	Int64Codec = int64Codec{}
+
+	Float64Codec = float64Codec{}
+	Float32Codec = float32Codec{}
+
+	BytesCodec  = bytesCodec{}
+	StringCodec = stringCodec{}
+
+	BoolCodec       = boolCodec{}
+	RuneCodec       = runeCodec{}
+	Complex64Codec  = complex64Codec{}
+	Complex128Codec = complex128Codec{}
+)
+
+type uint64Codec struct{}
+
+func (uc uint64Codec) Encode(i uint64) ([]byte, error) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, i)
+	return b, nil
+}
+
+func (uc uint64Codec) EncodeTo(dst []byte, i uint64) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(dst, i), nil
+}
+
+func (uc uint64Codec) Decode(b []byte) (uint64, error) {
+	if len(b) < 8 {
+		return 0, fmt.Errorf("katalis: cannot decode uint64 from %d bytes", len(b))
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+type uint32Codec struct{}
+
+func (uc uint32Codec) Encode(i uint32) ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, i)
+	return b, nil
+}
+
+func (uc uint32Codec) EncodeTo(dst []byte, i uint32) ([]byte, error) {
+	return binary.BigEndian.AppendUint32(dst, i), nil
+}
+
+func (uc uint32Codec) Decode(b []byte) (uint32, error) {
+	if len(b) < 4 {
+		return 0, fmt.Errorf("katalis: cannot decode uint32 from %d bytes", len(b))
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+type uint16Codec struct{}
+
+func (uc uint16Codec) Encode(i uint16) ([]byte, error) {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, i)
+	return b, nil
+}
+
+func (uc uint16Codec) EncodeTo(dst []byte, i uint16) ([]byte, error) {
+	return binary.BigEndian.AppendUint16(dst, i), nil
+}
+
+func (uc uint16Codec) Decode(b []byte) (uint16, error) {
+	if len(b) < 2 {
+		return 0, fmt.Errorf("katalis: cannot decode uint16 from %d bytes", len(b))
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
+
+type uintCodec struct{}
+
+// Encode writes the value as 8 bytes so values above 2^32 are not truncated
+// on 64-bit platforms.
+func (uc uintCodec) Encode(i uint) ([]byte, error) {
+	return Uint64Codec.Encode(uint64(i))
+}
+
+func (uc uintCodec) EncodeTo(dst []byte, i uint) ([]byte, error) {
+	return Uint64Codec.EncodeTo(dst, uint64(i))
+}
+
+// Decode also accepts the legacy 4-byte encoding, so stores written before
+// uint values were widened to 64 bits keep decoding correctly.
+func (uc uintCodec) Decode(b []byte) (uint, error) {
+	if len(b) == 4 {
+		u32, err := Uint32Codec.Decode(b)
+		return uint(u32), err
+	}
+	u64, err := Uint64Codec.Decode(b)
+	return uint(u64), err
+}
+
+type int64Codec struct{}
+
+func (ic int64Codec) Encode(i int64) ([]byte, error) {
+	return Uint64Codec.Encode(uint64(i))
+}
+
+func (ic int64Codec) EncodeTo(dst []byte, i int64) ([]byte, error) {
+	return Uint64Codec.EncodeTo(dst, uint64(i))
+}
+
+func (ic int64Codec) Decode(b []byte) (int64, error) {
+	i, err := Uint64Codec.Decode(b)
+	return int64(i), err
+}
+
+type int32Codec struct{}
+
+func (ic int32Codec) Encode(i int32) ([]byte, error) {
+	return Uint32Codec.Encode(uint32(i))
+}
+
+func (ic int32Codec) EncodeTo(dst []byte, i int32) ([]byte, error) {
+	return Uint32Codec.EncodeTo(dst, uint32(i))
+}
+
+func (ic int32Codec) Decode(b []byte) (int32, error) {
+	i, err := Uint32Codec.Decode(b)
+	return int32(i), err
+}
+
+type int16Codec struct{}
+
+func (ic int16Codec) Encode(i int16) ([]byte, error) {
+	return Uint16Codec.Encode(uint16(i))
+}
+
+func (ic int16Codec) EncodeTo(dst []byte, i int16) ([]byte, error) {
+	return Uint16Codec.EncodeTo(dst, uint16(i))
+}
+
+func (ic int16Codec) Decode(b []byte) (int16, error) {
+	i, err := Uint16Codec.Decode(b)
+	return int16(i), err
+}
+
+type intCodec struct{}
+
+// Encode writes the value as 8 bytes so values outside the int32 range are
+// not truncated on 64-bit platforms.
+func (ic intCodec) Encode(i int) ([]byte, error) {
+	return Int64Codec.Encode(int64(i))
+}
+
+func (ic intCodec) EncodeTo(dst []byte, i int) ([]byte, error) {
+	return Int64Codec.EncodeTo(dst, int64(i))
+}
+
+// Decode also accepts the legacy 4-byte encoding, so stores written before
+// int values were widened to 64 bits keep decoding correctly.
+func (ic intCodec) Decode(b []byte) (int, error) {
+	if len(b) == 4 {
+		i32, err := Int32Codec.Decode(b)
+		return int(i32), err
+	}
+	i64, err := Int64Codec.Decode(b)
+	return int(i64), err
+}
+
+type float64Codec struct{}
+
+func (f64c float64Codec) Encode(f float64) ([]byte, error) {
+	return Uint64Codec.Encode(math.Float64bits(f))
+}
+
+func (f64c float64Codec) EncodeTo(dst []byte, f float64) ([]byte, error) {
+	return Uint64Codec.EncodeTo(dst, math.Float64bits(f))
+}
+
+func (f32c float64Codec) Decode(b []byte) (float64, error) {
+	i, err := Uint64Codec.Decode(b)
+	return math.Float64frombits(i), err
+}
+
+type float32Codec struct{}
+
+func (f32c float32Codec) Encode(f float32) ([]byte, error) {
+	return Uint32Codec.Encode(math.Float32bits(f))
+}
+
+func (f32c float32Codec) EncodeTo(dst []byte, f float32) ([]byte, error) {
+	return Uint32Codec.EncodeTo(dst, math.Float32bits(f))
+}
+
+func (f32c float32Codec) Decode(b []byte) (float32, error) {
+	i, err := Uint32Codec.Decode(b)
+	return math.Float32frombits(i), err
+}
+
+type boolCodec struct{}
+
+func (bc boolCodec) Encode(v bool) ([]byte, error) {
+	if v {
+		return []byte{1}, nil
+	}
+	return []byte{0}, nil
+}
+
+func (bc boolCodec) Decode(b []byte) (bool, error) {
+	return len(b) > 0 && b[0] != 0, nil
+}
+
+type runeCodec struct{}
+
+func (rc runeCodec) Encode(r rune) ([]byte, error) {
+	return Int32Codec.Encode(r)
+}
+
+func (rc runeCodec) Decode(b []byte) (rune, error) {
+	return Int32Codec.Decode(b)
+}
+
+type complex64Codec struct{}
+
+func (cc complex64Codec) Encode(c complex64) ([]byte, error) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint32(b, math.Float32bits(real(c)))
+	binary.BigEndian.PutUint32(b[4:], math.Float32bits(imag(c)))
+	return b, nil
+}
+
+func (cc complex64Codec) Decode(b []byte) (complex64, error) {
+	if len(b) < 8 {
+		return 0, fmt.Errorf("katalis: cannot decode complex64 from %d bytes", len(b))
+	}
+	r := math.Float32frombits(binary.BigEndian.Uint32(b))
+	i := math.Float32frombits(binary.BigEndian.Uint32(b[4:]))
+	return complex(r, i), nil
+}
+
+type complex128Codec struct{}
+
+func (cc complex128Codec) Encode(c complex128) ([]byte, error) {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, math.Float64bits(real(c)))
+	binary.BigEndian.PutUint64(b[8:], math.Float64bits(imag(c)))
+	return b, nil
+}
+
+func (cc complex128Codec) Decode(b []byte) (complex128, error) {
+	if len(b) < 16 {
+		return 0, fmt.Errorf("katalis: cannot decode complex128 from %d bytes", len(b))
+	}
+	r := math.Float64frombits(binary.BigEndian.Uint64(b))
+	i := math.Float64frombits(binary.BigEndian.Uint64(b[8:]))
+	return complex(r, i), nil
+}
+
+type stringCodec struct{}
+
+func (sc stringCodec) Encode(s string) ([]byte, error) {
+	return []byte(s), nil
+}
+
+func (sc stringCodec) EncodeTo(dst []byte, s string) ([]byte, error) {
+	return append(dst, s...), nil
+}
+
+func (sc stringCodec) Decode(b []byte) (string, error) {
+	return string(b), nil
+}
+
+type bytesCodec struct{}
+
+func (sc bytesCodec) Encode(b []byte) ([]byte, error) {
+	return b, nil
+}
+
+func (sc bytesCodec) EncodeTo(dst, b []byte) ([]byte, error) {
+	return append(dst, b...), nil
+}
+
+func (sc bytesCodec) Decode(b []byte) ([]byte, error) {
+	return b, nil
+}
+
+// Gob returns a GobCodec for type T. The optional variadic parameter allows
+// type inference from a value.
+func Gob[T any](_ ...T) (g GobCodec[T]) { return }
+
+// GobCodec is a generic codec that uses Go's gob encoding to serialize values.
+// It works with any type that can be encoded by the encoding/gob package.
+type GobCodec[T any] struct{}
+
+// Encode serializes the value using gob encoding.
+func (pc GobCodec[T]) Encode(a T) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := gob.NewEncoder(&buf).Encode(a)
+	return buf.Bytes(), err
+}
+
+// Decode deserializes the value using gob decoding.
+func (pc GobCodec[T]) Decode(b []byte) (t T, err error) {
+	return t, gob.NewDecoder(bytes.NewReader(b)).Decode(&t)
+}
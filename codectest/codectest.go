@@ -0,0 +1,104 @@
+// Package codectest provides a conformance harness for katalis codecs.
+// Custom codec authors can call Run with representative samples to check
+// Encode→Decode round tripping, determinism and empty-input handling, and
+// Fuzz to exercise Decode with arbitrary inputs.
+package codectest
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Run checks that the codec round-trips every sample, that encoding is
+// deterministic, and that decoding empty input does not panic. Values are
+// compared with an Equal method when the type has one (e.g. time.Time), and
+// with reflect.DeepEqual otherwise.
+func Run[T any](t *testing.T, codec katalis.Codec[T], samples ...T) {
+	t.Helper()
+
+	for i, sample := range samples {
+		t.Run(fmt.Sprintf("sample_%d", i), func(t *testing.T) {
+			t.Helper()
+
+			encoded, err := codec.Encode(sample)
+			if err != nil {
+				t.Fatalf("Encode(%v) failed: %v", sample, err)
+			}
+
+			again, err := codec.Encode(sample)
+			if err != nil {
+				t.Fatalf("second Encode(%v) failed: %v", sample, err)
+			}
+			if !bytes.Equal(encoded, again) {
+				t.Errorf("Encode(%v) is not deterministic: %x != %x", sample, encoded, again)
+			}
+
+			decoded, err := codec.Decode(encoded)
+			if err != nil {
+				t.Fatalf("Decode(%x) failed: %v", encoded, err)
+			}
+			if !equal(sample, decoded) {
+				t.Errorf("round trip mismatch: encoded %v, decoded %v", sample, decoded)
+			}
+		})
+	}
+
+	t.Run("empty_input", func(t *testing.T) {
+		t.Helper()
+
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Decode(nil) panicked: %v", r)
+			}
+		}()
+		// Decoding empty input may fail, but must not panic.
+		codec.Decode(nil)
+	})
+}
+
+// Fuzz registers a fuzz target that feeds arbitrary bytes to Decode. Inputs
+// that decode successfully must re-encode and decode back to an equal value.
+// Use it from a standard fuzz test:
+//
+//	func FuzzMyCodec(f *testing.F) {
+//		codectest.Fuzz(f, myCodec)
+//	}
+func Fuzz[T any](f *testing.F, codec katalis.Codec[T], seeds ...[]byte) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		decoded, err := codec.Decode(b)
+		if err != nil {
+			return
+		}
+
+		encoded, err := codec.Encode(decoded)
+		if err != nil {
+			t.Fatalf("Encode(%v) failed after successful decode: %v", decoded, err)
+		}
+
+		again, err := codec.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode(%x) failed after re-encoding %v: %v", encoded, decoded, err)
+		}
+		if !equal(decoded, again) {
+			t.Errorf("round trip mismatch: %v != %v", decoded, again)
+		}
+	})
+}
+
+// equal compares two values, preferring an Equal method when available.
+func equal[T any](a, b T) bool {
+	type equaler interface{ Equal(T) bool }
+
+	if e, ok := any(a).(equaler); ok {
+		return e.Equal(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
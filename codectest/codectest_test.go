@@ -0,0 +1,35 @@
+package codectest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/codectest"
+)
+
+func TestRunString(t *testing.T) {
+	codectest.Run(t, katalis.StringCodec, "", "hello", "こんにちは")
+}
+
+func TestRunUint64(t *testing.T) {
+	codectest.Run(t, katalis.Uint64Codec, 0, 42, 1<<63)
+}
+
+func TestRunTime(t *testing.T) {
+	codectest.Run(t, katalis.TimeCodec, time.Unix(0, 0), time.Now())
+}
+
+func TestRunGob(t *testing.T) {
+	type point struct{ X, Y int }
+	codectest.Run(t, katalis.Gob[point](), point{}, point{X: 1, Y: 2})
+}
+
+func FuzzStringCodec(f *testing.F) {
+	codectest.Fuzz(f, katalis.StringCodec, []byte("seed"))
+}
+
+func FuzzTupleCodec(f *testing.F) {
+	codec := katalis.Tuple2(katalis.StringCodec, katalis.Uint64Codec)
+	codectest.Fuzz[katalis.Pair[string, uint64]](f, codec, []byte{0, 0, 0, 1, 'a', 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1})
+}
@@ -0,0 +1,132 @@
+package katalis
+
+import (
+	"context"
+	"time"
+
+	"github.com/akrylysov/pogreb"
+)
+
+// CompactionResult reports what a compaction run reclaimed.
+type CompactionResult = pogreb.CompactionResult
+
+// CompactionProgress is a snapshot of a running compaction, passed to the
+// progress callback of Compact.
+type CompactionProgress struct {
+	// Elapsed is the time since the compaction started.
+	Elapsed time.Duration
+	// FileSize is the current on-disk size of the store.
+	FileSize int64
+}
+
+// progressInterval is how often Compact invokes its progress callback.
+const progressInterval = 100 * time.Millisecond
+
+// Compact triggers a compaction run, so operators can reclaim space during
+// low-traffic windows instead of waiting for the background interval. The
+// optional progress callback is invoked periodically while the compaction
+// runs. If ctx expires before the run finishes Compact returns early with the
+// context's error; the compaction itself keeps running to completion in the
+// background, as the engine cannot abandon a half-moved segment.
+func (db DB[KT, VT]) Compact(ctx context.Context, progress func(CompactionProgress)) (CompactionResult, error) {
+	if err := db.closedErr(); err != nil {
+		return CompactionResult{}, err
+	}
+
+	type outcome struct {
+		result CompactionResult
+		err    error
+	}
+
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := db.emitCompaction(db.DB.Compact)
+		done <- outcome{result: result, err: err}
+	}()
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case out := <-done:
+			return out.result, out.err
+
+		case <-ticker.C:
+			if progress != nil {
+				size, _ := db.DB.FileSize()
+				progress(CompactionProgress{Elapsed: time.Since(start), FileSize: size})
+			}
+
+		case <-ctx.Done():
+			return CompactionResult{}, ctx.Err()
+		}
+	}
+}
+
+// ReclaimableBytes estimates how much disk space a compaction would reclaim
+// by comparing the store's on-disk size with the footprint of the live
+// records. It walks the whole store, so it is not free on large DBs.
+func (db DB[KT, VT]) ReclaimableBytes() (int64, error) {
+	if err := db.closedErr(); err != nil {
+		return 0, err
+	}
+
+	size, err := db.DB.FileSize()
+	if err != nil {
+		return 0, err
+	}
+
+	var live int64
+	it := db.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		live += entrySize(kb, vb)
+	}
+
+	if reclaimable := size - live; reclaimable > 0 {
+		return reclaimable, nil
+	}
+	return 0, nil
+}
+
+// RewriteTo streams every live record visible in this view into a freshly
+// laid-out store at path, syncs it and returns the new handle, opened with
+// the same codecs. Compaction can only reclaim space segment by segment and
+// never from the active one; a rewrite leaves no fragmentation behind at
+// all, at the cost of temporarily holding both stores on disk. The source
+// stays open and untouched, so callers can switch handles and delete the old
+// directory once satisfied. Open-time options such as limits, quotas or
+// compaction policies do not carry over: reopen the new path with Open to
+// restore them.
+func (db DB[KT, VT]) RewriteTo(path string) (DB[KT, VT], error) {
+	if err := db.closedErr(); err != nil {
+		return DB[KT, VT]{}, err
+	}
+
+	fresh, err := OpenOptions(path, db.keyCodec, db.valCodec, &Options{BackgroundSyncInterval: -1})
+	if err != nil {
+		return DB[KT, VT]{}, err
+	}
+	fresh.rawPrefix = db.rawPrefix
+	fresh.metaMode = db.metaMode
+
+	for kb, vb := range db.RawItems() {
+		if err := fresh.rawPut(kb, vb); err != nil {
+			fresh.Close()
+			return DB[KT, VT]{}, err
+		}
+	}
+	if err := fresh.Sync(); err != nil {
+		fresh.Close()
+		return DB[KT, VT]{}, err
+	}
+	return fresh, nil
+}
@@ -0,0 +1,115 @@
+package katalis_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 100 {
+		require.NoError(t, db.Put(i, "value"))
+	}
+	for i := range 50 {
+		require.NoError(t, db.Del(i))
+	}
+
+	_, err = db.Compact(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(50), db.Count())
+}
+
+func TestCompactCanceled(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = db.Compact(ctx, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestReclaimableBytes(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	reclaimable, err := db.ReclaimableBytes()
+	require.NoError(t, err)
+	before := reclaimable
+
+	for i := range 100 {
+		require.NoError(t, db.Put(i, "some value that takes up a bit of room"))
+	}
+	for i := range 100 {
+		require.NoError(t, db.Del(i))
+	}
+
+	reclaimable, err = db.ReclaimableBytes()
+	require.NoError(t, err)
+	assert.Greater(t, reclaimable, before)
+}
+
+func TestRewriteTo(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(filepath.Join(dir, "old.db"), katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Churn: write everything twice, then delete half.
+	for range 2 {
+		for i := range 100 {
+			require.NoError(t, db.Put(fmt.Sprintf("key%03d", i), strings.Repeat("v", 128)))
+		}
+	}
+	for i := 0; i < 100; i += 2 {
+		require.NoError(t, db.Del(fmt.Sprintf("key%03d", i)))
+	}
+
+	fresh, err := db.RewriteTo(filepath.Join(dir, "new.db"))
+	require.NoError(t, err)
+	defer fresh.Close()
+
+	assert.Equal(t, uint32(50), fresh.Count())
+	val, err := fresh.Get("key001")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("v", 128), val)
+
+	// The old handle keeps working independently.
+	require.NoError(t, db.Put("key999", "post-rewrite"))
+	has, err := fresh.Has("key999")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// The fresh layout carries none of the old churn.
+	oldSize, err := db.FileSize()
+	require.NoError(t, err)
+	newSize, err := fresh.FileSize()
+	require.NoError(t, err)
+	assert.Less(t, newSize, oldSize)
+}
+
+func TestRewriteToClosed(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(filepath.Join(dir, "old.db"), katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = db.RewriteTo(filepath.Join(dir, "new.db"))
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+}
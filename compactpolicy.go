@@ -0,0 +1,275 @@
+package katalis
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CompactionStatus is the snapshot a CompactionPolicy evaluates: how much
+// space a compaction would reclaim, the current store size, and when the
+// last background run happened (the open time if none did yet).
+type CompactionStatus struct {
+	ReclaimableBytes int64
+	FileSizeBytes    int64
+	LastRun          time.Time
+	Now              time.Time
+}
+
+// CompactionPolicy decides when background compactions run. Evaluate reports
+// whether a compaction should start now and when the policy wants to be
+// consulted next. Implementations must be safe for use from the scheduling
+// goroutine; the built-in ones are stateless.
+type CompactionPolicy interface {
+	Evaluate(s CompactionStatus) (run bool, next time.Time)
+}
+
+// WithCompactionPolicy replaces the default daily background compaction with
+// the given policy. Passing nil disables background compaction entirely,
+// leaving only explicit Compact calls.
+func WithCompactionPolicy(p CompactionPolicy) OpenOption {
+	return func(c *openConfig) { c.policy = p }
+}
+
+// CompactEvery returns a policy compacting at fixed intervals, regardless of
+// how much space a run would reclaim. It is the default, at 24 hours.
+func CompactEvery(d time.Duration) CompactionPolicy {
+	return compactEvery(d)
+}
+
+type compactEvery time.Duration
+
+func (p compactEvery) Evaluate(s CompactionStatus) (bool, time.Time) {
+	d := time.Duration(p)
+	if s.Now.Sub(s.LastRun) >= d {
+		return true, s.Now.Add(d)
+	}
+	return false, s.LastRun.Add(d)
+}
+
+func (p compactEvery) String() string {
+	return fmt.Sprintf("every %v", time.Duration(p))
+}
+
+// CompactOverThreshold returns a policy compacting as soon as the
+// reclaimable space exceeds n bytes, checking every checkEvery.
+func CompactOverThreshold(n int64, checkEvery time.Duration) CompactionPolicy {
+	return compactOverThreshold{bytes: n, every: checkEvery}
+}
+
+type compactOverThreshold struct {
+	bytes int64
+	every time.Duration
+}
+
+func (p compactOverThreshold) Evaluate(s CompactionStatus) (bool, time.Time) {
+	return s.ReclaimableBytes >= p.bytes, s.Now.Add(p.every)
+}
+
+func (p compactOverThreshold) String() string {
+	return fmt.Sprintf("over %d reclaimable bytes, checked every %v", p.bytes, p.every)
+}
+
+// CompactQuietHours returns a policy compacting once per day inside the
+// local-time window [startHour, endHour), so the work lands in low-traffic
+// hours. Windows may wrap midnight, e.g. CompactQuietHours(23, 5).
+func CompactQuietHours(startHour, endHour int) CompactionPolicy {
+	return compactQuietHours{start: startHour, end: endHour}
+}
+
+type compactQuietHours struct {
+	start, end int
+}
+
+// window returns the bounds of the current (or most recent) quiet window
+// relative to now.
+func (p compactQuietHours) window(now time.Time) (start, end time.Time) {
+	y, m, d := now.Date()
+	start = time.Date(y, m, d, p.start, 0, 0, 0, now.Location())
+	if start.After(now) {
+		start = start.AddDate(0, 0, -1)
+	}
+
+	hours := (p.end - p.start + 24) % 24
+	if hours == 0 {
+		hours = 24
+	}
+	return start, start.Add(time.Duration(hours) * time.Hour)
+}
+
+func (p compactQuietHours) Evaluate(s CompactionStatus) (bool, time.Time) {
+	start, end := p.window(s.Now)
+	nextWindow := start.AddDate(0, 0, 1)
+
+	inWindow := s.Now.Before(end)
+	ranThisWindow := s.LastRun.After(start) || s.LastRun.Equal(start)
+	return inWindow && !ranThisWindow, nextWindow
+}
+
+func (p compactQuietHours) String() string {
+	return fmt.Sprintf("daily between %02d:00 and %02d:00", p.start, p.end)
+}
+
+// adaptiveRunFraction is the reclaimable share of the store that makes the
+// adaptive policy compact immediately.
+const adaptiveRunFraction = 0.25
+
+// CompactAdaptive returns a policy that compacts once a quarter of the store
+// is reclaimable and checks more often the dirtier the store gets, from once
+// an hour when clean down to once a minute when approaching the threshold.
+func CompactAdaptive() CompactionPolicy {
+	return compactAdaptive{}
+}
+
+type compactAdaptive struct{}
+
+func (compactAdaptive) Evaluate(s CompactionStatus) (bool, time.Time) {
+	if s.FileSizeBytes <= 0 {
+		return false, s.Now.Add(time.Hour)
+	}
+
+	frac := float64(s.ReclaimableBytes) / float64(s.FileSizeBytes)
+	if frac >= adaptiveRunFraction {
+		return true, s.Now.Add(time.Minute)
+	}
+
+	delay := time.Duration((1 - frac/adaptiveRunFraction) * float64(time.Hour))
+	if delay < time.Minute {
+		delay = time.Minute
+	}
+	return false, s.Now.Add(delay)
+}
+
+func (compactAdaptive) String() string { return "adaptive" }
+
+// compactorState is the background compaction scheduler of one DB handle.
+type compactorState struct {
+	stop chan struct{}
+	done chan struct{}
+	poke chan struct{} // wakes the scheduler after a policy change
+
+	mu     sync.Mutex
+	policy CompactionPolicy
+	last   time.Time
+	next   time.Time
+}
+
+func (c *compactorState) schedule() (last, next time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last, c.next
+}
+
+func (c *compactorState) setNext(next time.Time) {
+	c.mu.Lock()
+	c.next = next
+	c.mu.Unlock()
+}
+
+func (c *compactorState) setLast(last time.Time) {
+	c.mu.Lock()
+	c.last = last
+	c.mu.Unlock()
+}
+
+func (c *compactorState) policyNow() CompactionPolicy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.policy
+}
+
+// setPolicy swaps the active policy and wakes the scheduler so the new one
+// takes effect immediately instead of after the old timer fires.
+func (c *compactorState) setPolicy(p CompactionPolicy) {
+	c.mu.Lock()
+	c.policy = p
+	c.mu.Unlock()
+
+	select {
+	case c.poke <- struct{}{}:
+	default:
+	}
+}
+
+// startCompactor launches the scheduling goroutine; a nil policy means no
+// background compaction.
+func (db *DB[KT, VT]) startCompactor(p CompactionPolicy) {
+	if p == nil {
+		return
+	}
+	db.compactor = &compactorState{
+		policy: p,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		poke:   make(chan struct{}, 1),
+		last:   time.Now(),
+	}
+	go db.runCompactor()
+}
+
+// stopCompactor shuts the scheduler down, waiting out a compaction still in
+// flight so Close never yanks the store from under it.
+func (db DB[KT, VT]) stopCompactor() {
+	if db.compactor == nil {
+		return
+	}
+	close(db.compactor.stop)
+	<-db.compactor.done
+}
+
+func (db DB[KT, VT]) runCompactor() {
+	c := db.compactor
+	defer close(c.done)
+
+	for {
+		now := time.Now()
+		status := CompactionStatus{LastRun: c.last, Now: now}
+
+		var err error
+		if status.ReclaimableBytes, err = db.ReclaimableBytes(); err == nil {
+			status.FileSizeBytes, err = db.FileSize()
+		}
+
+		var run bool
+		next := now.Add(time.Minute) // retry delay when the store is unreadable
+		if err == nil {
+			run, next = c.policyNow().Evaluate(status)
+		}
+		if run {
+			if _, err := db.emitCompaction(db.DB.Compact); err == nil {
+				c.setLast(time.Now())
+			}
+		}
+		c.setNext(next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-c.stop:
+			timer.Stop()
+			return
+		case <-c.poke:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// SetCompactionInterval replaces the background compaction policy with
+// CompactEvery(d) at runtime, so long-running daemons can relax compaction
+// during bulk loads and tighten it afterwards without reopening the store.
+// It returns an error when d is not positive or when the store was opened
+// with a nil compaction policy, which has no scheduler to retune.
+func (db DB[KT, VT]) SetCompactionInterval(d time.Duration) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return errors.New("katalis: compaction interval must be positive")
+	}
+	if db.compactor == nil {
+		return errors.New("katalis: background compaction is disabled for this store")
+	}
+	db.compactor.setPolicy(CompactEvery(d))
+	return nil
+}
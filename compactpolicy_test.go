@@ -0,0 +1,157 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactEvery(t *testing.T) {
+	p := katalis.CompactEvery(time.Hour)
+	now := time.Now()
+
+	run, next := p.Evaluate(katalis.CompactionStatus{LastRun: now.Add(-30 * time.Minute), Now: now})
+	assert.False(t, run)
+	assert.Equal(t, now.Add(30*time.Minute), next)
+
+	run, next = p.Evaluate(katalis.CompactionStatus{LastRun: now.Add(-2 * time.Hour), Now: now})
+	assert.True(t, run)
+	assert.Equal(t, now.Add(time.Hour), next)
+}
+
+func TestCompactOverThreshold(t *testing.T) {
+	p := katalis.CompactOverThreshold(1000, time.Minute)
+	now := time.Now()
+
+	run, next := p.Evaluate(katalis.CompactionStatus{ReclaimableBytes: 999, Now: now})
+	assert.False(t, run)
+	assert.Equal(t, now.Add(time.Minute), next)
+
+	run, _ = p.Evaluate(katalis.CompactionStatus{ReclaimableBytes: 1000, Now: now})
+	assert.True(t, run)
+}
+
+func TestCompactQuietHours(t *testing.T) {
+	p := katalis.CompactQuietHours(2, 5)
+	day := time.Date(2026, 8, 30, 0, 0, 0, 0, time.Local)
+	opened := day.Add(1 * time.Hour) // 01:00, before the window
+
+	// Inside the window with no run yet.
+	run, next := p.Evaluate(katalis.CompactionStatus{LastRun: opened, Now: day.Add(3 * time.Hour)})
+	assert.True(t, run)
+	assert.Equal(t, day.Add(26*time.Hour), next)
+
+	// Inside the window, already ran today.
+	run, _ = p.Evaluate(katalis.CompactionStatus{LastRun: day.Add(2*time.Hour + 30*time.Minute), Now: day.Add(3 * time.Hour)})
+	assert.False(t, run)
+
+	// Outside the window.
+	run, next = p.Evaluate(katalis.CompactionStatus{LastRun: opened, Now: day.Add(12 * time.Hour)})
+	assert.False(t, run)
+	assert.Equal(t, day.Add(26*time.Hour), next)
+}
+
+func TestCompactQuietHoursWrapsMidnight(t *testing.T) {
+	p := katalis.CompactQuietHours(23, 5)
+	day := time.Date(2026, 8, 30, 0, 0, 0, 0, time.Local)
+
+	// 01:00 belongs to the window that started at 23:00 yesterday.
+	run, _ := p.Evaluate(katalis.CompactionStatus{LastRun: day.Add(-4 * time.Hour), Now: day.Add(time.Hour)})
+	assert.True(t, run)
+}
+
+func TestCompactAdaptive(t *testing.T) {
+	p := katalis.CompactAdaptive()
+	now := time.Now()
+
+	run, next := p.Evaluate(katalis.CompactionStatus{FileSizeBytes: 1000, ReclaimableBytes: 0, Now: now})
+	assert.False(t, run)
+	assert.Equal(t, now.Add(time.Hour), next)
+
+	run, _ = p.Evaluate(katalis.CompactionStatus{FileSizeBytes: 1000, ReclaimableBytes: 250, Now: now})
+	assert.True(t, run)
+
+	// Dirtier stores are checked again sooner.
+	_, soon := p.Evaluate(katalis.CompactionStatus{FileSizeBytes: 1000, ReclaimableBytes: 200, Now: now})
+	assert.Less(t, soon.Sub(now), time.Hour)
+}
+
+// recordingPolicy asks for one compaction on its first evaluation and
+// records every status it sees, so the test can observe the scheduler.
+type recordingPolicy struct {
+	statuses chan katalis.CompactionStatus
+	ran      bool
+}
+
+func (p *recordingPolicy) Evaluate(s katalis.CompactionStatus) (bool, time.Time) {
+	select {
+	case p.statuses <- s:
+	default:
+	}
+	run := !p.ran
+	p.ran = true
+	return run, s.Now.Add(20 * time.Millisecond)
+}
+
+func TestBackgroundCompactionRuns(t *testing.T) {
+	policy := &recordingPolicy{statuses: make(chan katalis.CompactionStatus, 16)}
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec,
+		katalis.WithCompactionPolicy(policy))
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 20 {
+		require.NoError(t, db.Put(fmt.Sprintf("key-%d", i), "some value"))
+	}
+
+	// The first evaluation triggered a compaction, so LastRun must move
+	// forward in a later status.
+	first := <-policy.statuses
+	assert.False(t, first.LastRun.IsZero())
+	require.Eventually(t, func() bool {
+		select {
+		case s := <-policy.statuses:
+			return s.LastRun.After(first.LastRun)
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStatsExposesSchedule(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Put("a", "1"))
+
+	stats, err := db.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), stats.Records)
+	assert.Positive(t, stats.FileSizeBytes)
+	assert.Equal(t, "every 24h0m0s", stats.CompactionPolicy)
+
+	// The scheduler publishes the next run from its own goroutine.
+	require.Eventually(t, func() bool {
+		stats, err := db.Stats()
+		return err == nil && !stats.NextCompaction.IsZero()
+	}, time.Second, 10*time.Millisecond)
+	stats, err = db.Stats()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), stats.NextCompaction, time.Minute)
+}
+
+func TestStatsWithoutBackgroundCompaction(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec,
+		katalis.WithCompactionPolicy(nil))
+	require.NoError(t, err)
+	defer db.Close()
+
+	stats, err := db.Stats()
+	require.NoError(t, err)
+	assert.Empty(t, stats.CompactionPolicy)
+	assert.True(t, stats.NextCompaction.IsZero())
+}
@@ -0,0 +1,428 @@
+package katalis
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/akrylysov/pogreb"
+)
+
+type Codec[T any] interface {
+	Encode(T) ([]byte, error)
+	Decode([]byte) (T, error)
+}
+
+type DB[KT, VT any] struct {
+	*pogreb.DB
+	path     string
+	keyCodec Codec[KT]
+	valCodec Codec[VT]
+	txMu     *sync.Mutex  // serializes transactions, see Tx
+	locks    *lockTable   // per-key advisory locks, see Lock
+	closed   *atomic.Bool // set by Close, see closedErr
+
+	// Write size limits in bytes, 0 meaning unlimited. See WithMaxKeySize.
+	maxKeySize int
+	maxValSize int
+
+	quota *quotaState // disk usage quota, nil when unlimited. See WithMaxDiskUsage.
+
+	// Write rate limiters, nil when uncapped. See WithWriteRateLimit.
+	writeLimit *rateLimiter
+	byteLimit  *rateLimiter
+
+	bloom *bloomFilter // negative-lookup filter, nil unless WithBloomFilter is used.
+
+	async *asyncState[KT, VT] // write batcher, see PutAsync.
+
+	flights *flightGroup[VT] // read deduplicator, nil unless WithReadDedup is used.
+
+	vars *expvarStats // expvar counters, nil unless WithExpvar is used.
+
+	compactor *compactorState // background compaction scheduler, see WithCompactionPolicy.
+
+	syncer *syncState // runtime-tunable sync loop, see SetSyncInterval.
+
+	health *healthState // degraded-mode tracking, see Health.
+
+	metaMode bool // values carry metadata envelopes, see WithMetadata.
+
+	opTimeout time.Duration // per-operation deadline, 0 meaning none. See WithOpTimeout.
+
+	hook func(Event) // maintenance event observer, see WithEventHook.
+
+	rawPrefix []byte // namespace of a tenant view, nil for the root DB. See Tenant.
+}
+
+type Options = pogreb.Options
+
+var ErrIterationDone = pogreb.ErrIterationDone
+
+// Open opens or creates a new DB. The DB must be closed after use, by calling
+// Close method.
+func Open[KT, VT any](path string, keyCodec Codec[KT], valCodec Codec[VT], opts ...OpenOption) (db DB[KT, VT], err error) {
+	// Background compaction is scheduled by katalis itself (see
+	// WithCompactionPolicy), so the engine's own interval stays off.
+	c := openConfig{
+		opts: &pogreb.Options{
+			BackgroundSyncInterval: -1,
+		},
+		policy: CompactEvery(time.Hour * 24),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	db, err = OpenOptions(path, keyCodec, valCodec, c.opts)
+	if c.metadata {
+		db.valCodec = metaCodec[VT]{inner: db.valCodec}
+		db.metaMode = true
+	}
+	db.maxKeySize = c.maxKeySize
+	db.maxValSize = c.maxValSize
+	db.writeLimit = c.writeLimit
+	db.byteLimit = c.byteLimit
+	if err == nil && c.maxDiskUsage > 0 {
+		err = db.initQuota(c.maxDiskUsage)
+	}
+	if err == nil && c.bloom != nil {
+		err = db.initBloom(c.bloom)
+	}
+	if err == nil && c.expvarName != "" {
+		db.initExpvar(c.expvarName)
+	}
+	db.hook = c.hook
+	db.opTimeout = c.opTimeout
+	if c.readDedup {
+		db.flights = new(flightGroup[VT])
+	}
+	if err == nil {
+		db.startCompactor(c.policy)
+	}
+	return db, err
+}
+
+// OpenOptions is like Open but accepts an Options struct.
+func OpenOptions[KT, VT any](path string, keyCodec Codec[KT], valCodec Codec[VT], opts *Options) (db DB[KT, VT], err error) {
+	pg, err := pogreb.Open(path, opts)
+
+	db = DB[KT, VT]{
+		DB:       pg,
+		path:     path,
+		keyCodec: keyCodec,
+		valCodec: valCodec,
+		txMu:     new(sync.Mutex),
+		locks:    new(lockTable),
+		closed:   new(atomic.Bool),
+		async:    new(asyncState[KT, VT]),
+		syncer:   new(syncState),
+		health:   new(healthState),
+	}
+	if err == nil {
+		// Reapply a transaction committed to the WAL but interrupted before
+		// it fully reached the store.
+		err = replayWAL(path, pg)
+	}
+	return
+}
+
+// Path returns the directory the store was opened at.
+func (db DB[KT, VT]) Path() string { return db.path }
+
+// KeyCodec returns the codec the DB encodes keys with, so wrappers and
+// tooling can introspect a handle they were given. Note that on a tenant
+// view this is the prefixing codec, not the one passed to Open.
+func (db DB[KT, VT]) KeyCodec() Codec[KT] { return db.keyCodec }
+
+// ValCodec returns the codec the DB encodes values with.
+func (db DB[KT, VT]) ValCodec() Codec[VT] { return db.valCodec }
+
+// Get returns the value for the given key stored in the DB or an empty value if
+// the key doesn't exist.
+func (db DB[KT, VT]) Get(key KT) (res VT, err error) {
+	if err := db.closedErr(); err != nil {
+		return res, err
+	}
+
+	// Encode the key to []byte.
+	kbuf := getBuf()
+	defer putBuf(kbuf)
+	kb, err := encodeInto(db.keyCodec, kbuf, key)
+	if err != nil {
+		return res, opErr("get", key, KindEncodeKey, err)
+	}
+
+	// Collapse concurrent reads of the same key into one.
+	if db.flights != nil {
+		return db.flights.do(string(kb), func() (VT, error) {
+			return db.getEncoded(key, kb)
+		})
+	}
+	return db.getEncoded(key, kb)
+}
+
+// getEncoded is the read+decode behind Get, operating on the already encoded
+// key.
+func (db DB[KT, VT]) getEncoded(key KT, kb []byte) (res VT, err error) {
+	// The filter answers definitely-absent lookups without disk access.
+	if db.bloom != nil && !db.bloom.mayContain(kb) {
+		return res, nil
+	}
+
+	// Fetch from the DB the []byte of the value.
+	b, err := db.engineGet(kb)
+	if err != nil {
+		return res, opErr("get", key, KindIO, err)
+	}
+	// Decode the value into its type.
+	res, err = db.valCodec.Decode(b)
+	if err != nil {
+		return res, opErr("get", key, KindDecodeValue, err)
+	}
+	db.countGet()
+	return res, nil
+}
+
+// Put sets the value for the given key. It updates the value for the existing key.
+func (db DB[KT, VT]) Put(key KT, val VT) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	return db.put(key, val)
+}
+
+// put is Put without the closed check, so the async batcher can flush pending
+// writes while Close is draining it.
+func (db DB[KT, VT]) put(key KT, val VT) error {
+	// A degraded store rejects writes up front instead of piling raw OS
+	// errors on the caller.
+	if err := db.degradedErr(); err != nil {
+		return err
+	}
+
+	// Encode the key to []byte.
+	kbuf := getBuf()
+	defer putBuf(kbuf)
+	kb, err := encodeInto(db.keyCodec, kbuf, key)
+	if err != nil {
+		return opErr("put", key, KindEncodeKey, err)
+	}
+
+	// Encode the value to []byte.
+	vbuf := getBuf()
+	defer putBuf(vbuf)
+	vb, err := encodeInto(db.valCodec, vbuf, val)
+	if err != nil {
+		return opErr("put", key, KindEncodeValue, err)
+	}
+
+	// Keep the original creation timestamp when overwriting an enveloped
+	// record.
+	if db.metaMode {
+		db.preserveCreated(kb, vb)
+	}
+
+	// Reject oversized and quota-busting writes before they touch disk.
+	if err := db.checkLimits(key, kb, vb); err != nil {
+		return err
+	}
+	delta, err := db.checkQuota(key, kb, vb)
+	if err != nil {
+		return err
+	}
+	if err := db.limitWrite(len(kb) + len(vb)); err != nil {
+		return err
+	}
+
+	// Write in the DB the key and the value both as []byte.
+	if err := db.enginePut(kb, vb); err != nil {
+		db.noteWriteError(err)
+		return opErr("put", key, KindIO, err)
+	}
+	db.noteWriteSuccess()
+	db.commitQuota(delta)
+	if db.bloom != nil {
+		db.bloom.add(kb)
+	}
+	db.countPut()
+	return nil
+}
+
+// Del deletes the value for the given key from the DB.
+func (db DB[KT, VT]) Del(key KT) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	if err := db.degradedErr(); err != nil {
+		return err
+	}
+
+	// Encode the key to []byte.
+	kbuf := getBuf()
+	defer putBuf(kbuf)
+	kb, err := encodeInto(db.keyCodec, kbuf, key)
+	if err != nil {
+		return opErr("del", key, KindEncodeKey, err)
+	}
+	// Delete from the DB the key-value pair.
+	freed := db.deleteFootprint(kb)
+	if err := db.engineDelete(kb); err != nil {
+		db.noteWriteError(err)
+		return opErr("del", key, KindIO, err)
+	}
+	db.noteWriteSuccess()
+	db.commitQuota(-freed)
+	db.countDel()
+	return nil
+}
+
+// Has returns true if the DB contains the given key.
+func (db DB[KT, VT]) Has(key KT) (bool, error) {
+	if err := db.closedErr(); err != nil {
+		return false, err
+	}
+
+	// Encode the key to []byte.
+	kbuf := getBuf()
+	defer putBuf(kbuf)
+	kb, err := encodeInto(db.keyCodec, kbuf, key)
+	if err != nil {
+		return false, opErr("has", key, KindEncodeKey, err)
+	}
+
+	// The filter answers definitely-absent lookups without disk access.
+	if db.bloom != nil && !db.bloom.mayContain(kb) {
+		return false, nil
+	}
+	has, err := db.engineHas(kb)
+	if err != nil {
+		return false, opErr("has", key, KindIO, err)
+	}
+	return has, nil
+}
+
+// Fold iterates over all keys in the database calling the function `fn` for
+// each key. If the function returns an error, no further keys are processed
+// and the error returned.
+func (db DB[KT, VT]) Fold(fn func(key KT, val VT, err error) error) (err error) {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	iter := db.DB.Items()
+	for err == nil {
+		// Fetch the key-value pair from DB as []byte.
+		kb, vb, e := iter.Next()
+		if isTerminate(e) {
+			return nil
+		}
+		if e == nil && !db.inView(kb) {
+			continue
+		}
+		err = errors.Join(err, e)
+
+		// Decode the key into its type.
+		key, e := db.keyCodec.Decode(kb)
+		err = errors.Join(err, e)
+
+		// Decode the value into its type.
+		val, e := db.valCodec.Decode(vb)
+		err = errors.Join(err, e)
+
+		// Call the user-provided function. A panic in it re-panics wrapped
+		// with the current key, after the iterator unwound cleanly.
+		err = callFold(fn, key, val, err)
+	}
+	return
+}
+
+// Items returns an iterator over all key-value pairs in the database. Decode
+// errors are silently skipped, allowing iteration to continue. Use AllItems if
+// you need to handle errors explicitly.
+func (db DB[KT, VT]) Items() iter.Seq2[KT, VT] {
+	if db.closedErr() != nil {
+		return func(yield func(KT, VT) bool) {}
+	}
+	items := db.DB.Items()
+
+	return func(yield func(KT, VT) bool) {
+		for {
+			var key KT
+			var val VT
+
+			kb, vb, err := items.Next()
+			if isTerminate(err) {
+				return
+			}
+			if err == nil && !db.inView(kb) {
+				continue
+			}
+
+			if err == nil {
+				key, err = db.keyCodec.Decode(kb)
+			}
+			if err == nil {
+				val, err = db.valCodec.Decode(vb)
+			}
+
+			// Skip entries with errors
+			if err != nil {
+				continue
+			}
+
+			if !callYield(yield, key, val) {
+				return
+			}
+		}
+	}
+}
+
+// Entry represents a key-value pair from the database. It is used by AllItems
+// to return both the key and value together with potential errors during iteration.
+type Entry[KT, VT any] struct {
+	Key   KT
+	Value VT
+}
+
+// AllItems returns an iterator over all key-value pairs in the database with
+// error reporting. Unlike Items, decode errors are yielded to the caller rather
+// than terminating iteration.
+func (db DB[KT, VT]) AllItems() iter.Seq2[Entry[KT, VT], error] {
+	return func(yield func(Entry[KT, VT], error) bool) {
+		if err := db.closedErr(); err != nil {
+			yield(Entry[KT, VT]{}, err)
+			return
+		}
+		iter := db.DB.Items()
+		for {
+			var entry Entry[KT, VT]
+
+			kb, vb, err := iter.Next()
+			if isTerminate(err) {
+				return
+			}
+			if err == nil && !db.inView(kb) {
+				continue
+			}
+
+			if err == nil {
+				entry.Key, err = db.keyCodec.Decode(kb)
+			}
+			if err == nil {
+				entry.Value, err = db.valCodec.Decode(vb)
+			}
+
+			if !callYieldEntry(yield, entry, err) {
+				return
+			}
+		}
+	}
+}
+
+func isTerminate(err error) bool {
+	return errors.Is(err, pogreb.ErrIterationDone) || errors.Is(err, io.EOF)
+}
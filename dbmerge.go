@@ -0,0 +1,155 @@
+package katalis
+
+import "errors"
+
+// ErrNewestWinsNoMetadata is returned by Merge with the NewestWins policy
+// when either store was not opened with WithMetadata, since there are no
+// record timestamps to compare.
+var ErrNewestWinsNoMetadata = errors.New("katalis: newest-wins merge needs stores opened with WithMetadata")
+
+// Conflict describes a key present in both stores of a Merge, with the
+// decoded values on each side. The Meta fields carry the record timestamps
+// when the respective store was opened with WithMetadata and are zero
+// otherwise.
+type Conflict[KT, VT any] struct {
+	Key              KT
+	Dst, Src         VT
+	DstMeta, SrcMeta Meta
+}
+
+// ConflictPolicy decides what Merge writes for keys present in both stores.
+// Use KeepDst, KeepSrc, NewestWins or ResolveWith to build one.
+type ConflictPolicy[KT, VT any] struct {
+	// resolve returns the value to write and whether to write at all.
+	resolve func(Conflict[KT, VT]) (VT, bool, error)
+}
+
+// KeepDst is the policy that leaves conflicting records in the destination
+// untouched.
+func KeepDst[KT, VT any]() ConflictPolicy[KT, VT] {
+	return ConflictPolicy[KT, VT]{
+		resolve: func(c Conflict[KT, VT]) (VT, bool, error) {
+			return c.Dst, false, nil
+		},
+	}
+}
+
+// KeepSrc is the policy that overwrites conflicting records with the source
+// value.
+func KeepSrc[KT, VT any]() ConflictPolicy[KT, VT] {
+	return ConflictPolicy[KT, VT]{
+		resolve: func(c Conflict[KT, VT]) (VT, bool, error) {
+			return c.Src, true, nil
+		},
+	}
+}
+
+// NewestWins is the policy that keeps whichever record was updated last,
+// comparing the metadata timestamps of the two stores. Both stores must be
+// opened with WithMetadata; ties keep the destination.
+func NewestWins[KT, VT any]() ConflictPolicy[KT, VT] {
+	return ConflictPolicy[KT, VT]{
+		resolve: func(c Conflict[KT, VT]) (VT, bool, error) {
+			var zero VT
+			if c.DstMeta.Updated.IsZero() || c.SrcMeta.Updated.IsZero() {
+				return zero, false, ErrNewestWinsNoMetadata
+			}
+			if c.SrcMeta.Updated.After(c.DstMeta.Updated) {
+				return c.Src, true, nil
+			}
+			return c.Dst, false, nil
+		},
+	}
+}
+
+// ResolveWith is the policy that lets fn pick the value to write for each
+// conflict. An error from fn aborts the merge.
+func ResolveWith[KT, VT any](fn func(Conflict[KT, VT]) (VT, error)) ConflictPolicy[KT, VT] {
+	return ConflictPolicy[KT, VT]{
+		resolve: func(c Conflict[KT, VT]) (VT, bool, error) {
+			val, err := fn(c)
+			return val, err == nil, err
+		},
+	}
+}
+
+// MergeResult summarizes a Merge run.
+type MergeResult struct {
+	// Copied is the number of source records whose keys were absent from
+	// the destination.
+	Copied uint64
+	// Conflicts is the number of keys present in both stores.
+	Conflicts uint64
+	// Overwritten is how many conflicts the policy resolved in favor of a
+	// write.
+	Overwritten uint64
+}
+
+// Merge copies every record of src into dst, consulting the policy for keys
+// present in both stores. Records are decoded with the source codecs and
+// re-encoded with the destination's, so the two stores may differ in
+// metadata envelopes or key prefixes. The merge aborts on the first error,
+// returning the counts accumulated so far; src is never modified.
+func Merge[KT, VT any](dst, src DB[KT, VT], policy ConflictPolicy[KT, VT]) (MergeResult, error) {
+	var res MergeResult
+	if err := dst.closedErr(); err != nil {
+		return res, err
+	}
+	if err := src.closedErr(); err != nil {
+		return res, err
+	}
+
+	for kb, vb := range src.RawItems() {
+		key, err := src.keyCodec.Decode(kb)
+		if err != nil {
+			return res, err
+		}
+
+		has, err := dst.Has(key)
+		if err != nil {
+			return res, err
+		}
+
+		val, err := src.valCodec.Decode(vb)
+		if err != nil {
+			return res, err
+		}
+
+		if !has {
+			if err := dst.Put(key, val); err != nil {
+				return res, err
+			}
+			res.Copied++
+			continue
+		}
+		res.Conflicts++
+
+		c := Conflict[KT, VT]{Key: key, Src: val}
+		if c.Dst, err = dst.Get(key); err != nil {
+			return res, err
+		}
+		if src.metaMode {
+			if c.SrcMeta, err = parseMeta(vb); err != nil {
+				return res, err
+			}
+		}
+		if dst.metaMode {
+			if c.DstMeta, err = dst.GetMeta(key); err != nil {
+				return res, err
+			}
+		}
+
+		out, write, err := policy.resolve(c)
+		if err != nil {
+			return res, err
+		}
+		if !write {
+			continue
+		}
+		if err := dst.Put(key, out); err != nil {
+			return res, err
+		}
+		res.Overwritten++
+	}
+	return res, nil
+}
@@ -0,0 +1,126 @@
+package katalis_test
+
+import (
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openMergePair(t *testing.T) (dst, src katalis.DB[string, int]) {
+	t.Helper()
+	dir := t.TempDir()
+
+	dst, err := katalis.Open(dir+"/dst.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { dst.Close() })
+
+	src, err = katalis.Open(dir+"/src.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { src.Close() })
+	return dst, src
+}
+
+func TestMergeKeepDst(t *testing.T) {
+	dst, src := openMergePair(t)
+	require.NoError(t, dst.Put("a", 1))
+	require.NoError(t, src.Put("a", 100))
+	require.NoError(t, src.Put("b", 2))
+
+	res, err := katalis.Merge(dst, src, katalis.KeepDst[string, int]())
+	require.NoError(t, err)
+	assert.Equal(t, katalis.MergeResult{Copied: 1, Conflicts: 1}, res)
+
+	got := maps.Collect(dst.Items())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, got)
+}
+
+func TestMergeKeepSrc(t *testing.T) {
+	dst, src := openMergePair(t)
+	require.NoError(t, dst.Put("a", 1))
+	require.NoError(t, src.Put("a", 100))
+	require.NoError(t, src.Put("b", 2))
+
+	res, err := katalis.Merge(dst, src, katalis.KeepSrc[string, int]())
+	require.NoError(t, err)
+	assert.Equal(t, katalis.MergeResult{Copied: 1, Conflicts: 1, Overwritten: 1}, res)
+
+	got := maps.Collect(dst.Items())
+	assert.Equal(t, map[string]int{"a": 100, "b": 2}, got)
+
+	// The source is untouched.
+	got = maps.Collect(src.Items())
+	assert.Equal(t, map[string]int{"a": 100, "b": 2}, got)
+}
+
+func TestMergeNewestWins(t *testing.T) {
+	dir := t.TempDir()
+
+	dst, err := katalis.Open(dir+"/dst.db", katalis.StringCodec, katalis.IntCodec, katalis.WithMetadata())
+	require.NoError(t, err)
+	defer dst.Close()
+
+	src, err := katalis.Open(dir+"/src.db", katalis.StringCodec, katalis.IntCodec, katalis.WithMetadata())
+	require.NoError(t, err)
+	defer src.Close()
+
+	// "old" is written to src first, so dst's copy is newer; "new" the
+	// other way around.
+	require.NoError(t, src.Put("old", 100))
+	require.NoError(t, dst.Put("new", 1))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, dst.Put("old", 1))
+	require.NoError(t, src.Put("new", 100))
+
+	res, err := katalis.Merge(dst, src, katalis.NewestWins[string, int]())
+	require.NoError(t, err)
+	assert.Equal(t, katalis.MergeResult{Conflicts: 2, Overwritten: 1}, res)
+
+	got := maps.Collect(dst.Items())
+	assert.Equal(t, map[string]int{"old": 1, "new": 100}, got)
+}
+
+func TestMergeNewestWinsNoMetadata(t *testing.T) {
+	dst, src := openMergePair(t)
+	require.NoError(t, dst.Put("a", 1))
+	require.NoError(t, src.Put("a", 2))
+
+	_, err := katalis.Merge(dst, src, katalis.NewestWins[string, int]())
+	assert.ErrorIs(t, err, katalis.ErrNewestWinsNoMetadata)
+}
+
+func TestMergeResolveWith(t *testing.T) {
+	dst, src := openMergePair(t)
+	require.NoError(t, dst.Put("a", 1))
+	require.NoError(t, src.Put("a", 2))
+
+	res, err := katalis.Merge(dst, src, katalis.ResolveWith(func(c katalis.Conflict[string, int]) (int, error) {
+		return c.Dst + c.Src, nil
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, katalis.MergeResult{Conflicts: 1, Overwritten: 1}, res)
+
+	v, err := dst.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+}
+
+func TestMergeResolveWithError(t *testing.T) {
+	dst, src := openMergePair(t)
+	require.NoError(t, dst.Put("a", 1))
+	require.NoError(t, src.Put("a", 2))
+
+	boom := errors.New("boom")
+	_, err := katalis.Merge(dst, src, katalis.ResolveWith(func(c katalis.Conflict[string, int]) (int, error) {
+		return 0, boom
+	}))
+	assert.ErrorIs(t, err, boom)
+
+	v, err := dst.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
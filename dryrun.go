@@ -0,0 +1,191 @@
+package katalis
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+)
+
+// Mutation is a single write or deletion recorded by a dry run, with the
+// decoded key and value rather than the encoded bytes of a journal Change.
+// Val is the zero value for deletions.
+type Mutation[KT, VT any] struct {
+	Op  Op
+	Key KT
+	Val VT
+}
+
+// String formats the mutation as "put key=... val=..." or "del key=...".
+func (m Mutation[KT, VT]) String() string {
+	if m.Op == OpDel {
+		return fmt.Sprintf("del key=%v", m.Key)
+	}
+	return fmt.Sprintf("put key=%v val=%v", m.Key, m.Val)
+}
+
+// DryRunDB wraps a DB recording intended mutations instead of applying them:
+// Put and Del never touch the store, while reads through the wrapper observe
+// the staged state, so migration or import code behaves as if its writes had
+// landed. The recorded change set is returned by Changes, ready to review
+// before rerunning the same code against the real DB.
+type DryRunDB[KT, VT any] struct {
+	DB[KT, VT]
+
+	mu      *sync.Mutex
+	staged  *map[string]stagedOp
+	changes *[]Mutation[KT, VT]
+}
+
+// DryRun wraps the DB in audit mode: mutations through the wrapper are
+// recorded, not applied.
+func DryRun[KT, VT any](db DB[KT, VT]) DryRunDB[KT, VT] {
+	staged := make(map[string]stagedOp)
+	return DryRunDB[KT, VT]{
+		DB:      db,
+		mu:      &sync.Mutex{},
+		staged:  &staged,
+		changes: &[]Mutation[KT, VT]{},
+	}
+}
+
+// Get returns the value for the given key as it would read after the staged
+// mutations were applied.
+func (d DryRunDB[KT, VT]) Get(key KT) (res VT, err error) {
+	kb, err := d.keyCodec.Encode(key)
+	if err != nil {
+		return res, err
+	}
+
+	d.mu.Lock()
+	op, ok := (*d.staged)[string(kb)]
+	d.mu.Unlock()
+
+	if !ok {
+		return d.DB.Get(key)
+	}
+	if op.del {
+		return d.valCodec.Decode(nil)
+	}
+	return d.valCodec.Decode(op.val)
+}
+
+// Has returns true if the key would exist after the staged mutations were
+// applied.
+func (d DryRunDB[KT, VT]) Has(key KT) (bool, error) {
+	kb, err := d.keyCodec.Encode(key)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	op, ok := (*d.staged)[string(kb)]
+	d.mu.Unlock()
+
+	if ok {
+		return !op.del, nil
+	}
+	return d.DB.Has(key)
+}
+
+// Put records the intended write without touching the store.
+func (d DryRunDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := d.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := d.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	(*d.staged)[string(kb)] = stagedOp{val: vb}
+	*d.changes = append(*d.changes, Mutation[KT, VT]{Op: OpPut, Key: key, Val: val})
+	return nil
+}
+
+// Del records the intended deletion without touching the store.
+func (d DryRunDB[KT, VT]) Del(key KT) error {
+	kb, err := d.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	(*d.staged)[string(kb)] = stagedOp{del: true}
+	*d.changes = append(*d.changes, Mutation[KT, VT]{Op: OpDel, Key: key})
+	return nil
+}
+
+// Items returns an iterator over the records as they would read after the
+// staged mutations were applied. Iteration order is not specified.
+func (d DryRunDB[KT, VT]) Items() iter.Seq2[KT, VT] {
+	return func(yield func(KT, VT) bool) {
+		d.mu.Lock()
+		staged := make(map[string]stagedOp, len(*d.staged))
+		for kb, op := range *d.staged {
+			staged[kb] = op
+		}
+		d.mu.Unlock()
+
+		for kb, op := range staged {
+			if op.del {
+				continue
+			}
+
+			key, err := d.keyCodec.Decode([]byte(kb))
+			if err != nil {
+				return
+			}
+			val, err := d.valCodec.Decode(op.val)
+			if err != nil {
+				return
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+
+		for kb, vb := range d.RawItems() {
+			if _, ok := staged[string(kb)]; ok {
+				continue
+			}
+
+			key, err := d.keyCodec.Decode(kb)
+			if err != nil {
+				return
+			}
+			val, err := d.valCodec.Decode(vb)
+			if err != nil {
+				return
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+	}
+}
+
+// Changes returns the recorded mutations in the order they were issued,
+// including ones later overwritten by another mutation of the same key.
+func (d DryRunDB[KT, VT]) Changes() []Mutation[KT, VT] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]Mutation[KT, VT], len(*d.changes))
+	copy(out, *d.changes)
+	return out
+}
+
+// String formats the recorded change set, one mutation per line.
+func (d DryRunDB[KT, VT]) String() string {
+	var sb strings.Builder
+	for _, c := range d.Changes() {
+		sb.WriteString(c.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+package katalis_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunDoesNotMutate(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+
+	dry := katalis.DryRun(db)
+	require.NoError(t, dry.Put("a", 10))
+	require.NoError(t, dry.Put("b", 2))
+	require.NoError(t, dry.Del("a"))
+
+	// The underlying store is untouched.
+	v, err := db.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	has, err := db.Has("b")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestDryRunReadsObserveStaged(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	dry := katalis.DryRun(db)
+	require.NoError(t, dry.Put("a", 10))
+	require.NoError(t, dry.Del("b"))
+	require.NoError(t, dry.Put("c", 3))
+
+	v, err := dry.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 10, v)
+
+	has, err := dry.Has("b")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	got := maps.Collect(dry.Items())
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, got)
+}
+
+func TestDryRunChanges(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	dry := katalis.DryRun(db)
+	require.NoError(t, dry.Put("a", 1))
+	require.NoError(t, dry.Del("a"))
+	require.NoError(t, dry.Put("b", 2))
+
+	want := []katalis.Mutation[string, int]{
+		{Op: katalis.OpPut, Key: "a", Val: 1},
+		{Op: katalis.OpDel, Key: "a"},
+		{Op: katalis.OpPut, Key: "b", Val: 2},
+	}
+	assert.Equal(t, want, dry.Changes())
+	assert.Equal(t, "put key=a val=1\ndel key=a\nput key=b val=2\n", dry.String())
+}
@@ -0,0 +1,43 @@
+package katalis
+
+import "time"
+
+// Durability controls when writes are fsynced to disk, trading safety against
+// write throughput. Pass it to Open through WithDurability.
+type Durability int
+
+const (
+	// Always fsyncs after every write. It is the default: no acknowledged
+	// write is lost on power failure.
+	Always Durability = iota
+	// Interval fsyncs in the background once per second. A crash can lose up
+	// to the last second of writes; use PutSync for the ones that matter.
+	Interval
+	// Never leaves syncing entirely to explicit Sync calls and Close. Fastest
+	// and least safe.
+	Never
+)
+
+// WithDurability sets the durability level of the store.
+func WithDurability(d Durability) OpenOption {
+	return func(c *openConfig) {
+		switch d {
+		case Always:
+			c.opts.BackgroundSyncInterval = -1
+		case Interval:
+			c.opts.BackgroundSyncInterval = time.Second
+		case Never:
+			c.opts.BackgroundSyncInterval = 0
+		}
+	}
+}
+
+// PutSync sets the value for the given key and fsyncs before returning,
+// regardless of the store's durability level. Use it for writes that must
+// survive power loss when the store was opened with Interval or Never.
+func (db DB[KT, VT]) PutSync(key KT, val VT) error {
+	if err := db.Put(key, val); err != nil {
+		return err
+	}
+	return db.Sync()
+}
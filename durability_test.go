@@ -0,0 +1,51 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDurability(t *testing.T) {
+	levels := []struct {
+		name  string
+		level katalis.Durability
+	}{
+		{"always", katalis.Always},
+		{"interval", katalis.Interval},
+		{"never", katalis.Never},
+	}
+
+	for _, tc := range levels {
+		t.Run(tc.name, func(t *testing.T) {
+			path := t.TempDir() + "/test.db"
+
+			db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec, katalis.WithDurability(tc.level))
+			require.NoError(t, err)
+			require.NoError(t, db.Put("key", 42))
+			require.NoError(t, db.Close())
+
+			db, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+			require.NoError(t, err)
+			defer db.Close()
+
+			val, err := db.Get("key")
+			require.NoError(t, err)
+			assert.Equal(t, 42, val)
+		})
+	}
+}
+
+func TestPutSync(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec, katalis.WithDurability(katalis.Never))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.PutSync("key", 42))
+
+	val, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+}
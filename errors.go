@@ -0,0 +1,117 @@
+package katalis
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ErrorKind classifies what went wrong in an Error, so callers can react to
+// whole categories of failures with errors.Is instead of matching strings.
+type ErrorKind uint8
+
+const (
+	// KindIO is a failure of the underlying storage engine.
+	KindIO ErrorKind = iota
+	// KindNotFound means the requested record does not exist.
+	KindNotFound
+	// KindCorrupt means a record was read but its bytes are not valid.
+	KindCorrupt
+	// KindClosed means the DB was already closed.
+	KindClosed
+	// KindEncodeKey means the key could not be encoded with the key codec.
+	KindEncodeKey
+	// KindEncodeValue means the value could not be encoded with the value codec.
+	KindEncodeValue
+	// KindDecodeKey means a stored key could not be decoded with the key codec.
+	KindDecodeKey
+	// KindDecodeValue means a stored value could not be decoded with the value
+	// codec.
+	KindDecodeValue
+	// KindTooLarge means the encoded key or value exceeded a size limit.
+	KindTooLarge
+	// KindQuota means the write would exceed the disk usage quota.
+	KindQuota
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindIO:
+		return "io"
+	case KindNotFound:
+		return "not found"
+	case KindCorrupt:
+		return "corrupt"
+	case KindClosed:
+		return "closed"
+	case KindEncodeKey:
+		return "encode key"
+	case KindEncodeValue:
+		return "encode value"
+	case KindDecodeKey:
+		return "decode key"
+	case KindDecodeValue:
+		return "decode value"
+	case KindTooLarge:
+		return "too large"
+	case KindQuota:
+		return "quota exceeded"
+	default:
+		return "unknown"
+	}
+}
+
+// Error is the error type returned by DB operations. It carries the failing
+// operation, the stringified key when one was involved, and a kind so that
+// decode failures, IO failures and closed-store errors are distinguishable.
+type Error struct {
+	// Op is the operation that failed, e.g. "get" or "put".
+	Op string
+	// Key is the stringified key involved, empty when none applies.
+	Key string
+	// Kind classifies the failure.
+	Kind ErrorKind
+	// Err is the underlying cause, possibly nil.
+	Err error
+}
+
+func (e *Error) Error() string {
+	msg := "katalis: " + e.Op
+	if e.Key != "" {
+		msg += " " + strconv.Quote(e.Key)
+	}
+	msg += ": " + e.Kind.String()
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is makes errors.Is match two Errors by kind (and by op and key when the
+// target specifies them), and maps KindNotFound and KindClosed to the
+// ErrNotFound and ErrClosed sentinels.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Kind == KindNotFound
+	case ErrClosed:
+		return e.Kind == KindClosed
+	case ErrTooLarge:
+		return e.Kind == KindTooLarge
+	case ErrQuotaExceeded:
+		return e.Kind == KindQuota
+	}
+	if t, ok := target.(*Error); ok {
+		return t.Kind == e.Kind &&
+			(t.Op == "" || t.Op == e.Op) &&
+			(t.Key == "" || t.Key == e.Key)
+	}
+	return false
+}
+
+// opErr builds an *Error for the given operation, key and kind.
+func opErr(op string, key any, kind ErrorKind, err error) *Error {
+	return &Error{Op: op, Key: fmt.Sprint(key), Kind: kind, Err: err}
+}
@@ -0,0 +1,51 @@
+package katalis_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorDecodeValue(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Plant a value that cannot decode as int.
+	require.NoError(t, db.DB.Put([]byte("bad"), []byte{1}))
+
+	_, err = db.Get("bad")
+	require.Error(t, err)
+
+	var kerr *katalis.Error
+	require.ErrorAs(t, err, &kerr)
+	assert.Equal(t, "get", kerr.Op)
+	assert.Equal(t, "bad", kerr.Key)
+	assert.Equal(t, katalis.KindDecodeValue, kerr.Kind)
+	assert.NotNil(t, kerr.Err)
+
+	// Matching by kind alone works through errors.Is.
+	assert.ErrorIs(t, err, &katalis.Error{Kind: katalis.KindDecodeValue})
+	assert.NotErrorIs(t, err, &katalis.Error{Kind: katalis.KindIO})
+}
+
+func TestErrorSentinelMapping(t *testing.T) {
+	closed := &katalis.Error{Op: "get", Kind: katalis.KindClosed}
+	assert.ErrorIs(t, closed, katalis.ErrClosed)
+
+	notFound := &katalis.Error{Op: "get", Kind: katalis.KindNotFound}
+	assert.ErrorIs(t, notFound, katalis.ErrNotFound)
+}
+
+func TestErrorMessage(t *testing.T) {
+	err := &katalis.Error{
+		Op:   "get",
+		Key:  "user:7",
+		Kind: katalis.KindDecodeValue,
+		Err:  errors.New("too short"),
+	}
+	assert.Equal(t, `katalis: get "user:7": decode value: too short`, err.Error())
+}
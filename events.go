@@ -0,0 +1,84 @@
+package katalis
+
+import "time"
+
+// EventKind is the kind of maintenance event emitted by the store.
+type EventKind int
+
+const (
+	// EventCompactionStart fires when a compaction begins, whether
+	// triggered by Compact or by the background policy.
+	EventCompactionStart EventKind = iota
+	// EventCompactionEnd fires when that compaction finishes, carrying the
+	// reclaimed bytes, the duration and the error if it failed.
+	EventCompactionEnd
+	// EventSync fires whenever the library fsyncs the store: explicit Sync
+	// and Flush calls, and the batched sync behind PutAsync.
+	EventSync
+	// EventDegraded fires when the store switches to degraded read-only
+	// mode, carrying the write error that tripped it. See Health.
+	EventDegraded
+)
+
+// String returns the event kind name.
+func (k EventKind) String() string {
+	switch k {
+	case EventCompactionStart:
+		return "compaction-start"
+	case EventCompactionEnd:
+		return "compaction-end"
+	case EventSync:
+		return "sync"
+	case EventDegraded:
+		return "degraded"
+	}
+	return "unknown"
+}
+
+// Event is one maintenance event. Correlating these with service latency
+// tells whether a spike lines up with katalis housekeeping.
+type Event struct {
+	Kind EventKind
+	// At is when the event fired.
+	At time.Time
+	// ReclaimedBytes is how much a compaction freed, set on
+	// EventCompactionEnd.
+	ReclaimedBytes int64
+	// Duration is how long the compaction ran, set on EventCompactionEnd.
+	Duration time.Duration
+	// Err is the failure of the run, set on EventCompactionEnd.
+	Err error
+}
+
+// WithEventHook registers fn as the observer of maintenance events. fn is
+// called synchronously from the goroutine doing the maintenance, so it must
+// be fast and safe for concurrent use; typically it just feeds a channel or
+// a metrics counter.
+func WithEventHook(fn func(Event)) OpenOption {
+	return func(c *openConfig) { c.hook = fn }
+}
+
+// emit delivers the event to the hook, stamping the time. A DB without a
+// hook emits nothing.
+func (db DB[KT, VT]) emit(e Event) {
+	if db.hook == nil {
+		return
+	}
+	e.At = time.Now()
+	db.hook(e)
+}
+
+// emitCompaction wraps a compaction run with its start and end events.
+func (db DB[KT, VT]) emitCompaction(run func() (CompactionResult, error)) (CompactionResult, error) {
+	db.emit(Event{Kind: EventCompactionStart})
+	start := time.Now()
+
+	result, err := run()
+	db.emit(Event{
+		Kind:           EventCompactionEnd,
+		ReclaimedBytes: int64(result.ReclaimedBytes),
+		Duration:       time.Since(start),
+		Err:            err,
+	})
+	return result, err
+}
@@ -0,0 +1,92 @@
+package katalis_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventLog collects emitted events safely across goroutines.
+type eventLog struct {
+	mu     sync.Mutex
+	events []katalis.Event
+}
+
+func (l *eventLog) record(e katalis.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+}
+
+func (l *eventLog) kinds() []katalis.EventKind {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	kinds := make([]katalis.EventKind, len(l.events))
+	for i, e := range l.events {
+		kinds[i] = e.Kind
+	}
+	return kinds
+}
+
+func TestCompactionEvents(t *testing.T) {
+	var log eventLog
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithEventHook(log.record))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 42))
+	_, err = db.Compact(context.Background(), nil)
+	require.NoError(t, err)
+
+	kinds := log.kinds()
+	require.Len(t, kinds, 2)
+	assert.Equal(t, katalis.EventCompactionStart, kinds[0])
+	assert.Equal(t, katalis.EventCompactionEnd, kinds[1])
+
+	log.mu.Lock()
+	end := log.events[1]
+	log.mu.Unlock()
+	assert.NoError(t, end.Err)
+	assert.False(t, end.At.IsZero())
+	assert.GreaterOrEqual(t, end.Duration, time.Duration(0))
+}
+
+func TestSyncEvents(t *testing.T) {
+	var log eventLog
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithEventHook(log.record))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 1))
+	require.NoError(t, db.Sync())
+	assert.Equal(t, []katalis.EventKind{katalis.EventSync}, log.kinds())
+
+	// The batched sync behind PutAsync emits too.
+	require.NoError(t, <-db.PutAsync("key", 2))
+	assert.Contains(t, log.kinds(), katalis.EventSync)
+	assert.GreaterOrEqual(t, len(log.kinds()), 2)
+}
+
+func TestEventKindString(t *testing.T) {
+	assert.Equal(t, "compaction-start", katalis.EventCompactionStart.String())
+	assert.Equal(t, "compaction-end", katalis.EventCompactionEnd.String())
+	assert.Equal(t, "sync", katalis.EventSync.String())
+}
+
+func TestNoHookNoPanic(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 1))
+	require.NoError(t, db.Sync())
+	_, err = db.Compact(context.Background(), nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,75 @@
+package katalis
+
+import "expvar"
+
+// expvarStats holds the operation counters published by WithExpvar.
+type expvarStats struct {
+	gets, puts, dels expvar.Int
+}
+
+// WithExpvar publishes live DB statistics on the standard expvar page under
+// "katalis.<name>", so anything already scraping /debug/vars picks them up
+// with no extra wiring:
+//
+//	db, err := katalis.Open(path, kc, vc, katalis.WithExpvar("sessions"))
+//
+// The map carries the gets/puts/dels operation counters of this DB handle
+// and the records, file_size_bytes and reclaimable_bytes gauges, read from
+// the store when scraped. Names are process-wide: opening a second DB with
+// the same name rebinds the variables to the new handle.
+func WithExpvar(name string) OpenOption {
+	return func(c *openConfig) { c.expvarName = name }
+}
+
+func (db *DB[KT, VT]) initExpvar(name string) {
+	db.vars = new(expvarStats)
+
+	// Rebind an already published map instead of letting expvar.Publish
+	// panic when a DB is reopened under the same name.
+	full := "katalis." + name
+	m, ok := expvar.Get(full).(*expvar.Map)
+	if !ok {
+		m = new(expvar.Map)
+		expvar.Publish(full, m)
+	}
+
+	m.Set("gets", &db.vars.gets)
+	m.Set("puts", &db.vars.puts)
+	m.Set("dels", &db.vars.dels)
+
+	snapshot := *db
+	m.Set("records", expvar.Func(func() any { return snapshot.Count() }))
+	m.Set("file_size_bytes", expvar.Func(func() any {
+		size, err := snapshot.FileSize()
+		if err != nil {
+			return 0
+		}
+		return size
+	}))
+	m.Set("reclaimable_bytes", expvar.Func(func() any {
+		reclaimable, err := snapshot.ReclaimableBytes()
+		if err != nil {
+			return 0
+		}
+		return reclaimable
+	}))
+}
+
+// countGet bumps the expvar get counter when publishing is enabled.
+func (db DB[KT, VT]) countGet() {
+	if db.vars != nil {
+		db.vars.gets.Add(1)
+	}
+}
+
+func (db DB[KT, VT]) countPut() {
+	if db.vars != nil {
+		db.vars.puts.Add(1)
+	}
+}
+
+func (db DB[KT, VT]) countDel() {
+	if db.vars != nil {
+		db.vars.dels.Add(1)
+	}
+}
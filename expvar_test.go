@@ -0,0 +1,74 @@
+package katalis_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithExpvar(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec,
+		katalis.WithExpvar("expvar-test"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", "1"))
+	require.NoError(t, db.Put("b", "2"))
+	_, err = db.Get("a")
+	require.NoError(t, err)
+	require.NoError(t, db.Del("b"))
+
+	v := expvar.Get("katalis.expvar-test")
+	require.NotNil(t, v)
+
+	var stats struct {
+		Gets          int64 `json:"gets"`
+		Puts          int64 `json:"puts"`
+		Dels          int64 `json:"dels"`
+		Records       int64 `json:"records"`
+		FileSizeBytes int64 `json:"file_size_bytes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(v.String()), &stats))
+	assert.Equal(t, int64(1), stats.Gets)
+	assert.Equal(t, int64(2), stats.Puts)
+	assert.Equal(t, int64(1), stats.Dels)
+	assert.Equal(t, int64(1), stats.Records)
+	assert.Positive(t, stats.FileSizeBytes)
+}
+
+func TestWithExpvarReopen(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.StringCodec,
+		katalis.WithExpvar("expvar-reopen"))
+	require.NoError(t, err)
+	require.NoError(t, db.Put("a", "1"))
+	require.NoError(t, db.Close())
+
+	// Reopening under the same name must rebind, not panic.
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.StringCodec,
+		katalis.WithExpvar("expvar-reopen"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	var stats struct {
+		Puts    int64 `json:"puts"`
+		Records int64 `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("katalis.expvar-reopen").String()), &stats))
+	assert.Zero(t, stats.Puts)
+	assert.Equal(t, int64(1), stats.Records)
+}
+
+func TestExpvarDisabledByDefault(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", "1"))
+	assert.Nil(t, expvar.Get("katalis."))
+}
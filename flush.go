@@ -0,0 +1,38 @@
+package katalis
+
+import "context"
+
+// Sync fsyncs pending writes to disk. It is the typed counterpart of the
+// underlying store's Sync: safe on a closed or zero DB, where it returns
+// ErrClosed instead of panicking.
+func (db DB[KT, VT]) Sync() error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	db.emit(Event{Kind: EventSync})
+	return db.DB.Sync()
+}
+
+// Flush makes everything written so far durable: it waits for writes still
+// queued by PutAsync to be applied and fsynced, then syncs the store itself.
+// Use it as the single barrier before taking filesystem or VM snapshots.
+// When ctx expires before the async queue drains, Flush returns its error;
+// the queued writes still land eventually.
+func (db DB[KT, VT]) Flush(ctx context.Context) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	if done := db.asyncBarrier(); done != nil {
+		select {
+		case err := <-done:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	db.emit(Event{Kind: EventSync})
+	return db.DB.Sync()
+}
@@ -0,0 +1,58 @@
+package katalis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncTyped(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Put("key", 42))
+	require.NoError(t, db.Sync())
+
+	require.NoError(t, db.Close())
+	assert.ErrorIs(t, db.Sync(), katalis.ErrClosed)
+
+	var zero katalis.DB[string, int]
+	assert.NotPanics(t, func() {
+		assert.ErrorIs(t, zero.Sync(), katalis.ErrClosed)
+	})
+}
+
+func TestFlushWaitsForAsyncWrites(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 100 {
+		db.PutAsync("key", i)
+	}
+	require.NoError(t, db.Flush(context.Background()))
+
+	v, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 99, v)
+}
+
+func TestFlushWithoutAsyncWrites(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 1))
+	require.NoError(t, db.Flush(context.Background()))
+}
+
+func TestFlushClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	assert.ErrorIs(t, db.Flush(context.Background()), katalis.ErrClosed)
+}
@@ -0,0 +1,327 @@
+// Package fts adds full-text search to a katalis DB. Writes go through the
+// index, which tokenizes the string fields chosen by the caller and keeps an
+// inverted index in a sibling store, so queries resolve from per-token
+// posting lists instead of a full scan over the records.
+//
+// Queries are case-insensitive term lists: terms separated by spaces must
+// all match (AND), the word OR separates alternatives, and a trailing *
+// makes a term match by prefix. "grpc client OR server*" matches records
+// containing both "grpc" and "client", plus records containing any token
+// starting with "server".
+package fts
+
+import (
+	"encoding/binary"
+	"errors"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Index wraps a katalis DB with an inverted full-text index over its values.
+// Records must be written through the index for it to stay consistent; use
+// Reindex after touching the primary store directly.
+type Index[KT, VT any] struct {
+	db     katalis.DB[KT, VT]
+	inv    katalis.DB[[]byte, []byte] // token -> posting list of encoded keys
+	kc     katalis.Codec[KT]
+	fields func(VT) []string
+	mu     sync.Mutex // serializes posting-list read-modify-writes
+}
+
+// Open opens or creates an indexed store at the given path, with the
+// inverted index in a sibling store at path + ".fts". fields extracts the
+// strings of a value that should be searchable. The index must be closed
+// after use, by calling Close.
+func Open[KT, VT any](path string, keyCodec katalis.Codec[KT], valCodec katalis.Codec[VT], fields func(VT) []string) (*Index[KT, VT], error) {
+	db, err := katalis.Open(path, keyCodec, valCodec)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := katalis.Open(path+".fts", katalis.BytesCodec, katalis.BytesCodec)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Index[KT, VT]{db: db, inv: inv, kc: keyCodec, fields: fields}, nil
+}
+
+// Close closes the primary store and the inverted index.
+func (ix *Index[KT, VT]) Close() error {
+	return errors.Join(ix.db.Close(), ix.inv.Close())
+}
+
+// Get returns the value for the given key stored in the primary DB, or an
+// empty value if the key doesn't exist.
+func (ix *Index[KT, VT]) Get(key KT) (VT, error) { return ix.db.Get(key) }
+
+// Has returns true if the primary DB contains the given key.
+func (ix *Index[KT, VT]) Has(key KT) (bool, error) { return ix.db.Has(key) }
+
+// Put stores the value under key and updates the inverted index: tokens no
+// longer present in the value drop this key from their posting lists, new
+// ones gain it.
+func (ix *Index[KT, VT]) Put(key KT, val VT) error {
+	kb, err := ix.kc.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	old, err := ix.tokensOf(key)
+	if err != nil {
+		return err
+	}
+	if err := ix.db.Put(key, val); err != nil {
+		return err
+	}
+	return ix.repost(kb, old, tokenize(ix.fields(val)))
+}
+
+// Del deletes the record and drops its key from every posting list it was
+// on.
+func (ix *Index[KT, VT]) Del(key KT) error {
+	kb, err := ix.kc.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	old, err := ix.tokensOf(key)
+	if err != nil {
+		return err
+	}
+	if err := ix.db.Del(key); err != nil {
+		return err
+	}
+	return ix.repost(kb, old, nil)
+}
+
+// Search returns an iterator over the keys of the records matching the
+// query, in encoded-key order. Keys that fail to decode are skipped.
+func (ix *Index[KT, VT]) Search(query string) iter.Seq[KT] {
+	return func(yield func(KT) bool) {
+		var matches map[string]struct{}
+		for _, group := range parseQuery(query) {
+			kbs, err := ix.matchGroup(group)
+			if err != nil {
+				return
+			}
+			if matches == nil {
+				matches = kbs
+				continue
+			}
+			for kb := range kbs {
+				matches[kb] = struct{}{}
+			}
+		}
+
+		for _, kb := range slices.Sorted(maps.Keys(matches)) {
+			key, err := ix.kc.Decode([]byte(kb))
+			if err != nil {
+				continue
+			}
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Reindex rebuilds the inverted index from scratch by scanning the primary
+// store, for adopting a store written without the index or recovering from
+// a partial write.
+func (ix *Index[KT, VT]) Reindex() error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if err := ix.inv.Clear(); err != nil {
+		return err
+	}
+	return ix.db.Fold(func(key KT, val VT, err error) error {
+		if err != nil {
+			return err
+		}
+		kb, err := ix.kc.Encode(key)
+		if err != nil {
+			return err
+		}
+		return ix.repost(kb, nil, tokenize(ix.fields(val)))
+	})
+}
+
+// tokensOf returns the tokens of the value currently stored under key, nil
+// when the key is absent.
+func (ix *Index[KT, VT]) tokensOf(key KT) ([]string, error) {
+	has, err := ix.db.Has(key)
+	if err != nil || !has {
+		return nil, err
+	}
+	val, err := ix.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return tokenize(ix.fields(val)), nil
+}
+
+// repost moves kb between posting lists so it appears exactly on the lists
+// of cur. The caller holds ix.mu.
+func (ix *Index[KT, VT]) repost(kb []byte, old, cur []string) error {
+	for _, tok := range old {
+		if !slices.Contains(cur, tok) {
+			if err := ix.editPosting(tok, kb, false); err != nil {
+				return err
+			}
+		}
+	}
+	for _, tok := range cur {
+		if err := ix.editPosting(tok, kb, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// editPosting adds kb to or removes it from the token's posting list.
+func (ix *Index[KT, VT]) editPosting(token string, kb []byte, add bool) error {
+	tk := []byte(token)
+	raw, err := ix.inv.Get(tk)
+	if err != nil {
+		return err
+	}
+
+	kbs := splitPostings(raw)
+	i := slices.IndexFunc(kbs, func(p []byte) bool { return slices.Equal(p, kb) })
+	switch {
+	case add && i < 0:
+		kbs = append(kbs, kb)
+	case !add && i >= 0:
+		kbs = slices.Delete(kbs, i, i+1)
+	default:
+		return nil
+	}
+
+	if len(kbs) == 0 {
+		return ix.inv.Del(tk)
+	}
+	raw = raw[:0]
+	for _, p := range kbs {
+		raw = binary.AppendUvarint(raw, uint64(len(p)))
+		raw = append(raw, p...)
+	}
+	return ix.inv.Put(tk, raw)
+}
+
+// matchGroup resolves one AND-group of terms into the set of encoded keys
+// matching all of them.
+func (ix *Index[KT, VT]) matchGroup(terms []string) (map[string]struct{}, error) {
+	var matches map[string]struct{}
+	for _, term := range terms {
+		kbs, err := ix.matchTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			matches = kbs
+			continue
+		}
+		for kb := range matches {
+			if _, ok := kbs[kb]; !ok {
+				delete(matches, kb)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// matchTerm resolves one term into the set of encoded keys on its posting
+// lists. An exact term is a single index lookup; a prefix term unions the
+// lists of every token it covers.
+func (ix *Index[KT, VT]) matchTerm(term string) (map[string]struct{}, error) {
+	matches := make(map[string]struct{})
+
+	prefix, ok := strings.CutSuffix(term, "*")
+	if !ok {
+		raw, err := ix.inv.Get([]byte(term))
+		if err != nil {
+			return nil, err
+		}
+		for _, kb := range splitPostings(raw) {
+			matches[string(kb)] = struct{}{}
+		}
+		return matches, nil
+	}
+
+	for tk, raw := range ix.inv.Items() {
+		if !strings.HasPrefix(string(tk), prefix) {
+			continue
+		}
+		for _, kb := range splitPostings(raw) {
+			matches[string(kb)] = struct{}{}
+		}
+	}
+	return matches, nil
+}
+
+// splitPostings parses a posting list into its encoded keys. Malformed
+// tails are dropped rather than failing the whole query.
+func splitPostings(raw []byte) [][]byte {
+	var kbs [][]byte
+	for len(raw) > 0 {
+		n, read := binary.Uvarint(raw)
+		if read <= 0 || uint64(len(raw)-read) < n {
+			return kbs
+		}
+		kbs = append(kbs, slices.Clone(raw[read:read+int(n)]))
+		raw = raw[read+int(n):]
+	}
+	return kbs
+}
+
+// tokenize lowercases the fields and splits them into alphanumeric tokens,
+// deduplicated.
+func tokenize(fields []string) []string {
+	var tokens []string
+	for _, f := range fields {
+		for _, tok := range strings.FieldsFunc(strings.ToLower(f), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if !slices.Contains(tokens, tok) {
+				tokens = append(tokens, tok)
+			}
+		}
+	}
+	return tokens
+}
+
+// parseQuery splits the query into AND-groups separated by the word OR,
+// with every term lowercased.
+func parseQuery(query string) [][]string {
+	var (
+		groups [][]string
+		group  []string
+	)
+	for _, term := range strings.Fields(query) {
+		if strings.EqualFold(term, "or") {
+			if len(group) > 0 {
+				groups = append(groups, group)
+				group = nil
+			}
+			continue
+		}
+		group = append(group, strings.ToLower(term))
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
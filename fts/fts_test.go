@@ -0,0 +1,119 @@
+package fts_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/fts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type note struct {
+	Title string
+	Body  string
+}
+
+func openIndex(t *testing.T) *fts.Index[string, note] {
+	t.Helper()
+
+	ix, err := fts.Open(
+		t.TempDir()+"/notes.db",
+		katalis.StringCodec,
+		katalis.Gob[note](),
+		func(n note) []string { return []string{n.Title, n.Body} },
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { ix.Close() })
+	return ix
+}
+
+func seedNotes(t *testing.T, ix *fts.Index[string, note]) {
+	t.Helper()
+	require.NoError(t, ix.Put("n1", note{Title: "Shopping list", Body: "eggs, milk and bread"}))
+	require.NoError(t, ix.Put("n2", note{Title: "Server notes", Body: "restart the gRPC server nightly"}))
+	require.NoError(t, ix.Put("n3", note{Title: "Recipes", Body: "bread needs eggs and patience"}))
+}
+
+func TestSearchSingleTerm(t *testing.T) {
+	ix := openIndex(t)
+	seedNotes(t, ix)
+
+	assert.Equal(t, []string{"n1", "n3"}, slices.Collect(ix.Search("eggs")))
+	assert.Equal(t, []string{"n2"}, slices.Collect(ix.Search("server")))
+	assert.Empty(t, slices.Collect(ix.Search("pizza")))
+
+	// Matching is case-insensitive on both sides.
+	assert.Equal(t, []string{"n2"}, slices.Collect(ix.Search("GRPC")))
+}
+
+func TestSearchAndOr(t *testing.T) {
+	ix := openIndex(t)
+	seedNotes(t, ix)
+
+	// Terms separated by spaces must all match.
+	assert.Equal(t, []string{"n1"}, slices.Collect(ix.Search("eggs milk")))
+
+	// OR separates alternatives.
+	assert.Equal(t, []string{"n1", "n2"}, slices.Collect(ix.Search("milk OR nightly")))
+	assert.Equal(t, []string{"n1", "n2", "n3"}, slices.Collect(ix.Search("bread OR server")))
+}
+
+func TestSearchPrefixTerm(t *testing.T) {
+	ix := openIndex(t)
+	seedNotes(t, ix)
+
+	// "re*" covers restart and recipes.
+	assert.Equal(t, []string{"n2", "n3"}, slices.Collect(ix.Search("re*")))
+	assert.Equal(t, []string{"n1"}, slices.Collect(ix.Search("shop*")))
+}
+
+func TestPutUpdatesPostings(t *testing.T) {
+	ix := openIndex(t)
+	seedNotes(t, ix)
+
+	// Rewriting a record moves it to the new tokens' posting lists.
+	require.NoError(t, ix.Put("n1", note{Title: "Chores", Body: "water the plants"}))
+
+	assert.Equal(t, []string{"n3"}, slices.Collect(ix.Search("eggs")))
+	assert.Equal(t, []string{"n1"}, slices.Collect(ix.Search("plants")))
+}
+
+func TestDelDropsPostings(t *testing.T) {
+	ix := openIndex(t)
+	seedNotes(t, ix)
+
+	require.NoError(t, ix.Del("n3"))
+
+	assert.Equal(t, []string{"n1"}, slices.Collect(ix.Search("eggs")))
+	has, err := ix.Has("n3")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestReindex(t *testing.T) {
+	ix := openIndex(t)
+	seedNotes(t, ix)
+
+	require.NoError(t, ix.Reindex())
+
+	assert.Equal(t, []string{"n1", "n3"}, slices.Collect(ix.Search("eggs")))
+	assert.Equal(t, []string{"n2"}, slices.Collect(ix.Search("nightly")))
+}
+
+func TestSearchManyRecords(t *testing.T) {
+	ix := openIndex(t)
+
+	for i := range 100 {
+		body := "common filler"
+		if i%10 == 0 {
+			body = "rare needle"
+		}
+		require.NoError(t, ix.Put(fmt.Sprintf("n%03d", i), note{Body: body}))
+	}
+
+	assert.Len(t, slices.Collect(ix.Search("needle")), 10)
+	assert.Len(t, slices.Collect(ix.Search("common")), 90)
+}
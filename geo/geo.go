@@ -0,0 +1,259 @@
+// Package geo implements a small geospatial index on top of katalis, for
+// keeping track of device locations on an edge gateway without a dedicated
+// spatial store. Entries are stored under geohash-prefixed keys, so Near
+// resolves a radius query with cheap byte-prefix matching over the cells
+// covering the circle before falling back to exact distances.
+package geo
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/NicoNex/katalis"
+)
+
+// maxPrecision is the geohash length stored in the index keys, about 37mm
+// of cell size: effectively exact for device positioning.
+const maxPrecision = 12
+
+// earthRadius is the mean Earth radius in meters, used by the haversine
+// distance.
+const earthRadius = 6371000.0
+
+// Internal raw-key prefixes: one record per entry under its geohash, plus an
+// id record pointing back at it for updates and deletes.
+var (
+	cellPrefix = []byte("g\x00")
+	idPrefix   = []byte("i\x00")
+)
+
+// Entry is one indexed location, as returned by Near.
+type Entry struct {
+	ID       string
+	Lat, Lon float64
+	Distance float64 // meters from the query point
+}
+
+// Store is a geospatial index backed by a katalis DB.
+type Store struct {
+	db katalis.DB[[]byte, []byte]
+}
+
+// Open opens or creates a geospatial index at the given path. The store must
+// be closed after use, by calling Close.
+func Open(path string) (*Store, error) {
+	db, err := katalis.Open(path, katalis.BytesCodec, katalis.BytesCodec)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying DB.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put records the position of id, replacing its previous one.
+func (s *Store) Put(id string, lat, lon float64) error {
+	hash := encodeGeohash(lat, lon, maxPrecision)
+
+	// Drop the old cell record when the entry moved.
+	if old, err := s.db.Get(idKey(id)); err != nil {
+		return err
+	} else if old != nil && string(old) != hash {
+		if err := s.db.Del(cellKey(string(old), id)); err != nil {
+			return err
+		}
+	}
+
+	pos := binary.BigEndian.AppendUint64(nil, math.Float64bits(lat))
+	pos = binary.BigEndian.AppendUint64(pos, math.Float64bits(lon))
+	if err := s.db.Put(cellKey(hash, id), pos); err != nil {
+		return err
+	}
+	return s.db.Put(idKey(id), []byte(hash))
+}
+
+// Del removes the entry. Missing ids are a no-op.
+func (s *Store) Del(id string) error {
+	hash, err := s.db.Get(idKey(id))
+	if err != nil || hash == nil {
+		return err
+	}
+	if err := s.db.Del(cellKey(string(hash), id)); err != nil {
+		return err
+	}
+	return s.db.Del(idKey(id))
+}
+
+// Position returns the recorded position of id, reporting false when the id
+// is not indexed.
+func (s *Store) Position(id string) (lat, lon float64, ok bool, err error) {
+	hash, err := s.db.Get(idKey(id))
+	if err != nil || hash == nil {
+		return 0, 0, false, err
+	}
+	pos, err := s.db.Get(cellKey(string(hash), id))
+	if err != nil || len(pos) != 16 {
+		return 0, 0, false, err
+	}
+	lat = math.Float64frombits(binary.BigEndian.Uint64(pos))
+	lon = math.Float64frombits(binary.BigEndian.Uint64(pos[8:]))
+	return lat, lon, true, nil
+}
+
+// Near returns the entries within radius meters of the given point, sorted
+// nearest first. The candidate set comes from a prefix scan over the geohash
+// cells covering the circle; the exact haversine distance then trims the
+// corners.
+func (s *Store) Near(lat, lon, radius float64) ([]Entry, error) {
+	cover := coverCells(lat, lon, radius)
+
+	var entries []Entry
+	for kb, vb := range s.db.RawItems() {
+		hash, id, ok := splitCellKey(kb)
+		if !ok || len(vb) != 16 {
+			continue
+		}
+		if !slices.ContainsFunc(cover, func(c string) bool { return strings.HasPrefix(hash, c) }) {
+			continue
+		}
+
+		elat := math.Float64frombits(binary.BigEndian.Uint64(vb))
+		elon := math.Float64frombits(binary.BigEndian.Uint64(vb[8:]))
+		if d := haversine(lat, lon, elat, elon); d <= radius {
+			entries = append(entries, Entry{ID: id, Lat: elat, Lon: elon, Distance: d})
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b Entry) int {
+		if c := cmp.Compare(a.Distance, b.Distance); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return entries, nil
+}
+
+// cellKey returns the raw key of the entry's cell record.
+func cellKey(hash, id string) []byte {
+	k := append(slices.Clone(cellPrefix), hash...)
+	k = append(k, 0)
+	return append(k, id...)
+}
+
+// idKey returns the raw key of the entry's id record.
+func idKey(id string) []byte {
+	return append(slices.Clone(idPrefix), id...)
+}
+
+// splitCellKey parses a raw key back into geohash and id, reporting whether
+// kb is a cell record.
+func splitCellKey(kb []byte) (hash, id string, ok bool) {
+	rest, found := strings.CutPrefix(string(kb), string(cellPrefix))
+	if !found {
+		return "", "", false
+	}
+	hash, id, found = strings.Cut(rest, "\x00")
+	return hash, id, found
+}
+
+// coverCells returns the geohash prefixes covering the circle: the cell of
+// the center at a precision whose cells are no smaller than the radius, plus
+// its eight neighbors to absorb boundary effects.
+func coverCells(lat, lon, radius float64) []string {
+	p := precisionFor(lat, radius)
+	dLat, dLon := cellSize(p)
+
+	var cover []string
+	for _, i := range []float64{-1, 0, 1} {
+		for _, j := range []float64{-1, 0, 1} {
+			nlat := min(max(lat+i*dLat, -90), 90)
+			nlon := math.Mod(lon+j*dLon+540, 360) - 180
+			if c := encodeGeohash(nlat, nlon, p); !slices.Contains(cover, c) {
+				cover = append(cover, c)
+			}
+		}
+	}
+	return cover
+}
+
+// precisionFor returns the longest geohash prefix whose cells still span at
+// least radius meters in both dimensions around the given latitude.
+func precisionFor(lat, radius float64) int {
+	const meterPerDeg = 111320.0
+
+	for p := maxPrecision; p > 1; p-- {
+		dLat, dLon := cellSize(p)
+		h := dLat * meterPerDeg
+		w := dLon * meterPerDeg * math.Cos(lat*math.Pi/180)
+		if h >= radius && w >= radius {
+			return p
+		}
+	}
+	return 1
+}
+
+// cellSize returns the latitude and longitude span, in degrees, of a geohash
+// cell at the given precision.
+func cellSize(precision int) (dLat, dLon float64) {
+	lonBits := (5*precision + 1) / 2
+	latBits := 5 * precision / 2
+	return 180 / math.Exp2(float64(latBits)), 360 / math.Exp2(float64(lonBits))
+}
+
+// haversine returns the great-circle distance in meters between two points.
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	const deg = math.Pi / 180
+
+	dLat := (lat2 - lat1) * deg
+	dLon := (lon2 - lon1) * deg
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*deg)*math.Cos(lat2*deg)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// geohashBase32 is the standard geohash alphabet.
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// encodeGeohash returns the geohash of the point at the given precision.
+func encodeGeohash(lat, lon float64, precision int) string {
+	var (
+		latMin, latMax = -90.0, 90.0
+		lonMin, lonMax = -180.0, 180.0
+		even           = true
+		bit, ch        int
+		hash           = make([]byte, 0, precision)
+	)
+
+	for len(hash) < precision {
+		if even {
+			if mid := (lonMin + lonMax) / 2; lon >= mid {
+				ch = ch<<1 | 1
+				lonMin = mid
+			} else {
+				ch <<= 1
+				lonMax = mid
+			}
+		} else {
+			if mid := (latMin + latMax) / 2; lat >= mid {
+				ch = ch<<1 | 1
+				latMin = mid
+			} else {
+				ch <<= 1
+				latMax = mid
+			}
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			hash = append(hash, geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return string(hash)
+}
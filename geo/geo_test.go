@@ -0,0 +1,109 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis/geo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openStore(t *testing.T) *geo.Store {
+	t.Helper()
+
+	s, err := geo.Open(t.TempDir() + "/geo.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// Rome city center and a few places around it.
+const (
+	romeLat, romeLon = 41.9028, 12.4964
+)
+
+func TestPutAndPosition(t *testing.T) {
+	s := openStore(t)
+
+	require.NoError(t, s.Put("dev1", romeLat, romeLon))
+
+	lat, lon, ok, err := s.Position("dev1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.InDelta(t, romeLat, lat, 1e-9)
+	assert.InDelta(t, romeLon, lon, 1e-9)
+
+	_, _, ok, err = s.Position("absent")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNear(t *testing.T) {
+	s := openStore(t)
+
+	require.NoError(t, s.Put("colosseum", 41.8902, 12.4922)) // ~1.4km from center
+	require.NoError(t, s.Put("vatican", 41.9022, 12.4539))   // ~3.5km
+	require.NoError(t, s.Put("fiumicino", 41.8003, 12.2389)) // ~24km
+	require.NoError(t, s.Put("milan", 45.4642, 9.19))        // ~480km
+	require.NoError(t, s.Put("center", romeLat, romeLon))    // 0m
+
+	entries, err := s.Near(romeLat, romeLon, 5000)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	// Nearest first.
+	assert.Equal(t, "center", entries[0].ID)
+	assert.Equal(t, "colosseum", entries[1].ID)
+	assert.Equal(t, "vatican", entries[2].ID)
+	assert.Less(t, entries[1].Distance, entries[2].Distance)
+
+	// A wider radius picks up the airport but still not Milan.
+	entries, err = s.Near(romeLat, romeLon, 50000)
+	require.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
+
+func TestNearAcrossCellBoundary(t *testing.T) {
+	s := openStore(t)
+
+	// Two points ~200m apart straddling the greenwich meridian, a geohash
+	// cell boundary at every precision.
+	require.NoError(t, s.Put("west", 51.4779, -0.0015))
+	require.NoError(t, s.Put("east", 51.4779, 0.0015))
+
+	entries, err := s.Near(51.4779, -0.0015, 300)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestPutMovesEntry(t *testing.T) {
+	s := openStore(t)
+
+	require.NoError(t, s.Put("dev1", romeLat, romeLon))
+	require.NoError(t, s.Put("dev1", 45.4642, 9.19)) // moved to Milan
+
+	entries, err := s.Near(romeLat, romeLon, 5000)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = s.Near(45.4642, 9.19, 5000)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "dev1", entries[0].ID)
+}
+
+func TestDel(t *testing.T) {
+	s := openStore(t)
+
+	require.NoError(t, s.Put("dev1", romeLat, romeLon))
+	require.NoError(t, s.Del("dev1"))
+	require.NoError(t, s.Del("absent")) // no-op
+
+	entries, err := s.Near(romeLat, romeLon, 5000)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, _, ok, err := s.Position("dev1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
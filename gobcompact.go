@@ -0,0 +1,139 @@
+package katalis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"slices"
+	"sync"
+)
+
+// GobCompact returns a GobCompactCodec for type T. The optional variadic
+// parameter allows type inference from a value.
+func GobCompact[T any](_ ...T) GobCompactCodec[T] {
+	return GobCompactCodec[T]{state: new(gobCompactState)}
+}
+
+// GobCompactCodec is a gob codec that transmits the type description only
+// once instead of prefixing it to every stored value, so blobs are smaller
+// and faster to encode than with GobCodec. The type description is primed
+// from the zero value of T, which means T must be a concrete type whose
+// structure is fully known up front; values carrying interfaces that register
+// new concrete types per record are not supported.
+//
+// To move existing data written with Gob over to this codec, see
+// MigrateValues.
+type GobCompactCodec[T any] struct {
+	state *gobCompactState
+}
+
+type gobCompactState struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	enc     *gob.Encoder
+	primer  []byte
+	initErr error
+}
+
+// init primes the shared encoder with the type description of T, recording
+// the emitted bytes so Decode can replay them. Call with s.mu held.
+func (c GobCompactCodec[T]) init() error {
+	s := c.state
+	if s.enc != nil || s.initErr != nil {
+		return s.initErr
+	}
+
+	s.enc = gob.NewEncoder(&s.buf)
+	var zero T
+	if err := s.enc.Encode(&zero); err != nil {
+		s.initErr = err
+		return err
+	}
+	s.primer = slices.Clone(s.buf.Bytes())
+	s.buf.Reset()
+	return nil
+}
+
+// Encode serializes the value without the per-value type preamble.
+func (c GobCompactCodec[T]) Encode(t T) ([]byte, error) {
+	s := c.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+
+	s.buf.Reset()
+	if err := s.enc.Encode(&t); err != nil {
+		return nil, err
+	}
+	return slices.Clone(s.buf.Bytes()), nil
+}
+
+// Decode deserializes a value written by Encode, replaying the recorded type
+// description first.
+func (c GobCompactCodec[T]) Decode(b []byte) (t T, err error) {
+	s := c.state
+	s.mu.Lock()
+	if err = c.init(); err != nil {
+		s.mu.Unlock()
+		return t, err
+	}
+	primer := s.primer
+	s.mu.Unlock()
+
+	dec := gob.NewDecoder(io.MultiReader(bytes.NewReader(primer), bytes.NewReader(b)))
+
+	// Consume the primer's zero value to bring the decoder up to the state
+	// the shared encoder was in when b was written.
+	var zero T
+	if err = dec.Decode(&zero); err != nil {
+		return t, err
+	}
+	return t, dec.Decode(&t)
+}
+
+// MigrateValues re-encodes every value in the store from one codec to
+// another, e.g. from Gob to GobCompact. It loads all records in memory to
+// avoid mutating the store mid-iteration, so run it while the DB is otherwise
+// idle.
+func MigrateValues[KT, VT any](db DB[KT, VT], from, to Codec[VT]) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	type record struct {
+		key []byte
+		val VT
+	}
+	var records []record
+
+	it := db.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		val, err := from.Decode(vb)
+		if err != nil {
+			return err
+		}
+		records = append(records, record{key: slices.Clone(kb), val: val})
+	}
+
+	for _, rec := range records {
+		vb, err := to.Encode(rec.val)
+		if err != nil {
+			return err
+		}
+		if err := db.rawPut(rec.key, vb); err != nil {
+			return err
+		}
+	}
+	return db.Sync()
+}
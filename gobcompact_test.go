@@ -0,0 +1,82 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type gobCompactSample struct {
+	Name  string
+	Count int
+	Tags  []string
+}
+
+func TestGobCompactRoundTrip(t *testing.T) {
+	codec := katalis.GobCompact[gobCompactSample]()
+
+	samples := []gobCompactSample{
+		{Name: "first", Count: 1, Tags: []string{"a", "b"}},
+		{Name: "second", Count: 2},
+		{},
+	}
+	for _, want := range samples {
+		b, err := codec.Encode(want)
+		require.NoError(t, err)
+
+		got, err := codec.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestGobCompactSmallerThanGob(t *testing.T) {
+	sample := gobCompactSample{Name: "x", Count: 1}
+
+	plain, err := katalis.Gob[gobCompactSample]().Encode(sample)
+	require.NoError(t, err)
+
+	compact, err := katalis.GobCompact[gobCompactSample]().Encode(sample)
+	require.NoError(t, err)
+
+	assert.Less(t, len(compact), len(plain))
+}
+
+func TestGobCompactDecodesAcrossInstances(t *testing.T) {
+	// A value encoded by one codec instance must decode with a fresh one,
+	// e.g. after a process restart.
+	b, err := katalis.GobCompact[gobCompactSample]().Encode(gobCompactSample{Name: "persisted"})
+	require.NoError(t, err)
+
+	got, err := katalis.GobCompact[gobCompactSample]().Decode(b)
+	require.NoError(t, err)
+	assert.Equal(t, "persisted", got.Name)
+}
+
+func TestMigrateValues(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.Gob[gobCompactSample]())
+	require.NoError(t, err)
+
+	want := map[string]gobCompactSample{
+		"a": {Name: "a", Count: 1},
+		"b": {Name: "b", Count: 2},
+	}
+	for k, v := range want {
+		require.NoError(t, db.Put(k, v))
+	}
+
+	require.NoError(t, katalis.MigrateValues(db, katalis.Gob[gobCompactSample](), katalis.GobCompact[gobCompactSample]()))
+	require.NoError(t, db.Close())
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.GobCompact[gobCompactSample]())
+	require.NoError(t, err)
+	defer db.Close()
+
+	got, err := katalis.Collect(db)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
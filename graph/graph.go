@@ -0,0 +1,141 @@
+// Package graph stores a directed graph on top of katalis, for modelling
+// relationships over a plain KV store without hand-rolling the key encoding.
+// Each edge lands under two composite keys, one per direction, so both the
+// out- and in-neighborhood of a node resolve with a single prefix match and
+// RemoveNode never scans the whole edge set twice.
+package graph
+
+import (
+	"bytes"
+	"encoding/binary"
+	"iter"
+	"slices"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Raw-key prefixes of the two edge directions: out records list the edges
+// leaving a node, in records mirror them from the target's side.
+var (
+	outPrefix = []byte("o\x00")
+	inPrefix  = []byte("i\x00")
+)
+
+// Graph is a directed graph backed by a katalis DB. Nodes are strings and
+// exist implicitly, as endpoints of edges.
+type Graph struct {
+	db katalis.DB[[]byte, []byte]
+}
+
+// Open opens or creates a graph at the given path. The graph must be closed
+// after use, by calling Close.
+func Open(path string) (*Graph, error) {
+	db, err := katalis.Open(path, katalis.BytesCodec, katalis.BytesCodec)
+	if err != nil {
+		return nil, err
+	}
+	return &Graph{db: db}, nil
+}
+
+// Close closes the underlying DB.
+func (g *Graph) Close() error {
+	return g.db.Close()
+}
+
+// AddEdge adds the directed edge a -> b. Adding an existing edge is a no-op.
+func (g *Graph) AddEdge(a, b string) error {
+	if err := g.db.Put(edgeKey(outPrefix, a, b), nil); err != nil {
+		return err
+	}
+	return g.db.Put(edgeKey(inPrefix, b, a), nil)
+}
+
+// RemoveEdge removes the directed edge a -> b. Missing edges are a no-op.
+func (g *Graph) RemoveEdge(a, b string) error {
+	if err := g.db.Del(edgeKey(outPrefix, a, b)); err != nil {
+		return err
+	}
+	return g.db.Del(edgeKey(inPrefix, b, a))
+}
+
+// HasEdge returns true if the graph contains the directed edge a -> b.
+func (g *Graph) HasEdge(a, b string) (bool, error) {
+	return g.db.Has(edgeKey(outPrefix, a, b))
+}
+
+// Neighbors returns an iterator over the nodes a has an edge to, sorted.
+func (g *Graph) Neighbors(a string) iter.Seq[string] {
+	return g.endpoints(outPrefix, a)
+}
+
+// Inbound returns an iterator over the nodes with an edge to a, sorted.
+func (g *Graph) Inbound(a string) iter.Seq[string] {
+	return g.endpoints(inPrefix, a)
+}
+
+// RemoveNode removes a together with all its edges, in both directions.
+func (g *Graph) RemoveNode(a string) error {
+	for b := range g.Neighbors(a) {
+		if err := g.RemoveEdge(a, b); err != nil {
+			return err
+		}
+	}
+	for b := range g.Inbound(a) {
+		if err := g.RemoveEdge(b, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endpoints iterates the far endpoints of a node's edge records in the given
+// direction.
+func (g *Graph) endpoints(prefix []byte, a string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		p := nodePrefix(prefix, a)
+
+		var nodes []string
+		for kb := range g.db.RawItems() {
+			if b, ok := cutEdgeKey(kb, p); ok {
+				nodes = append(nodes, b)
+			}
+		}
+
+		slices.Sort(nodes)
+		for _, b := range nodes {
+			if !yield(b) {
+				return
+			}
+		}
+	}
+}
+
+// nodePrefix returns the raw-key prefix shared by a node's edge records in
+// one direction. The node length goes in front of its name so one node can
+// never prefix another.
+func nodePrefix(prefix []byte, a string) []byte {
+	p := binary.AppendUvarint(slices.Clone(prefix), uint64(len(a)))
+	return append(p, a...)
+}
+
+// edgeKey returns the raw key of the edge record from a to b in one
+// direction.
+func edgeKey(prefix []byte, a, b string) []byte {
+	k := binary.AppendUvarint(nodePrefix(prefix, a), uint64(len(b)))
+	return append(k, b...)
+}
+
+// cutEdgeKey parses the far endpoint out of a raw edge key, reporting
+// whether kb belongs to the node identified by p.
+func cutEdgeKey(kb, p []byte) (string, bool) {
+	if !bytes.HasPrefix(kb, p) {
+		return "", false
+	}
+	rest := kb[len(p):]
+
+	n, read := binary.Uvarint(rest)
+	if read <= 0 || uint64(len(rest)-read) != n {
+		return "", false
+	}
+	return string(rest[read:]), true
+}
@@ -0,0 +1,93 @@
+package graph_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/NicoNex/katalis/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openGraph(t *testing.T) *graph.Graph {
+	t.Helper()
+
+	g, err := graph.Open(t.TempDir() + "/graph.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { g.Close() })
+	return g
+}
+
+func TestAddEdgeAndNeighbors(t *testing.T) {
+	g := openGraph(t)
+
+	require.NoError(t, g.AddEdge("a", "b"))
+	require.NoError(t, g.AddEdge("a", "c"))
+	require.NoError(t, g.AddEdge("b", "c"))
+	require.NoError(t, g.AddEdge("a", "b")) // duplicate, no-op
+
+	assert.Equal(t, []string{"b", "c"}, slices.Collect(g.Neighbors("a")))
+	assert.Equal(t, []string{"c"}, slices.Collect(g.Neighbors("b")))
+	assert.Empty(t, slices.Collect(g.Neighbors("c")))
+
+	// Edges are directed.
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(g.Inbound("c")))
+	assert.Empty(t, slices.Collect(g.Inbound("a")))
+}
+
+func TestHasEdge(t *testing.T) {
+	g := openGraph(t)
+
+	require.NoError(t, g.AddEdge("a", "b"))
+
+	has, err := g.HasEdge("a", "b")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	has, err = g.HasEdge("b", "a")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestRemoveEdge(t *testing.T) {
+	g := openGraph(t)
+
+	require.NoError(t, g.AddEdge("a", "b"))
+	require.NoError(t, g.AddEdge("a", "c"))
+
+	require.NoError(t, g.RemoveEdge("a", "b"))
+	require.NoError(t, g.RemoveEdge("x", "y")) // missing, no-op
+
+	assert.Equal(t, []string{"c"}, slices.Collect(g.Neighbors("a")))
+	assert.Empty(t, slices.Collect(g.Inbound("b")))
+}
+
+func TestRemoveNode(t *testing.T) {
+	g := openGraph(t)
+
+	require.NoError(t, g.AddEdge("hub", "a"))
+	require.NoError(t, g.AddEdge("hub", "b"))
+	require.NoError(t, g.AddEdge("c", "hub"))
+	require.NoError(t, g.AddEdge("a", "b"))
+
+	require.NoError(t, g.RemoveNode("hub"))
+
+	assert.Empty(t, slices.Collect(g.Neighbors("hub")))
+	assert.Empty(t, slices.Collect(g.Inbound("hub")))
+	assert.Empty(t, slices.Collect(g.Neighbors("c")))
+	assert.Empty(t, slices.Collect(g.Inbound("a")))
+
+	// Unrelated edges survive.
+	assert.Equal(t, []string{"b"}, slices.Collect(g.Neighbors("a")))
+}
+
+func TestNodeNamesNeverOverlap(t *testing.T) {
+	g := openGraph(t)
+
+	// "a" and "ab" share a textual prefix but not a key prefix.
+	require.NoError(t, g.AddEdge("a", "x"))
+	require.NoError(t, g.AddEdge("ab", "y"))
+
+	assert.Equal(t, []string{"x"}, slices.Collect(g.Neighbors("a")))
+	assert.Equal(t, []string{"y"}, slices.Collect(g.Neighbors("ab")))
+}
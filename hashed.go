@@ -0,0 +1,119 @@
+package katalis
+
+import (
+	"crypto/sha256"
+	"iter"
+)
+
+// HashedDB wraps a DB storing a digest of each encoded key instead of the
+// key itself, which bounds the on-disk key size and keeps sensitive keys,
+// such as URLs, out of the store. Lookups hash the key on the way in, so
+// Get, Put, Del and Has behave as usual; the keys themselves are gone,
+// though, so iteration can only return digests, and records must be written
+// through the wrapper to be found again.
+type HashedDB[KT, VT any] struct {
+	DB[KT, VT]
+
+	hash func([]byte) []byte
+}
+
+// HashedKeys wraps the DB so that only digests of the encoded keys reach the
+// store. A nil hash defaults to SHA-256.
+func HashedKeys[KT, VT any](db DB[KT, VT], hash func([]byte) []byte) HashedDB[KT, VT] {
+	if hash == nil {
+		hash = func(b []byte) []byte {
+			sum := sha256.Sum256(b)
+			return sum[:]
+		}
+	}
+	return HashedDB[KT, VT]{DB: db, hash: hash}
+}
+
+// digest encodes the key and hashes the encoding.
+func (h HashedDB[KT, VT]) digest(key KT) ([]byte, error) {
+	kb, err := h.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	return h.hash(kb), nil
+}
+
+// Get returns the value stored under the key's digest, or an empty value if
+// the key doesn't exist.
+func (h HashedDB[KT, VT]) Get(key KT) (res VT, err error) {
+	if err := h.closedErr(); err != nil {
+		return res, err
+	}
+
+	kb, err := h.digest(key)
+	if err != nil {
+		return res, err
+	}
+
+	b, err := h.DB.DB.Get(kb)
+	if err != nil {
+		return res, err
+	}
+	return h.valCodec.Decode(b)
+}
+
+// Put stores the value under the key's digest.
+func (h HashedDB[KT, VT]) Put(key KT, val VT) error {
+	if err := h.closedErr(); err != nil {
+		return err
+	}
+
+	kb, err := h.digest(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := h.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+	return h.DB.rawPut(kb, vb)
+}
+
+// Del deletes the record stored under the key's digest.
+func (h HashedDB[KT, VT]) Del(key KT) error {
+	if err := h.closedErr(); err != nil {
+		return err
+	}
+
+	kb, err := h.digest(key)
+	if err != nil {
+		return err
+	}
+	return h.DB.rawDel(kb)
+}
+
+// Has returns true if a record exists under the key's digest.
+func (h HashedDB[KT, VT]) Has(key KT) (bool, error) {
+	if err := h.closedErr(); err != nil {
+		return false, err
+	}
+
+	kb, err := h.digest(key)
+	if err != nil {
+		return false, err
+	}
+	return h.DB.DB.Has(kb)
+}
+
+// Items returns an iterator over the records as digest-value pairs: the
+// original keys are not stored, so the digest is all there is to yield.
+// Values that fail to decode are silently skipped.
+func (h HashedDB[KT, VT]) Items() iter.Seq2[[]byte, VT] {
+	return func(yield func([]byte, VT) bool) {
+		for kb, vb := range h.DB.RawItems() {
+			val, err := h.valCodec.Decode(vb)
+			if err != nil {
+				continue
+			}
+			if !yield(kb, val) {
+				return
+			}
+		}
+	}
+}
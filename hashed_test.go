@@ -0,0 +1,88 @@
+package katalis_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashedKeysRoundTrip(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	h := katalis.HashedKeys(db, nil)
+	url := "https://example.com/some/very/long/and/sensitive/path?token=secret"
+	require.NoError(t, h.Put(url, 42))
+
+	v, err := h.Get(url)
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	has, err := h.Has(url)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	require.NoError(t, h.Del(url))
+	has, err = h.Has(url)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestHashedKeysStoresDigest(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	h := katalis.HashedKeys(db, nil)
+	require.NoError(t, h.Put("secret-key", 1))
+
+	want := sha256.Sum256([]byte("secret-key"))
+	count := 0
+	for kb, v := range h.Items() {
+		count++
+		assert.Equal(t, want[:], kb)
+		assert.Equal(t, 1, v)
+	}
+	assert.Equal(t, 1, count)
+
+	// The plain key never reaches the store.
+	has, err := db.Has("secret-key")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestHashedKeysCustomHash(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// A truncating hash bounds the key size.
+	h := katalis.HashedKeys(db, func(b []byte) []byte {
+		sum := sha256.Sum256(b)
+		return sum[:8]
+	})
+	require.NoError(t, h.Put("key", 7))
+
+	for kb := range h.Items() {
+		assert.Len(t, kb, 8)
+	}
+
+	v, err := h.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+}
+
+func TestHashedKeysClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	h := katalis.HashedKeys(db, nil)
+	assert.ErrorIs(t, h.Put("key", 1), katalis.ErrClosed)
+	_, err = h.Get("key")
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+}
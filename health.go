@@ -0,0 +1,134 @@
+package katalis
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrDegraded is returned by writes on a store that switched to degraded
+// read-only mode after running out of disk space or hitting persistent IO
+// errors. Reads keep working; see Health and Heal.
+var ErrDegraded = errors.New("katalis: store is degraded, writes disabled")
+
+// ioFailureThreshold is how many consecutive write failures degrade the
+// store when none of them is ENOSPC.
+const ioFailureThreshold = 3
+
+// HealthState is the coarse condition of a store.
+type HealthState int
+
+const (
+	// Healthy means writes are accepted.
+	Healthy HealthState = iota
+	// DegradedReadOnly means the store disabled writes after ENOSPC or
+	// repeated IO errors; reads still work.
+	DegradedReadOnly
+)
+
+// String returns the state name.
+func (s HealthState) String() string {
+	if s == DegradedReadOnly {
+		return "degraded"
+	}
+	return "healthy"
+}
+
+// Health is the state surfaced by DB.Health.
+type Health struct {
+	State HealthState
+	// Reason is the error that degraded the store, nil when healthy.
+	Reason error
+	// Since is when the store degraded, zero when healthy.
+	Since time.Time
+}
+
+// healthState tracks write failures and the degraded flag of one store.
+type healthState struct {
+	mu          sync.Mutex
+	degraded    bool
+	reason      error
+	since       time.Time
+	consecutive int
+}
+
+// Health returns the store's current health. A degraded store keeps serving
+// reads but fails every write with ErrDegraded until Heal is called or the
+// store is reopened.
+func (db DB[KT, VT]) Health() Health {
+	if db.health == nil {
+		return Health{State: Healthy}
+	}
+
+	db.health.mu.Lock()
+	defer db.health.mu.Unlock()
+	if !db.health.degraded {
+		return Health{State: Healthy}
+	}
+	return Health{State: DegradedReadOnly, Reason: db.health.reason, Since: db.health.since}
+}
+
+// Heal clears the degraded state, re-enabling writes after the operator
+// resolved the underlying condition, typically by freeing disk space.
+func (db DB[KT, VT]) Heal() error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	db.health.mu.Lock()
+	defer db.health.mu.Unlock()
+	db.health.degraded = false
+	db.health.reason = nil
+	db.health.since = time.Time{}
+	db.health.consecutive = 0
+	return nil
+}
+
+// degradedErr returns ErrDegraded when the store is in degraded mode.
+func (db DB[KT, VT]) degradedErr() error {
+	if db.health == nil {
+		return nil
+	}
+	db.health.mu.Lock()
+	defer db.health.mu.Unlock()
+	if db.health.degraded {
+		return ErrDegraded
+	}
+	return nil
+}
+
+// noteWriteError records a failed engine write, degrading the store
+// immediately on ENOSPC and after ioFailureThreshold consecutive failures
+// otherwise. Degrading emits EventDegraded.
+func (db DB[KT, VT]) noteWriteError(err error) {
+	h := db.health
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	h.consecutive++
+	trip := !h.degraded &&
+		(errors.Is(err, syscall.ENOSPC) || h.consecutive >= ioFailureThreshold)
+	if trip {
+		h.degraded = true
+		h.reason = err
+		h.since = time.Now()
+	}
+	h.mu.Unlock()
+
+	if trip {
+		db.emit(Event{Kind: EventDegraded, Err: err})
+	}
+}
+
+// noteWriteSuccess resets the consecutive-failure counter.
+func (db DB[KT, VT]) noteWriteSuccess() {
+	if db.health == nil {
+		return
+	}
+	db.health.mu.Lock()
+	db.health.consecutive = 0
+	db.health.mu.Unlock()
+}
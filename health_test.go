@@ -0,0 +1,132 @@
+package katalis_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hugeKey exceeds the engine's key size limit (64 KiB), making every write of
+// it fail inside the engine — a stand-in for a stalling or full disk.
+var hugeKey = strings.Repeat("k", 1<<16+1)
+
+func TestHealthDegradesAfterPersistentIOErrors(t *testing.T) {
+	var events []katalis.Event
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithEventHook(func(e katalis.Event) { events = append(events, e) }))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 1))
+	assert.Equal(t, katalis.Healthy, db.Health().State)
+
+	for range 3 {
+		assert.Error(t, db.Put(hugeKey, 1))
+	}
+
+	health := db.Health()
+	assert.Equal(t, katalis.DegradedReadOnly, health.State)
+	assert.Error(t, health.Reason)
+	assert.False(t, health.Since.IsZero())
+
+	// Writes fail fast with ErrDegraded, reads keep working.
+	assert.ErrorIs(t, db.Put("other", 2), katalis.ErrDegraded)
+	assert.ErrorIs(t, db.Del("key"), katalis.ErrDegraded)
+	v, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	// The switch emitted a hook event.
+	degraded := 0
+	for _, e := range events {
+		if e.Kind == katalis.EventDegraded {
+			degraded++
+			assert.Error(t, e.Err)
+		}
+	}
+	assert.Equal(t, 1, degraded)
+}
+
+func TestHealSucceedsAfterRecovery(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for range 3 {
+		assert.Error(t, db.Put(hugeKey, 1))
+	}
+	require.Equal(t, katalis.DegradedReadOnly, db.Health().State)
+
+	require.NoError(t, db.Heal())
+	assert.Equal(t, katalis.Healthy, db.Health().State)
+	require.NoError(t, db.Put("key", 2))
+}
+
+func TestHealthSuccessResetsFailureStreak(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.Error(t, db.Put(hugeKey, 1))
+	assert.Error(t, db.Put(hugeKey, 1))
+	require.NoError(t, db.Put("ok", 1))
+	assert.Error(t, db.Put(hugeKey, 1))
+	assert.Error(t, db.Put(hugeKey, 1))
+
+	assert.Equal(t, katalis.Healthy, db.Health().State)
+}
+
+func TestHealthStateString(t *testing.T) {
+	assert.Equal(t, "healthy", katalis.Healthy.String())
+	assert.Equal(t, "degraded", katalis.DegradedReadOnly.String())
+}
+
+func TestDegradedBlocksAllWritePaths(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Put("key", 1))
+
+	for range 3 {
+		require.Error(t, db.Put(hugeKey, 1))
+	}
+	require.Equal(t, katalis.DegradedReadOnly, db.Health().State)
+
+	// Byte-level paths must honor the read-only switch like Put and Del do.
+	err = db.Tx(func(tx *katalis.Tx[string, int]) error { return tx.Put("staged", 1) })
+	assert.ErrorIs(t, err, katalis.ErrDegraded)
+
+	assert.ErrorIs(t, db.PutLarge("blob", strings.NewReader("data")), katalis.ErrDegraded)
+
+	w, err := db.NewWriter("stream")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("data"))
+	require.NoError(t, err)
+	assert.ErrorIs(t, w.Close(), katalis.ErrDegraded)
+
+	assert.ErrorIs(t, db.RawPut([]byte("raw"), []byte("x")), katalis.ErrDegraded)
+
+	// None of the blocked writes leaked into the store, and reads still work.
+	v, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	has, err := db.Has("staged")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestPutLargeFailuresDegrade(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The derived chunk keys of hugeKey exceed the engine limit too, so the
+	// failure streak is tracked even when Put is never involved.
+	for range 3 {
+		require.Error(t, db.PutLarge(hugeKey, strings.NewReader("x")))
+	}
+	assert.Equal(t, katalis.DegradedReadOnly, db.Health().State)
+}
@@ -0,0 +1,81 @@
+package katalis
+
+import "github.com/akrylysov/pogreb"
+
+// Iterator is a classic cursor-style iterator over the store, for callers
+// that cannot use the range-func iterators or need to interleave iteration
+// with select loops:
+//
+//	it := db.Iterator()
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Key(), it.Value())
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle it
+//	}
+type Iterator[KT, VT any] struct {
+	db    DB[KT, VT]
+	inner *pogreb.ItemIterator
+	key   KT
+	val   VT
+	err   error
+}
+
+// Iterator returns a cursor-style iterator over all key-value pairs.
+func (db DB[KT, VT]) Iterator() *Iterator[KT, VT] {
+	it := &Iterator[KT, VT]{db: db}
+	if it.err = db.closedErr(); it.err == nil {
+		it.inner = db.DB.Items()
+	}
+	return it
+}
+
+// Next advances the iterator to the next record, reporting false when the
+// iteration is over or an error occurred. After Next returns false, check Err.
+func (it *Iterator[KT, VT]) Next() bool {
+	if it.err != nil || it.inner == nil {
+		return false
+	}
+
+	kb, vb, err := it.inner.Next()
+	if isTerminate(err) {
+		it.inner = nil
+		return false
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !it.db.inView(kb) {
+		return it.Next()
+	}
+
+	if it.key, err = it.db.keyCodec.Decode(kb); err != nil {
+		it.err = err
+		return false
+	}
+	if it.val, err = it.db.valCodec.Decode(vb); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+// Key returns the key of the current record. It is only valid after a Next
+// call that returned true.
+func (it *Iterator[KT, VT]) Key() KT { return it.key }
+
+// Value returns the value of the current record. It is only valid after a
+// Next call that returned true.
+func (it *Iterator[KT, VT]) Value() VT { return it.val }
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[KT, VT]) Err() error { return it.err }
+
+// Close releases the iterator. It always returns nil and is safe to call at
+// any point; it exists so the iterator can be managed like other cursors.
+func (it *Iterator[KT, VT]) Close() error {
+	it.inner = nil
+	return nil
+}
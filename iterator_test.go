@@ -0,0 +1,56 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	want := map[string]int{"a": 1, "b": 2, "c": 3}
+	for k, v := range want {
+		require.NoError(t, db.Put(k, v))
+	}
+
+	it := db.Iterator()
+	defer it.Close()
+
+	got := make(map[string]int)
+	for it.Next() {
+		got[it.Key()] = it.Value()
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, want, got)
+}
+
+func TestIteratorDecodeError(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Plant a record whose value cannot decode as int.
+	require.NoError(t, db.DB.Put([]byte("bad"), []byte{1}))
+
+	it := db.Iterator()
+	defer it.Close()
+
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+}
+
+func TestIteratorClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	it := db.Iterator()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), katalis.ErrClosed)
+	assert.NoError(t, it.Close())
+}
@@ -0,0 +1,275 @@
+package katalis
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"sync"
+	"time"
+)
+
+// Op is the kind of operation recorded in a change journal.
+type Op byte
+
+const (
+	OpPut Op = 1
+	OpDel Op = 2
+)
+
+// Change is one entry of the change journal: an operation applied to the DB
+// together with its monotonically increasing sequence number. Key and Value
+// hold the encoded bytes, so consumers can feed them to downstream systems
+// without needing the codecs.
+type Change struct {
+	Seq   uint64
+	At    time.Time
+	Op    Op
+	Key   []byte
+	Value []byte
+}
+
+// JournaledDB wraps a DB with an opt-in append-only change journal. Every
+// write performed through the wrapper is recorded with a sequence number
+// before being applied, so downstream systems can tail the change stream
+// with Changes and rebuild projections without rescanning the whole store.
+//
+// Writes performed on the underlying DB directly bypass the journal.
+type JournaledDB[KT, VT any] struct {
+	DB[KT, VT]
+
+	mu  sync.Mutex
+	f   *os.File
+	seq uint64
+}
+
+// Journaled wraps the DB with a change journal kept in an append-only file
+// next to the store directory. Reopening the DB resumes the journal where it
+// left off.
+func Journaled[KT, VT any](db DB[KT, VT]) (*JournaledDB[KT, VT], error) {
+	path := journalPath(db.path)
+
+	seq := uint64(0)
+	for change, err := range readJournal(path, 0) {
+		if err != nil {
+			return nil, err
+		}
+		seq = change.Seq
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &JournaledDB[KT, VT]{DB: db, f: f, seq: seq}, nil
+}
+
+// Put records the write in the journal and applies it to the DB.
+func (j *JournaledDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := j.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := j.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.append(OpPut, kb, vb); err != nil {
+		return err
+	}
+	return j.DB.rawPut(kb, vb)
+}
+
+// Del records the deletion in the journal and applies it to the DB.
+func (j *JournaledDB[KT, VT]) Del(key KT) error {
+	kb, err := j.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.append(OpDel, kb, nil); err != nil {
+		return err
+	}
+	return j.DB.rawDel(kb)
+}
+
+// Seq returns the sequence number of the last journaled change.
+func (j *JournaledDB[KT, VT]) Seq() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.seq
+}
+
+// Changes returns an iterator over the journaled changes with sequence
+// numbers greater than sinceSeq, in application order. Pass 0 to replay the
+// whole journal.
+func (j *JournaledDB[KT, VT]) Changes(sinceSeq uint64) iter.Seq2[Change, error] {
+	return readJournal(journalPath(j.path), sinceSeq)
+}
+
+// Truncate drops journal entries with sequence numbers up to and including
+// upToSeq, bounding the journal's disk usage once downstream consumers have
+// caught up.
+func (j *JournaledDB[KT, VT]) Truncate(upToSeq uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	path := journalPath(j.path)
+
+	var kept []byte
+	for change, err := range readJournal(path, upToSeq) {
+		if err != nil {
+			return err
+		}
+		kept = appendChange(kept, change)
+	}
+
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, kept); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	// Reopen the append handle on the rewritten file.
+	if err := j.f.Close(); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	j.f = f
+	return nil
+}
+
+// Close closes the journal and the underlying DB.
+func (j *JournaledDB[KT, VT]) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.f.Close(); err != nil {
+		j.DB.Close()
+		return err
+	}
+	return j.DB.Close()
+}
+
+// append writes one change record. The caller must hold j.mu.
+func (j *JournaledDB[KT, VT]) append(op Op, kb, vb []byte) error {
+	j.seq++
+	b := appendChange(nil, Change{Seq: j.seq, At: time.Now(), Op: op, Key: kb, Value: vb})
+
+	if _, err := j.f.Write(b); err != nil {
+		j.seq--
+		return err
+	}
+	return nil
+}
+
+// journalPath returns the location of the change journal, kept next to the
+// store directory like the transaction WAL.
+func journalPath(path string) string {
+	return path + ".journal"
+}
+
+// appendChange serializes one journal record.
+func appendChange(b []byte, c Change) []byte {
+	b = binary.BigEndian.AppendUint64(b, c.Seq)
+	b = binary.BigEndian.AppendUint64(b, uint64(c.At.UnixNano()))
+	b = append(b, byte(c.Op))
+	b = appendFrame(b, c.Key)
+	return appendFrame(b, c.Value)
+}
+
+// readJournal iterates the journal records with sequence numbers greater
+// than sinceSeq. A missing journal yields nothing.
+func readJournal(path string, sinceSeq uint64) iter.Seq2[Change, error] {
+	return func(yield func(Change, error) bool) {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			return
+		}
+		if err != nil {
+			yield(Change{}, err)
+			return
+		}
+		defer f.Close()
+
+		r := bufio.NewReader(f)
+		for {
+			change, err := readChange(r)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(Change{}, err)
+				return
+			}
+
+			if change.Seq <= sinceSeq {
+				continue
+			}
+			if !yield(change, nil) {
+				return
+			}
+		}
+	}
+}
+
+// readChange reads one journal record from r, returning io.EOF at a clean
+// record boundary.
+func readChange(r *bufio.Reader) (Change, error) {
+	var header [17]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return Change{}, errors.New("katalis: truncated journal record header")
+		}
+		return Change{}, err
+	}
+
+	change := Change{
+		Seq: binary.BigEndian.Uint64(header[:]),
+		At:  time.Unix(0, int64(binary.BigEndian.Uint64(header[8:]))),
+		Op:  Op(header[16]),
+	}
+
+	var err error
+	if change.Key, err = readStreamFrame(r); err != nil {
+		return Change{}, err
+	}
+	if change.Value, err = readStreamFrame(r); err != nil {
+		return Change{}, err
+	}
+	return change, nil
+}
+
+// readStreamFrame reads one length-prefixed frame from r, returning io.EOF
+// at a clean frame boundary.
+func readStreamFrame(r *bufio.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		if errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, io.EOF
+		}
+		return nil, errors.New("katalis: truncated journal frame")
+	}
+
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, errors.New("katalis: truncated journal frame")
+	}
+	return b, nil
+}
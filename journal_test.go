@@ -0,0 +1,115 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openJournaled(t *testing.T, path string) *katalis.JournaledDB[string, string] {
+	t.Helper()
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+
+	j, err := katalis.Journaled(db)
+	require.NoError(t, err)
+	return j
+}
+
+func collectChanges(t *testing.T, j *katalis.JournaledDB[string, string], since uint64) []katalis.Change {
+	t.Helper()
+
+	var changes []katalis.Change
+	for change, err := range j.Changes(since) {
+		require.NoError(t, err)
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func TestJournalRecordsChanges(t *testing.T) {
+	j := openJournaled(t, t.TempDir()+"/test.db")
+	defer j.Close()
+
+	require.NoError(t, j.Put("a", "1"))
+	require.NoError(t, j.Put("b", "2"))
+	require.NoError(t, j.Del("a"))
+
+	changes := collectChanges(t, j, 0)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, uint64(1), changes[0].Seq)
+	assert.Equal(t, katalis.OpPut, changes[0].Op)
+	assert.Equal(t, []byte("a"), changes[0].Key)
+	assert.Equal(t, []byte("1"), changes[0].Value)
+
+	assert.Equal(t, katalis.OpDel, changes[2].Op)
+	assert.Equal(t, []byte("a"), changes[2].Key)
+
+	assert.Equal(t, uint64(3), j.Seq())
+}
+
+func TestJournalChangesSince(t *testing.T) {
+	j := openJournaled(t, t.TempDir()+"/test.db")
+	defer j.Close()
+
+	require.NoError(t, j.Put("a", "1"))
+	require.NoError(t, j.Put("b", "2"))
+	require.NoError(t, j.Put("c", "3"))
+
+	changes := collectChanges(t, j, 2)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []byte("c"), changes[0].Key)
+}
+
+func TestJournalResumesAfterReopen(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	j := openJournaled(t, path)
+	require.NoError(t, j.Put("a", "1"))
+	require.NoError(t, j.Close())
+
+	j = openJournaled(t, path)
+	defer j.Close()
+
+	assert.Equal(t, uint64(1), j.Seq())
+
+	require.NoError(t, j.Put("b", "2"))
+	assert.Equal(t, uint64(2), j.Seq())
+
+	changes := collectChanges(t, j, 0)
+	assert.Len(t, changes, 2)
+}
+
+func TestJournalTruncate(t *testing.T) {
+	j := openJournaled(t, t.TempDir()+"/test.db")
+	defer j.Close()
+
+	require.NoError(t, j.Put("a", "1"))
+	require.NoError(t, j.Put("b", "2"))
+	require.NoError(t, j.Put("c", "3"))
+
+	require.NoError(t, j.Truncate(2))
+
+	changes := collectChanges(t, j, 0)
+	require.Len(t, changes, 1)
+	assert.Equal(t, uint64(3), changes[0].Seq)
+
+	// The journal keeps appending after truncation.
+	require.NoError(t, j.Put("d", "4"))
+	assert.Len(t, collectChanges(t, j, 0), 2)
+}
+
+func TestJournalAppliesWrites(t *testing.T) {
+	j := openJournaled(t, t.TempDir()+"/test.db")
+	defer j.Close()
+
+	require.NoError(t, j.Put("k", "v"))
+
+	val, err := j.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, "v", val)
+}
@@ -0,0 +1,149 @@
+// Package katalistest provides a scriptable fake Store for exercising the
+// error-handling paths of code that depends on katalis, without involving the
+// real storage engine.
+package katalistest
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Fake is an in-memory katalis.Store whose failures can be scripted: it can
+// return an injected error on the Nth call of each operation, report decode
+// failures for chosen keys, and add latency to every call. The zero value is
+// not usable, use New.
+type Fake[KT comparable, VT any] struct {
+	mem *katalis.MemStore[KT, VT]
+
+	mu        sync.Mutex
+	calls     map[string]int
+	failures  map[string]map[int]error
+	corrupted map[KT]bool
+	latency   time.Duration
+}
+
+var _ katalis.Store[string, int] = (*Fake[string, int])(nil)
+
+// New returns an empty Fake.
+func New[KT comparable, VT any]() *Fake[KT, VT] {
+	return &Fake[KT, VT]{
+		mem:       katalis.NewMemStore[KT, VT](),
+		calls:     make(map[string]int),
+		failures:  make(map[string]map[int]error),
+		corrupted: make(map[KT]bool),
+	}
+}
+
+// FailGet makes the n-th Get call (1-based, counted from now on) return err.
+func (f *Fake[KT, VT]) FailGet(n int, err error) { f.fail("get", n, err) }
+
+// FailPut makes the n-th Put call return err.
+func (f *Fake[KT, VT]) FailPut(n int, err error) { f.fail("put", n, err) }
+
+// FailDel makes the n-th Del call return err.
+func (f *Fake[KT, VT]) FailDel(n int, err error) { f.fail("del", n, err) }
+
+// FailHas makes the n-th Has call return err.
+func (f *Fake[KT, VT]) FailHas(n int, err error) { f.fail("has", n, err) }
+
+// CorruptValue makes every Get of key fail with a katalis.Error of kind
+// KindDecodeValue, as if the stored bytes no longer decoded.
+func (f *Fake[KT, VT]) CorruptValue(key KT) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.corrupted[key] = true
+}
+
+// SetLatency makes every operation sleep for d before running.
+func (f *Fake[KT, VT]) SetLatency(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency = d
+}
+
+// Calls returns how many times the given operation ("get", "put", "del",
+// "has") was invoked.
+func (f *Fake[KT, VT]) Calls(op string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[op]
+}
+
+func (f *Fake[KT, VT]) fail(op string, n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures[op] == nil {
+		f.failures[op] = make(map[int]error)
+	}
+	f.failures[op][f.calls[op]+n] = err
+}
+
+// enter records a call of op and returns the scripted error for it, if any.
+func (f *Fake[KT, VT]) enter(op string) error {
+	f.mu.Lock()
+	f.calls[op]++
+	err := f.failures[op][f.calls[op]]
+	latency := f.latency
+	f.mu.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	return err
+}
+
+// Get returns the value for the given key, honoring scripted failures and
+// corrupted keys.
+func (f *Fake[KT, VT]) Get(key KT) (res VT, err error) {
+	if err := f.enter("get"); err != nil {
+		return res, err
+	}
+
+	f.mu.Lock()
+	corrupt := f.corrupted[key]
+	f.mu.Unlock()
+	if corrupt {
+		return res, &katalis.Error{
+			Op:   "get",
+			Key:  fmt.Sprint(key),
+			Kind: katalis.KindDecodeValue,
+			Err:  errors.New("corrupted by katalistest"),
+		}
+	}
+	return f.mem.Get(key)
+}
+
+// Put sets the value for the given key, honoring scripted failures.
+func (f *Fake[KT, VT]) Put(key KT, val VT) error {
+	if err := f.enter("put"); err != nil {
+		return err
+	}
+	return f.mem.Put(key, val)
+}
+
+// Del deletes the value for the given key, honoring scripted failures.
+func (f *Fake[KT, VT]) Del(key KT) error {
+	if err := f.enter("del"); err != nil {
+		return err
+	}
+	return f.mem.Del(key)
+}
+
+// Has returns true if the store contains the given key, honoring scripted
+// failures.
+func (f *Fake[KT, VT]) Has(key KT) (bool, error) {
+	if err := f.enter("has"); err != nil {
+		return false, err
+	}
+	return f.mem.Has(key)
+}
+
+// Items returns an iterator over all key-value pairs.
+func (f *Fake[KT, VT]) Items() iter.Seq2[KT, VT] {
+	return f.mem.Items()
+}
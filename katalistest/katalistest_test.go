@@ -0,0 +1,72 @@
+package katalistest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/katalistest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeBehavesLikeStore(t *testing.T) {
+	f := katalistest.New[string, int]()
+
+	require.NoError(t, f.Put("a", 1))
+
+	val, err := f.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	has, err := f.Has("a")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	require.NoError(t, f.Del("a"))
+	has, err = f.Has("a")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestFakeFailNthPut(t *testing.T) {
+	f := katalistest.New[string, int]()
+	boom := errors.New("disk full")
+	f.FailPut(2, boom)
+
+	require.NoError(t, f.Put("a", 1))
+	assert.ErrorIs(t, f.Put("b", 2), boom)
+	require.NoError(t, f.Put("c", 3))
+
+	assert.Equal(t, 3, f.Calls("put"))
+
+	// The failed write must not have landed.
+	has, err := f.Has("b")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestFakeCorruptValue(t *testing.T) {
+	f := katalistest.New[string, int]()
+	require.NoError(t, f.Put("a", 1))
+
+	f.CorruptValue("a")
+
+	_, err := f.Get("a")
+	require.Error(t, err)
+
+	var kerr *katalis.Error
+	require.ErrorAs(t, err, &kerr)
+	assert.Equal(t, katalis.KindDecodeValue, kerr.Kind)
+}
+
+func TestFakeLatency(t *testing.T) {
+	f := katalistest.New[string, int]()
+	f.SetLatency(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err := f.Get("a")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
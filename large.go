@@ -0,0 +1,186 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// largeChunkSize is the maximum size of a single chunk written by PutLarge.
+// Splitting large values keeps single pogreb records small, bounding memory
+// usage during compaction.
+const largeChunkSize = 1 << 20
+
+// largePrefix namespaces the manifest and chunk keys of large values so they
+// cannot collide with regular records.
+var largePrefix = []byte("\xffkatalis.large\xff")
+
+// ErrNotFound is returned by GetLarge when no large value is stored under the
+// given key.
+var ErrNotFound = errors.New("katalis: not found")
+
+// PutLarge stores a value of arbitrary size by splitting the data read from r
+// into chunks kept under derived keys, with a manifest recording the chunk
+// count. Large values live in their own key space: they do not collide with
+// values stored via Put and must be read back with GetLarge.
+func (db DB[KT, VT]) PutLarge(key KT, r io.Reader) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	kb, err := db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	// Remember how many chunks a previous value under this key used, so the
+	// leftover ones can be deleted after an overwrite with fewer chunks.
+	oldChunks, _, _ := db.largeManifest(kb)
+
+	var (
+		chunks uint32
+		size   uint64
+		buf    = make([]byte, largeChunkSize)
+	)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := db.rawPut(largeChunkKey(kb, chunks), buf[:n]); err != nil {
+				return err
+			}
+			chunks++
+			size += uint64(n)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write the manifest last so a failed upload never looks complete.
+	manifest := binary.BigEndian.AppendUint32(nil, chunks)
+	manifest = binary.BigEndian.AppendUint64(manifest, size)
+	if err := db.rawPut(largeManifestKey(kb), manifest); err != nil {
+		return err
+	}
+
+	// Drop chunks left over from a previous, larger value.
+	for i := chunks; i < oldChunks; i++ {
+		if err := db.rawDel(largeChunkKey(kb, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLarge returns a reader over the value stored with PutLarge under the
+// given key, or ErrNotFound if there is none. The reader fetches one chunk at
+// a time, so the whole value is never resident in memory.
+func (db DB[KT, VT]) GetLarge(key KT) (io.ReadCloser, error) {
+	if err := db.closedErr(); err != nil {
+		return nil, err
+	}
+
+	kb, err := db.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, _, err := db.largeManifest(kb)
+	if err != nil {
+		return nil, err
+	}
+	return &largeReader[KT, VT]{db: db, kb: kb, chunks: chunks}, nil
+}
+
+// DelLarge deletes the value stored with PutLarge under the given key along
+// with all its chunks.
+func (db DB[KT, VT]) DelLarge(key KT) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	kb, err := db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	chunks, _, err := db.largeManifest(kb)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	for i := uint32(0); i < chunks; i++ {
+		if err := db.rawDel(largeChunkKey(kb, i)); err != nil {
+			return err
+		}
+	}
+	return db.rawDel(largeManifestKey(kb))
+}
+
+// largeManifest reads the manifest for the encoded key kb.
+func (db DB[KT, VT]) largeManifest(kb []byte) (chunks uint32, size uint64, err error) {
+	b, err := db.DB.Get(largeManifestKey(kb))
+	if err != nil {
+		return 0, 0, err
+	}
+	if b == nil {
+		return 0, 0, ErrNotFound
+	}
+	if len(b) < 12 {
+		return 0, 0, fmt.Errorf("katalis: corrupted large value manifest: %d bytes", len(b))
+	}
+	return binary.BigEndian.Uint32(b), binary.BigEndian.Uint64(b[4:]), nil
+}
+
+// The encoded key is length-prefixed so keys of different lengths can never
+// produce colliding derived keys.
+func largeManifestKey(kb []byte) []byte {
+	mk := appendFrame(append([]byte{}, largePrefix...), kb)
+	return append(mk, 'm')
+}
+
+func largeChunkKey(kb []byte, i uint32) []byte {
+	ck := appendFrame(append([]byte{}, largePrefix...), kb)
+	ck = append(ck, 'c')
+	return binary.BigEndian.AppendUint32(ck, i)
+}
+
+// largeReader streams the chunks of a large value in order.
+type largeReader[KT, VT any] struct {
+	db     DB[KT, VT]
+	kb     []byte
+	chunks uint32
+	next   uint32
+	buf    []byte
+}
+
+func (lr *largeReader[KT, VT]) Read(p []byte) (int, error) {
+	for len(lr.buf) == 0 {
+		if lr.next == lr.chunks {
+			return 0, io.EOF
+		}
+
+		chunk, err := lr.db.DB.Get(largeChunkKey(lr.kb, lr.next))
+		if err != nil {
+			return 0, err
+		}
+		if chunk == nil {
+			return 0, fmt.Errorf("katalis: missing chunk %d of large value", lr.next)
+		}
+		lr.buf = chunk
+		lr.next++
+	}
+
+	n := copy(p, lr.buf)
+	lr.buf = lr.buf[n:]
+	return n, nil
+}
+
+func (lr *largeReader[KT, VT]) Close() error { return nil }
@@ -0,0 +1,117 @@
+package katalis_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutLargeAndGetLarge(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Large enough to span multiple chunks.
+	data := make([]byte, 3<<20+123)
+	rand.New(rand.NewSource(42)).Read(data)
+
+	require.NoError(t, db.PutLarge("blob", bytes.NewReader(data)))
+
+	r, err := db.GetLarge("blob")
+	require.NoError(t, err)
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestGetLargeMissing(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.GetLarge("missing")
+	assert.ErrorIs(t, err, katalis.ErrNotFound)
+}
+
+func TestPutLargeOverwriteShrinks(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	big := make([]byte, 2<<20)
+	require.NoError(t, db.PutLarge("blob", bytes.NewReader(big)))
+
+	small := []byte("tiny")
+	require.NoError(t, db.PutLarge("blob", bytes.NewReader(small)))
+
+	r, err := db.GetLarge("blob")
+	require.NoError(t, err)
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, small, got)
+}
+
+func TestPutLargeEmpty(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.PutLarge("empty", bytes.NewReader(nil)))
+
+	r, err := db.GetLarge("empty")
+	require.NoError(t, err)
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestDelLarge(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.PutLarge("blob", bytes.NewReader(make([]byte, 1<<20+1))))
+	require.NoError(t, db.DelLarge("blob"))
+
+	_, err = db.GetLarge("blob")
+	assert.ErrorIs(t, err, katalis.ErrNotFound)
+
+	// Deleting a missing large value is not an error.
+	require.NoError(t, db.DelLarge("blob"))
+}
+
+func TestPutLargeDoesNotCollideWithPut(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", []byte("regular")))
+	require.NoError(t, db.PutLarge("key", bytes.NewReader([]byte("large"))))
+
+	val, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("regular"), val)
+}
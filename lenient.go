@@ -0,0 +1,60 @@
+package katalis
+
+import "iter"
+
+// AllItemsLenient returns an iterator over all decodable key-value pairs.
+// Records whose key or value fail to decode are skipped and reported to
+// onCorrupt with their raw encoded bytes, instead of being joined into the
+// stream or silently dropped. onCorrupt may be nil, in which case this
+// behaves like Items.
+func (db DB[KT, VT]) AllItemsLenient(onCorrupt func(key, val []byte, err error)) iter.Seq2[KT, VT] {
+	if db.closedErr() != nil {
+		return func(yield func(KT, VT) bool) {}
+	}
+	items := db.DB.Items()
+
+	return func(yield func(KT, VT) bool) {
+		for {
+			kb, vb, err := items.Next()
+			if isTerminate(err) {
+				return
+			}
+			if err == nil && !db.inView(kb) {
+				continue
+			}
+			if err != nil {
+				if onCorrupt != nil {
+					onCorrupt(kb, vb, err)
+				}
+				continue
+			}
+
+			key, err := db.keyCodec.Decode(kb)
+			if err == nil {
+				var val VT
+				if val, err = db.valCodec.Decode(vb); err == nil {
+					if !yield(key, val) {
+						return
+					}
+					continue
+				}
+			}
+			if onCorrupt != nil {
+				onCorrupt(kb, vb, err)
+			}
+		}
+	}
+}
+
+// FoldLenient is like Fold, but one undecodable record no longer poisons the
+// whole walk: corrupted records are reported to onCorrupt and skipped, and fn
+// only ever sees records that fully decoded. A non-nil error from fn still
+// stops the iteration.
+func (db DB[KT, VT]) FoldLenient(fn func(key KT, val VT) error, onCorrupt func(key, val []byte, err error)) error {
+	for key, val := range db.AllItemsLenient(onCorrupt) {
+		if err := fn(key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
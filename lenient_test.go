@@ -0,0 +1,74 @@
+package katalis_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllItemsLenient(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+	// Plant a record whose value cannot decode as int.
+	require.NoError(t, db.DB.Put([]byte("bad"), []byte{1}))
+
+	var corrupt [][]byte
+	got := make(map[string]int)
+	for key, val := range db.AllItemsLenient(func(kb, vb []byte, err error) {
+		assert.Error(t, err)
+		corrupt = append(corrupt, kb)
+	}) {
+		got[key] = val
+	}
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, got)
+	require.Len(t, corrupt, 1)
+	assert.Equal(t, []byte("bad"), corrupt[0])
+}
+
+func TestFoldLenient(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.DB.Put([]byte("bad"), []byte{1}))
+
+	var reported int
+	sum := 0
+	err = db.FoldLenient(
+		func(key string, val int) error {
+			sum += val
+			return nil
+		},
+		func(kb, vb []byte, err error) { reported++ },
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sum)
+	assert.Equal(t, 1, reported)
+}
+
+func TestFoldLenientStopsOnFnError(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	boom := errors.New("boom")
+	calls := 0
+	err = db.FoldLenient(func(key string, val int) error {
+		calls++
+		return boom
+	}, nil)
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,56 @@
+package katalis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTooLarge is returned by writes whose encoded key or value exceeds the
+// limits set with WithMaxKeySize and WithMaxValueSize.
+var ErrTooLarge = errors.New("katalis: record exceeds size limit")
+
+// openConfig collects everything that Open options can tweak.
+type openConfig struct {
+	opts         *Options
+	maxKeySize   int
+	maxValSize   int
+	maxDiskUsage int64
+	writeLimit   *rateLimiter
+	byteLimit    *rateLimiter
+	bloom        *bloomFilter
+	expvarName   string
+	policy       CompactionPolicy
+	metadata     bool
+	hook         func(Event)
+	opTimeout    time.Duration
+	readDedup    bool
+}
+
+// OpenOption tweaks the configuration used by Open.
+type OpenOption func(*openConfig)
+
+// WithMaxKeySize makes writes whose encoded key is larger than n bytes fail
+// fast with ErrTooLarge instead of touching disk. Zero means unlimited.
+func WithMaxKeySize(n int) OpenOption {
+	return func(c *openConfig) { c.maxKeySize = n }
+}
+
+// WithMaxValueSize makes writes whose encoded value is larger than n bytes
+// fail fast with ErrTooLarge instead of touching disk. Zero means unlimited.
+func WithMaxValueSize(n int) OpenOption {
+	return func(c *openConfig) { c.maxValSize = n }
+}
+
+// checkLimits validates the encoded sizes of a write against the DB's limits.
+func (db DB[KT, VT]) checkLimits(key any, kb, vb []byte) error {
+	if db.maxKeySize > 0 && len(kb) > db.maxKeySize {
+		return opErr("put", key, KindTooLarge,
+			fmt.Errorf("encoded key is %d bytes, limit is %d", len(kb), db.maxKeySize))
+	}
+	if db.maxValSize > 0 && len(vb) > db.maxValSize {
+		return opErr("put", key, KindTooLarge,
+			fmt.Errorf("encoded value is %d bytes, limit is %d", len(vb), db.maxValSize))
+	}
+	return nil
+}
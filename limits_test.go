@@ -0,0 +1,57 @@
+package katalis_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxKeySize(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.IntCodec,
+		katalis.WithMaxKeySize(8),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("short", 1))
+
+	err = db.Put(strings.Repeat("k", 9), 2)
+	assert.ErrorIs(t, err, katalis.ErrTooLarge)
+
+	var kerr *katalis.Error
+	require.ErrorAs(t, err, &kerr)
+	assert.Equal(t, katalis.KindTooLarge, kerr.Kind)
+}
+
+func TestWithMaxValueSize(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.IntCodec,
+		katalis.StringCodec,
+		katalis.WithMaxValueSize(16),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put(1, "fits"))
+	assert.ErrorIs(t, db.Put(2, strings.Repeat("v", 17)), katalis.ErrTooLarge)
+
+	// The rejected write must not have landed.
+	has, err := db.Has(2)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestNoLimitsByDefault(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, db.Put(1, strings.Repeat("v", 1<<16)))
+}
@@ -0,0 +1,148 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// ListDB wraps a DB so one logical key can hold an ordered, append-only list
+// of values, the bread and butter of per-entity event sourcing. Each element
+// lands under an internal sub-key derived from the logical key and its
+// position, so appends never rewrite the existing elements.
+//
+// The wrapper assumes it owns its keys: mixing plain Puts and lists for the
+// same key on the same store leads to undefined records.
+type ListDB[KT, VT any] struct {
+	db DB[KT, VT]
+	mu sync.Mutex // serializes the length bump in Append
+}
+
+// Lists wraps the DB with append-only multi-value keys.
+func Lists[KT, VT any](db DB[KT, VT]) *ListDB[KT, VT] {
+	return &ListDB[KT, VT]{db: db}
+}
+
+// Append adds item at the end of the list stored under key, creating the
+// list if the key is new.
+func (l *ListDB[KT, VT]) Append(key KT, item VT) error {
+	hk, err := l.headKey(key)
+	if err != nil {
+		return err
+	}
+	vb, err := l.db.valCodec.Encode(item)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n, err := l.len(hk)
+	if err != nil {
+		return err
+	}
+	// Element first, head second: a crash in between leaves an orphan
+	// element the list never exposes, not a hole.
+	if err := l.db.rawPut(elemKey(hk, n), vb); err != nil {
+		return err
+	}
+	return l.db.rawPut(hk, binary.BigEndian.AppendUint64(nil, n+1))
+}
+
+// List returns an iterator over the elements stored under key, in append
+// order. A missing key yields nothing; elements that fail to decode are
+// skipped, like in Items.
+func (l *ListDB[KT, VT]) List(key KT) iter.Seq[VT] {
+	return func(yield func(VT) bool) {
+		hk, err := l.headKey(key)
+		if err != nil {
+			return
+		}
+		n, err := l.len(hk)
+		if err != nil {
+			return
+		}
+
+		for i := uint64(0); i < n; i++ {
+			vb, err := l.db.DB.Get(elemKey(hk, i))
+			if err != nil || vb == nil {
+				continue
+			}
+			val, err := l.db.valCodec.Decode(vb)
+			if err != nil {
+				continue
+			}
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of elements stored under key, 0 if the key doesn't
+// exist.
+func (l *ListDB[KT, VT]) Len(key KT) (uint64, error) {
+	hk, err := l.headKey(key)
+	if err != nil {
+		return 0, err
+	}
+	return l.len(hk)
+}
+
+// DelList deletes the list stored under key, elements included. Missing keys
+// are a no-op.
+func (l *ListDB[KT, VT]) DelList(key KT) error {
+	hk, err := l.headKey(key)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n, err := l.len(hk)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < n; i++ {
+		if err := l.db.rawDel(elemKey(hk, i)); err != nil {
+			return err
+		}
+	}
+	return l.db.rawDel(hk)
+}
+
+// Close closes the underlying DB.
+func (l *ListDB[KT, VT]) Close() error { return l.db.Close() }
+
+// headKey returns the raw key of the list's length record. The encoded key
+// length goes in front so element sub-keys of different lists can't collide.
+func (l *ListDB[KT, VT]) headKey(key KT) ([]byte, error) {
+	kb, err := l.db.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	hk := binary.AppendUvarint(nil, uint64(len(kb)))
+	return append(hk, kb...), nil
+}
+
+// elemKey returns the raw sub-key of element i of the list headed by hk.
+func elemKey(hk []byte, i uint64) []byte {
+	ek := make([]byte, 0, len(hk)+8)
+	ek = append(ek, hk...)
+	return binary.BigEndian.AppendUint64(ek, i)
+}
+
+// len reads the list length stored at hk, 0 when absent.
+func (l *ListDB[KT, VT]) len(hk []byte) (uint64, error) {
+	b, err := l.db.DB.Get(hk)
+	if err != nil || b == nil {
+		return 0, err
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("katalis: malformed list head record")
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
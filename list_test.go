@@ -0,0 +1,94 @@
+package katalis_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLists(t *testing.T) *katalis.ListDB[string, string] {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return katalis.Lists(db)
+}
+
+func TestListAppendOrder(t *testing.T) {
+	l := newLists(t)
+
+	require.NoError(t, l.Append("order:42", "created"))
+	require.NoError(t, l.Append("order:42", "paid"))
+	require.NoError(t, l.Append("order:42", "shipped"))
+
+	assert.Equal(t,
+		[]string{"created", "paid", "shipped"},
+		slices.Collect(l.List("order:42")))
+
+	n, err := l.Len("order:42")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), n)
+}
+
+func TestListKeysAreIndependent(t *testing.T) {
+	l := newLists(t)
+
+	require.NoError(t, l.Append("a", "one"))
+	require.NoError(t, l.Append("b", "uno"))
+	require.NoError(t, l.Append("a", "two"))
+
+	assert.Equal(t, []string{"one", "two"}, slices.Collect(l.List("a")))
+	assert.Equal(t, []string{"uno"}, slices.Collect(l.List("b")))
+}
+
+func TestListMissingKey(t *testing.T) {
+	l := newLists(t)
+
+	assert.Empty(t, slices.Collect(l.List("absent")))
+
+	n, err := l.Len("absent")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestListDelList(t *testing.T) {
+	l := newLists(t)
+
+	require.NoError(t, l.Append("a", "one"))
+	require.NoError(t, l.Append("a", "two"))
+	require.NoError(t, l.Append("b", "uno"))
+
+	require.NoError(t, l.DelList("a"))
+	require.NoError(t, l.DelList("absent")) // no-op
+
+	assert.Empty(t, slices.Collect(l.List("a")))
+	assert.Equal(t, []string{"uno"}, slices.Collect(l.List("b")))
+
+	// A deleted list starts over from scratch.
+	require.NoError(t, l.Append("a", "fresh"))
+	assert.Equal(t, []string{"fresh"}, slices.Collect(l.List("a")))
+}
+
+func TestListEarlyBreak(t *testing.T) {
+	l := newLists(t)
+
+	for _, s := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, l.Append("k", s))
+	}
+
+	var got []string
+	for v := range l.List("k") {
+		got = append(got, v)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, got)
+}
@@ -0,0 +1,47 @@
+package katalis
+
+// GetOrLoad returns the value for the given key, falling back to loader on a
+// miss and persisting what it returns: the cache-aside pattern in one call.
+// Misses for the same key are singleflighted through the key's advisory
+// lock, so concurrent callers trigger exactly one loader call and the rest
+// read the stored result. A loader error is returned as is and nothing is
+// persisted.
+func (db DB[KT, VT]) GetOrLoad(key KT, loader func(KT) (VT, error)) (res VT, err error) {
+	if err := db.closedErr(); err != nil {
+		return res, err
+	}
+
+	// Fast path: no lock when the key is already there.
+	has, err := db.Has(key)
+	if err != nil {
+		return res, err
+	}
+	if has {
+		return db.Get(key)
+	}
+
+	unlock, err := db.Lock(key)
+	if err != nil {
+		return res, err
+	}
+	defer unlock()
+
+	// Re-check under the lock: a concurrent miss may have loaded the key
+	// while this caller waited.
+	if has, err = db.Has(key); err != nil {
+		return res, err
+	}
+	if has {
+		return db.Get(key)
+	}
+
+	if res, err = loader(key); err != nil {
+		var zero VT
+		return zero, err
+	}
+	if err := db.Put(key, res); err != nil {
+		var zero VT
+		return zero, err
+	}
+	return res, nil
+}
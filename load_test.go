@@ -0,0 +1,86 @@
+package katalis_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrLoadHit(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 42))
+
+	v, err := db.GetOrLoad("key", func(string) (int, error) {
+		t.Fatal("loader called on a hit")
+		return 0, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+}
+
+func TestGetOrLoadMiss(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	v, err := db.GetOrLoad("key", func(key string) (int, error) {
+		assert.Equal(t, "key", key)
+		return 7, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+
+	// The loaded value was persisted.
+	v, err = db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+}
+
+func TestGetOrLoadSingleflight(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := db.GetOrLoad("key", func(string) (int, error) {
+				calls.Add(1)
+				return 99, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 99, v)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestGetOrLoadLoaderError(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	boom := errors.New("boom")
+	_, err = db.GetOrLoad("key", func(string) (int, error) {
+		return 0, boom
+	})
+	assert.ErrorIs(t, err, boom)
+
+	// Nothing was persisted, so the next call loads again.
+	has, err := db.Has("key")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
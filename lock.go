@@ -0,0 +1,63 @@
+package katalis
+
+import "sync"
+
+// lockStripes is the number of mutexes keys are striped over. Distinct keys
+// may share a stripe, which can only cause extra serialization, never missed
+// exclusion.
+const lockStripes = 128
+
+// lockTable is a striped mutex map shared by all copies of a DB handle.
+type lockTable struct {
+	stripes [lockStripes]sync.Mutex
+}
+
+func (lt *lockTable) stripe(kb []byte) *sync.Mutex {
+	return &lt.stripes[fnvHash(kb)%lockStripes]
+}
+
+// Lock acquires an advisory lock for the given key, blocking until it is
+// available, and returns the function that releases it. It lets application
+// code serialize multi-step logic per key:
+//
+//	unlock, err := db.Lock(key)
+//	if err != nil {
+//		return err
+//	}
+//	defer unlock()
+//
+// The lock is advisory: it only excludes other Lock and TryLock calls on the
+// same DB, not plain Put or Get.
+func (db DB[KT, VT]) Lock(key KT) (unlock func(), err error) {
+	if err := db.closedErr(); err != nil {
+		return nil, err
+	}
+
+	kb, err := db.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+
+	mu := db.locks.stripe(kb)
+	mu.Lock()
+	return mu.Unlock, nil
+}
+
+// TryLock is like Lock but does not block: it reports whether the lock was
+// acquired, and returns the release function only if it was.
+func (db DB[KT, VT]) TryLock(key KT) (unlock func(), ok bool, err error) {
+	if err := db.closedErr(); err != nil {
+		return nil, false, err
+	}
+
+	kb, err := db.keyCodec.Encode(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	mu := db.locks.stripe(kb)
+	if !mu.TryLock() {
+		return nil, false, nil
+	}
+	return mu.Unlock, true, nil
+}
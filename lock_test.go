@@ -0,0 +1,61 @@
+package katalis_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockSerializesAccess(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("counter", 0))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			unlock, err := db.Lock("counter")
+			require.NoError(t, err)
+			defer unlock()
+
+			val, err := db.Get("counter")
+			require.NoError(t, err)
+			require.NoError(t, db.Put("counter", val+1))
+		}()
+	}
+	wg.Wait()
+
+	val, err := db.Get("counter")
+	require.NoError(t, err)
+	assert.Equal(t, 10, val)
+}
+
+func TestTryLock(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	unlock, ok, err := db.TryLock("k")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A second attempt on the held lock fails without blocking.
+	_, ok, err = db.TryLock("k")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	unlock()
+
+	unlock, ok, err = db.TryLock("k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	unlock()
+}
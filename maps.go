@@ -0,0 +1,35 @@
+package katalis
+
+// Collect reads the whole store into a map. Records that fail to decode make
+// it return early with the error. KT must be comparable, which is why this is
+// a function rather than a method on DB.
+func Collect[KT comparable, VT any](db DB[KT, VT]) (map[KT]VT, error) {
+	if err := db.closedErr(); err != nil {
+		return nil, err
+	}
+
+	res := make(map[KT]VT)
+	for entry, err := range db.AllItems() {
+		if err != nil {
+			return nil, err
+		}
+		res[entry.Key] = entry.Value
+	}
+	return res, nil
+}
+
+// PutAll writes every pair of m into the store, syncing once at the end
+// instead of per write. If a write fails, the pairs already written stay in
+// the store. Like Collect it needs KT comparable, so it is a function.
+func PutAll[KT comparable, VT any](db DB[KT, VT], m map[KT]VT) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	for key, val := range m {
+		if err := db.Put(key, val); err != nil {
+			return err
+		}
+	}
+	return db.Sync()
+}
@@ -0,0 +1,42 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutAllCollect(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	want := map[string]int{"a": 1, "b": 2, "c": 3}
+	require.NoError(t, katalis.PutAll(db, want))
+
+	got, err := katalis.Collect(db)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestCollectEmpty(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	got, err := katalis.Collect(db)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestCollectClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = katalis.Collect(db)
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+	assert.ErrorIs(t, katalis.PutAll(db, map[string]int{"a": 1}), katalis.ErrClosed)
+}
@@ -0,0 +1,52 @@
+package katalis
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// Binary returns a BinaryCodec for type T. The optional variadic parameter
+// allows type inference from a value.
+func Binary[T encoding.BinaryMarshaler](_ ...T) (b BinaryCodec[T]) { return }
+
+// Text returns a TextCodec for type T. The optional variadic parameter allows
+// type inference from a value.
+func Text[T encoding.TextMarshaler](_ ...T) (t TextCodec[T]) { return }
+
+// BinaryCodec is a generic codec for types implementing the standard library
+// encoding.BinaryMarshaler interface, e.g. time.Time or netip.Addr. Decoding
+// requires *T to implement encoding.BinaryUnmarshaler.
+type BinaryCodec[T encoding.BinaryMarshaler] struct{}
+
+// Encode serializes the value using its MarshalBinary method.
+func (bc BinaryCodec[T]) Encode(t T) ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+// Decode deserializes the value using its UnmarshalBinary method.
+func (bc BinaryCodec[T]) Decode(b []byte) (t T, err error) {
+	u, ok := any(&t).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return t, fmt.Errorf("katalis: %T does not implement encoding.BinaryUnmarshaler", &t)
+	}
+	return t, u.UnmarshalBinary(b)
+}
+
+// TextCodec is a generic codec for types implementing the standard library
+// encoding.TextMarshaler interface. Decoding requires *T to implement
+// encoding.TextUnmarshaler.
+type TextCodec[T encoding.TextMarshaler] struct{}
+
+// Encode serializes the value using its MarshalText method.
+func (tc TextCodec[T]) Encode(t T) ([]byte, error) {
+	return t.MarshalText()
+}
+
+// Decode deserializes the value using its UnmarshalText method.
+func (tc TextCodec[T]) Decode(b []byte) (t T, err error) {
+	u, ok := any(&t).(encoding.TextUnmarshaler)
+	if !ok {
+		return t, fmt.Errorf("katalis: %T does not implement encoding.TextUnmarshaler", &t)
+	}
+	return t, u.UnmarshalText(b)
+}
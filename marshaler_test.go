@@ -0,0 +1,88 @@
+package katalis_test
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryCodec(t *testing.T) {
+	codec := katalis.Binary[time.Time]()
+
+	tests := []struct {
+		name  string
+		input time.Time
+	}{
+		{"zero", time.Time{}},
+		{"now", time.Now()},
+		{"with location", time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := codec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := codec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.True(t, tt.input.Equal(decoded))
+		})
+	}
+}
+
+func TestBinaryCodecNetip(t *testing.T) {
+	codec := katalis.Binary[netip.Addr]()
+	addr := netip.MustParseAddr("192.168.1.1")
+
+	encoded, err := codec.Encode(addr)
+	require.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, addr, decoded)
+}
+
+func TestTextCodec(t *testing.T) {
+	codec := katalis.Text[netip.Addr]()
+
+	tests := []struct {
+		name  string
+		input netip.Addr
+	}{
+		{"ipv4", netip.MustParseAddr("10.0.0.1")},
+		{"ipv6", netip.MustParseAddr("::1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := codec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := codec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+}
+
+func TestBinaryCodecInDB(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.Binary[time.Time](), katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	require.NoError(t, db.Put(now, "event"))
+
+	val, err := db.Get(now)
+	require.NoError(t, err)
+	assert.Equal(t, "event", val)
+}
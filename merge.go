@@ -0,0 +1,220 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+)
+
+// ErrMalformedMerge is returned when a merge record's operand list cannot be
+// parsed, typically because the key was also written without the wrapper.
+var ErrMalformedMerge = errors.New("katalis: malformed merge record")
+
+// mergeCollapseAfter is how many pending operands a record may accumulate
+// before Merge collapses it into a single value on the next append.
+const mergeCollapseAfter = 32
+
+// MergeDB wraps a DB with a user-registered associative merge function, so
+// counters, set-unions and append-lists don't need a read-modify-write round
+// trip per update: Merge only appends the encoded operand to the record, and
+// the operands are collapsed through the merge function lazily, on read or
+// when a record accumulates too many of them.
+//
+// Records are stored as a length-prefixed operand list, so keys written
+// through the wrapper must also be read through it.
+type MergeDB[KT, VT any] struct {
+	db DB[KT, VT]
+	fn func(acc, operand VT) VT
+	mu sync.Mutex // serializes the append in Merge and the collapses
+}
+
+// Merged wraps the DB with the associative merge function fn, which combines
+// the accumulated value with the next operand. Operands are applied oldest
+// first, so non-commutative functions like list-append behave as expected.
+func Merged[KT, VT any](db DB[KT, VT], fn func(acc, operand VT) VT) *MergeDB[KT, VT] {
+	return &MergeDB[KT, VT]{db: db, fn: fn}
+}
+
+// Merge applies operand to the value stored under key, lazily: the encoded
+// operand is appended to the record without decoding or merging what's
+// already there. On a missing key the operand becomes the initial value,
+// without going through the merge function.
+func (m *MergeDB[KT, VT]) Merge(key KT, operand VT) error {
+	kb, err := m.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+	ob, err := m.db.valCodec.Encode(operand)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	raw, err := m.db.DB.Get(kb)
+	if err != nil {
+		return err
+	}
+	if raw != nil {
+		ops, err := splitOperands(raw)
+		if err != nil {
+			return err
+		}
+		// Bound how much a hot record can grow before reads get slow.
+		if len(ops) >= mergeCollapseAfter {
+			if raw, err = m.collapse(ops); err != nil {
+				return err
+			}
+		}
+	}
+	return m.db.rawPut(kb, appendOperand(raw, ob))
+}
+
+// Get returns the merged value for the given key, folding any pending
+// operands through the merge function, or an empty value if the key doesn't
+// exist. The stored record is left as is.
+func (m *MergeDB[KT, VT]) Get(key KT) (res VT, err error) {
+	kb, err := m.db.keyCodec.Encode(key)
+	if err != nil {
+		return res, err
+	}
+	raw, err := m.db.DB.Get(kb)
+	if err != nil || raw == nil {
+		return res, err
+	}
+
+	ops, err := splitOperands(raw)
+	if err != nil {
+		return res, err
+	}
+	return m.fold(ops)
+}
+
+// Put replaces whatever is stored under key, pending operands included, with
+// the given value.
+func (m *MergeDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := m.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+	vb, err := m.db.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+	return m.db.rawPut(kb, appendOperand(nil, vb))
+}
+
+// Del deletes the value and any pending operands for the given key.
+func (m *MergeDB[KT, VT]) Del(key KT) error { return m.db.Del(key) }
+
+// Has returns true if the DB contains the given key.
+func (m *MergeDB[KT, VT]) Has(key KT) (bool, error) { return m.db.Has(key) }
+
+// Close closes the underlying DB.
+func (m *MergeDB[KT, VT]) Close() error { return m.db.Close() }
+
+// Collapse folds the key's pending operands and rewrites the record as a
+// single value, reclaiming the operand list's space. Missing keys are a
+// no-op.
+func (m *MergeDB[KT, VT]) Collapse(key KT) error {
+	kb, err := m.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.collapseRecord(kb)
+}
+
+// CollapseAll folds the pending operands of every record, typically before a
+// Compact so the store only keeps fully merged values.
+func (m *MergeDB[KT, VT]) CollapseAll() error {
+	if err := m.db.closedErr(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys, _ := m.db.rawSnapshot()
+	for _, kb := range keys {
+		if err := m.collapseRecord(kb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collapseRecord folds the record at kb in place. The caller holds m.mu.
+func (m *MergeDB[KT, VT]) collapseRecord(kb []byte) error {
+	raw, err := m.db.DB.Get(kb)
+	if err != nil || raw == nil {
+		return err
+	}
+	ops, err := splitOperands(raw)
+	if err != nil {
+		return err
+	}
+	if len(ops) == 1 {
+		return nil
+	}
+	if raw, err = m.collapse(ops); err != nil {
+		return err
+	}
+	return m.db.rawPut(kb, raw)
+}
+
+// fold decodes the operands and combines them through the merge function,
+// oldest first.
+func (m *MergeDB[KT, VT]) fold(ops [][]byte) (acc VT, err error) {
+	if acc, err = m.db.valCodec.Decode(ops[0]); err != nil {
+		return acc, err
+	}
+	for _, ob := range ops[1:] {
+		op, err := m.db.valCodec.Decode(ob)
+		if err != nil {
+			return acc, err
+		}
+		acc = m.fn(acc, op)
+	}
+	return acc, nil
+}
+
+// collapse folds the operands and re-encodes the result as a single-operand
+// record.
+func (m *MergeDB[KT, VT]) collapse(ops [][]byte) ([]byte, error) {
+	acc, err := m.fold(ops)
+	if err != nil {
+		return nil, err
+	}
+	vb, err := m.db.valCodec.Encode(acc)
+	if err != nil {
+		return nil, err
+	}
+	return appendOperand(nil, vb), nil
+}
+
+// appendOperand appends a length-prefixed operand to a raw merge record.
+func appendOperand(raw, ob []byte) []byte {
+	raw = binary.AppendUvarint(raw, uint64(len(ob)))
+	return append(raw, ob...)
+}
+
+// splitOperands parses a raw merge record into its operands.
+func splitOperands(raw []byte) ([][]byte, error) {
+	var ops [][]byte
+	for len(raw) > 0 {
+		n, read := binary.Uvarint(raw)
+		if read <= 0 || uint64(len(raw)-read) < n {
+			return nil, ErrMalformedMerge
+		}
+		ops = append(ops, raw[read:read+int(n)])
+		raw = raw[read+int(n):]
+	}
+	if len(ops) == 0 {
+		return nil, ErrMalformedMerge
+	}
+	return ops, nil
+}
@@ -0,0 +1,124 @@
+package katalis_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeCounter(t *testing.T) *katalis.MergeDB[string, int] {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return katalis.Merged(db, func(acc, operand int) int { return acc + operand })
+}
+
+func TestMergeCounter(t *testing.T) {
+	m := newMergeCounter(t)
+
+	// The first operand becomes the initial value.
+	require.NoError(t, m.Merge("hits", 1))
+	require.NoError(t, m.Merge("hits", 2))
+	require.NoError(t, m.Merge("hits", 3))
+
+	val, err := m.Get("hits")
+	require.NoError(t, err)
+	assert.Equal(t, 6, val)
+
+	// Missing keys still read as the zero value.
+	val, err = m.Get("absent")
+	require.NoError(t, err)
+	assert.Zero(t, val)
+}
+
+func TestMergeAppendList(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Operands apply oldest first, so append order is preserved.
+	m := katalis.Merged(db, func(acc, operand string) string { return acc + "," + operand })
+	require.NoError(t, m.Merge("log", "a"))
+	require.NoError(t, m.Merge("log", "b"))
+	require.NoError(t, m.Merge("log", "c"))
+
+	val, err := m.Get("log")
+	require.NoError(t, err)
+	assert.Equal(t, "a,b,c", val)
+}
+
+func TestMergePutReplaces(t *testing.T) {
+	m := newMergeCounter(t)
+
+	require.NoError(t, m.Merge("hits", 5))
+	require.NoError(t, m.Merge("hits", 5))
+	require.NoError(t, m.Put("hits", 1))
+	require.NoError(t, m.Merge("hits", 1))
+
+	val, err := m.Get("hits")
+	require.NoError(t, err)
+	assert.Equal(t, 2, val)
+}
+
+func TestMergeAutoCollapse(t *testing.T) {
+	m := newMergeCounter(t)
+
+	// Well past the collapse threshold: the record is folded along the way
+	// and the total must survive it.
+	for range 100 {
+		require.NoError(t, m.Merge("hits", 1))
+	}
+	val, err := m.Get("hits")
+	require.NoError(t, err)
+	assert.Equal(t, 100, val)
+}
+
+func TestMergeCollapse(t *testing.T) {
+	m := newMergeCounter(t)
+
+	require.NoError(t, m.Merge("a", 1))
+	require.NoError(t, m.Merge("a", 2))
+	require.NoError(t, m.Merge("b", 10))
+	require.NoError(t, m.Merge("b", 20))
+
+	require.NoError(t, m.Collapse("a"))
+	require.NoError(t, m.CollapseAll())
+	require.NoError(t, m.Collapse("absent")) // no-op
+
+	val, err := m.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, val)
+
+	val, err = m.Get("b")
+	require.NoError(t, err)
+	assert.Equal(t, 30, val)
+
+	// Collapsed records keep accepting operands.
+	require.NoError(t, m.Merge("a", 4))
+	val, err = m.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 7, val)
+}
+
+func TestMergeDelAndHas(t *testing.T) {
+	m := newMergeCounter(t)
+
+	require.NoError(t, m.Merge("hits", 1))
+	has, err := m.Has("hits")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	require.NoError(t, m.Del("hits"))
+	has, err = m.Has("hits")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
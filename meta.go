@@ -0,0 +1,173 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// Meta is the record-level metadata stored by WithMetadata.
+type Meta struct {
+	// Created is when the key was first written.
+	Created time.Time
+	// Updated is when the value was last written.
+	Updated time.Time
+}
+
+// metaVersion tags the envelope layout so it can evolve.
+const metaVersion = 1
+
+// metaLen is the envelope header size: version byte plus the created and
+// updated timestamps as big-endian unix nanoseconds.
+const metaLen = 1 + 8 + 8
+
+// ErrNoMetadata is returned by GetMeta and ItemsUpdatedSince on stores that
+// were not opened with WithMetadata.
+var ErrNoMetadata = errors.New("katalis: store has no metadata envelopes, open it with WithMetadata")
+
+// WithMetadata makes the store keep created-at and updated-at timestamps
+// inside every record, readable with GetMeta and filterable with
+// ItemsUpdatedSince, so audit trails no longer need timestamp fields
+// duplicated in every value struct:
+//
+//	db, err := katalis.Open(path, kc, vc, katalis.WithMetadata())
+//
+// The envelope is part of the on-disk value, so a store must use it from its
+// first write: opening an existing plain store with WithMetadata (or the
+// other way around) makes values fail to decode.
+func WithMetadata() OpenOption {
+	return func(c *openConfig) { c.metadata = true }
+}
+
+// metaCodec wraps the value codec with the metadata envelope. Encode stamps
+// both timestamps with the write time; put copies the created timestamp
+// forward from the record being overwritten.
+type metaCodec[T any] struct {
+	inner Codec[T]
+}
+
+func (c metaCodec[T]) Encode(v T) ([]byte, error) {
+	vb, err := c.inner.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uint64(time.Now().UnixNano())
+	b := make([]byte, 0, metaLen+len(vb))
+	b = append(b, metaVersion)
+	b = binary.BigEndian.AppendUint64(b, now)
+	b = binary.BigEndian.AppendUint64(b, now)
+	return append(b, vb...), nil
+}
+
+func (c metaCodec[T]) Decode(b []byte) (T, error) {
+	// Missing keys reach the codec as empty bytes; keep the inner codec's
+	// zero-value semantics for them.
+	if len(b) == 0 {
+		return c.inner.Decode(b)
+	}
+	if err := checkMeta(b); err != nil {
+		var zero T
+		return zero, err
+	}
+	return c.inner.Decode(b[metaLen:])
+}
+
+// checkMeta validates the envelope header of a raw record.
+func checkMeta(b []byte) error {
+	if len(b) < metaLen || b[0] != metaVersion {
+		return fmt.Errorf("katalis: value has no metadata envelope")
+	}
+	return nil
+}
+
+// parseMeta reads the timestamps out of a raw enveloped record.
+func parseMeta(b []byte) (Meta, error) {
+	if err := checkMeta(b); err != nil {
+		return Meta{}, err
+	}
+	return Meta{
+		Created: time.Unix(0, int64(binary.BigEndian.Uint64(b[1:9]))),
+		Updated: time.Unix(0, int64(binary.BigEndian.Uint64(b[9:17]))),
+	}, nil
+}
+
+// preserveCreated copies the created timestamp of the record previously
+// stored under kb into the freshly encoded envelope vb.
+func (db DB[KT, VT]) preserveCreated(kb, vb []byte) {
+	old, err := db.DB.Get(kb)
+	if err != nil || checkMeta(old) != nil {
+		return
+	}
+	copy(vb[1:9], old[1:9])
+}
+
+// GetMeta returns the metadata of the record stored under key.
+func (db DB[KT, VT]) GetMeta(key KT) (Meta, error) {
+	if err := db.closedErr(); err != nil {
+		return Meta{}, err
+	}
+	if !db.metaMode {
+		return Meta{}, ErrNoMetadata
+	}
+
+	kbuf := getBuf()
+	defer putBuf(kbuf)
+	kb, err := encodeInto(db.keyCodec, kbuf, key)
+	if err != nil {
+		return Meta{}, opErr("getmeta", key, KindEncodeKey, err)
+	}
+
+	b, err := db.DB.Get(kb)
+	if err != nil {
+		return Meta{}, opErr("getmeta", key, KindIO, err)
+	}
+	if b == nil {
+		return Meta{}, opErr("getmeta", key, KindNotFound, errors.New("key not found"))
+	}
+
+	meta, err := parseMeta(b)
+	if err != nil {
+		return Meta{}, opErr("getmeta", key, KindCorrupt, err)
+	}
+	return meta, nil
+}
+
+// ItemsUpdatedSince returns an iterator over the records whose last write
+// happened at or after t, in the same shape as AllItems.
+func (db DB[KT, VT]) ItemsUpdatedSince(t time.Time) iter.Seq2[Entry[KT, VT], error] {
+	return func(yield func(Entry[KT, VT], error) bool) {
+		var zero Entry[KT, VT]
+		if err := db.closedErr(); err != nil {
+			yield(zero, err)
+			return
+		}
+		if !db.metaMode {
+			yield(zero, ErrNoMetadata)
+			return
+		}
+
+		for kb, vb := range db.RawItems() {
+			meta, err := parseMeta(vb)
+			if err != nil {
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if meta.Updated.Before(t) {
+				continue
+			}
+
+			var entry Entry[KT, VT]
+			if entry.Key, err = db.keyCodec.Decode(kb); err == nil {
+				entry.Value, err = db.valCodec.Decode(vb)
+			}
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
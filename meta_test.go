@@ -0,0 +1,93 @@
+package katalis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openMetaDB(t *testing.T) katalis.DB[string, string] {
+	t.Helper()
+
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec,
+		katalis.WithMetadata())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestGetMeta(t *testing.T) {
+	db := openMetaDB(t)
+
+	before := time.Now()
+	require.NoError(t, db.Put("name", "ada"))
+
+	meta, err := db.GetMeta("name")
+	require.NoError(t, err)
+	assert.WithinRange(t, meta.Created, before, time.Now())
+	assert.Equal(t, meta.Created, meta.Updated)
+
+	// Values round-trip unchanged through the envelope.
+	val, err := db.Get("name")
+	require.NoError(t, err)
+	assert.Equal(t, "ada", val)
+}
+
+func TestMetaCreatedSurvivesOverwrite(t *testing.T) {
+	db := openMetaDB(t)
+
+	require.NoError(t, db.Put("name", "ada"))
+	first, err := db.GetMeta("name")
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, db.Put("name", "grace"))
+
+	second, err := db.GetMeta("name")
+	require.NoError(t, err)
+	assert.Equal(t, first.Created, second.Created)
+	assert.True(t, second.Updated.After(first.Updated))
+}
+
+func TestGetMetaMissingKey(t *testing.T) {
+	db := openMetaDB(t)
+
+	_, err := db.GetMeta("missing")
+	assert.ErrorIs(t, err, katalis.ErrNotFound)
+}
+
+func TestGetMetaWithoutEnvelopes(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.GetMeta("k")
+	assert.ErrorIs(t, err, katalis.ErrNoMetadata)
+}
+
+func TestItemsUpdatedSince(t *testing.T) {
+	db := openMetaDB(t)
+
+	require.NoError(t, db.Put("old", "1"))
+	time.Sleep(10 * time.Millisecond)
+	cutoff := time.Now()
+	require.NoError(t, db.Put("new", "2"))
+
+	got := make(map[string]string)
+	for entry, err := range db.ItemsUpdatedSince(cutoff) {
+		require.NoError(t, err)
+		got[entry.Key] = entry.Value
+	}
+	assert.Equal(t, map[string]string{"new": "2"}, got)
+
+	// The zero time matches every record.
+	var n int
+	for _, err := range db.ItemsUpdatedSince(time.Time{}) {
+		require.NoError(t, err)
+		n++
+	}
+	assert.Equal(t, 2, n)
+}
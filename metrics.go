@@ -0,0 +1,79 @@
+package katalis
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// OpStats aggregates the calls of one codec direction: how many ran, how many
+// bytes they processed and how long they took. All methods are safe for
+// concurrent use.
+type OpStats struct {
+	calls atomic.Uint64
+	bytes atomic.Uint64
+	nanos atomic.Uint64
+}
+
+// Calls returns how many times the operation ran.
+func (s *OpStats) Calls() uint64 { return s.calls.Load() }
+
+// Bytes returns the total encoded bytes processed: output size for encodes,
+// input size for decodes.
+func (s *OpStats) Bytes() uint64 { return s.bytes.Load() }
+
+// Duration returns the total time spent in the codec.
+func (s *OpStats) Duration() time.Duration { return time.Duration(s.nanos.Load()) }
+
+func (s *OpStats) record(n int, d time.Duration) {
+	s.calls.Add(1)
+	s.bytes.Add(uint64(n))
+	s.nanos.Add(uint64(d))
+}
+
+// CodecStats breaks codec cost down per direction and per side, separately
+// from storage IO, so switching codec becomes a data-driven decision.
+type CodecStats struct {
+	KeyEncode   OpStats
+	KeyDecode   OpStats
+	ValueEncode OpStats
+	ValueDecode OpStats
+}
+
+// Instrument returns a DB equivalent to db whose codec calls are recorded in
+// the returned CodecStats. The original db stays usable and unmetered; both
+// handles share the same underlying store.
+func Instrument[KT, VT any](db DB[KT, VT]) (DB[KT, VT], *CodecStats) {
+	stats := new(CodecStats)
+	db.keyCodec = meteredCodec[KT]{
+		inner: db.keyCodec,
+		enc:   &stats.KeyEncode,
+		dec:   &stats.KeyDecode,
+	}
+	db.valCodec = meteredCodec[VT]{
+		inner: db.valCodec,
+		enc:   &stats.ValueEncode,
+		dec:   &stats.ValueDecode,
+	}
+	return db, stats
+}
+
+// meteredCodec wraps a codec recording every call in the linked OpStats.
+type meteredCodec[T any] struct {
+	inner Codec[T]
+	enc   *OpStats
+	dec   *OpStats
+}
+
+func (c meteredCodec[T]) Encode(t T) ([]byte, error) {
+	start := time.Now()
+	b, err := c.inner.Encode(t)
+	c.enc.record(len(b), time.Since(start))
+	return b, err
+}
+
+func (c meteredCodec[T]) Decode(b []byte) (T, error) {
+	start := time.Now()
+	t, err := c.inner.Decode(b)
+	c.dec.record(len(b), time.Since(start))
+	return t, err
+}
@@ -0,0 +1,49 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrument(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	metered, stats := katalis.Instrument(db)
+
+	require.NoError(t, metered.Put("key", "value"))
+
+	val, err := metered.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+
+	// Put encodes key and value, Get encodes the key and decodes the value.
+	assert.Equal(t, uint64(2), stats.KeyEncode.Calls())
+	assert.Equal(t, uint64(1), stats.ValueEncode.Calls())
+	assert.Equal(t, uint64(1), stats.ValueDecode.Calls())
+	assert.Zero(t, stats.KeyDecode.Calls())
+
+	assert.Equal(t, uint64(2*len("key")), stats.KeyEncode.Bytes())
+	assert.Equal(t, uint64(len("value")), stats.ValueEncode.Bytes())
+	assert.Equal(t, uint64(len("value")), stats.ValueDecode.Bytes())
+}
+
+func TestInstrumentLeavesOriginalUnmetered(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	metered, stats := katalis.Instrument(db)
+
+	require.NoError(t, db.Put("key", "value"))
+	assert.Zero(t, stats.KeyEncode.Calls())
+
+	// Both handles share the same store.
+	val, err := metered.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+}
@@ -0,0 +1,37 @@
+package katalis
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// Codecs for net/netip types in their canonical binary form: 4 or 16 bytes
+// for an address, plus a trailing prefix-length byte for a prefix. The binary
+// form keeps keys compact compared to their string representation.
+var (
+	AddrCodec   = addrCodec{}
+	PrefixCodec = prefixCodec{}
+)
+
+type addrCodec struct{}
+
+func (ac addrCodec) Encode(a netip.Addr) ([]byte, error) {
+	return a.MarshalBinary()
+}
+
+func (ac addrCodec) Decode(b []byte) (a netip.Addr, err error) {
+	return a, a.UnmarshalBinary(b)
+}
+
+type prefixCodec struct{}
+
+func (pc prefixCodec) Encode(p netip.Prefix) ([]byte, error) {
+	return p.MarshalBinary()
+}
+
+func (pc prefixCodec) Decode(b []byte) (p netip.Prefix, err error) {
+	if err := p.UnmarshalBinary(b); err != nil {
+		return p, fmt.Errorf("katalis: invalid prefix encoding: %w", err)
+	}
+	return p, nil
+}
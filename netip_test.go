@@ -0,0 +1,63 @@
+package katalis_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddrCodec(t *testing.T) {
+	tests := []struct {
+		name  string
+		input netip.Addr
+		size  int
+	}{
+		{"ipv4", netip.MustParseAddr("203.0.113.9"), 4},
+		{"ipv6", netip.MustParseAddr("2001:db8::1"), 16},
+		{"loopback", netip.MustParseAddr("::1"), 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := katalis.AddrCodec.Encode(tt.input)
+			require.NoError(t, err)
+			assert.Len(t, encoded, tt.size)
+
+			decoded, err := katalis.AddrCodec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+}
+
+func TestPrefixCodec(t *testing.T) {
+	tests := []struct {
+		name  string
+		input netip.Prefix
+	}{
+		{"ipv4", netip.MustParsePrefix("10.0.0.0/8")},
+		{"ipv6", netip.MustParsePrefix("2001:db8::/32")},
+		{"host", netip.MustParsePrefix("192.0.2.1/32")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := katalis.PrefixCodec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := katalis.PrefixCodec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+}
+
+func TestPrefixCodecInvalid(t *testing.T) {
+	_, err := katalis.PrefixCodec.Decode([]byte{1, 2, 3, 4, 5, 6})
+	assert.Error(t, err)
+}
@@ -0,0 +1,20 @@
+package katalis
+
+// Numeric returns a codec for any defined type whose underlying type is
+// int64, e.g. time.Duration, encoded as big-endian 8 bytes. The optional
+// variadic parameter allows type inference from a value.
+func Numeric[T ~int64](_ ...T) (n NumericCodec[T]) { return }
+
+// NumericCodec is the generic codec returned by Numeric.
+type NumericCodec[T ~int64] struct{}
+
+// Encode serializes the value as a big-endian int64.
+func (nc NumericCodec[T]) Encode(t T) ([]byte, error) {
+	return Int64Codec.Encode(int64(t))
+}
+
+// Decode deserializes the value from a big-endian int64.
+func (nc NumericCodec[T]) Decode(b []byte) (T, error) {
+	i, err := Int64Codec.Decode(b)
+	return T(i), err
+}
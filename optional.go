@@ -0,0 +1,47 @@
+package katalis
+
+import "fmt"
+
+// Optional wraps an inner codec into a codec for *T that distinguishes nil
+// from the zero value on disk. A one-byte presence marker precedes the inner
+// encoding, so a stored nil decodes back to nil rather than to T's zero value.
+func Optional[T any](inner Codec[T]) OptionalCodec[T] {
+	return OptionalCodec[T]{inner: inner}
+}
+
+// OptionalCodec is the codec returned by Optional.
+type OptionalCodec[T any] struct {
+	inner Codec[T]
+}
+
+// Encode serializes the value with a presence marker, delegating to the inner
+// codec when the pointer is non-nil.
+func (oc OptionalCodec[T]) Encode(t *T) ([]byte, error) {
+	if t == nil {
+		return []byte{0}, nil
+	}
+
+	b, err := oc.inner.Encode(*t)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{1}, b...), nil
+}
+
+// Decode deserializes the value, returning nil if the presence marker
+// indicates a stored nil.
+func (oc OptionalCodec[T]) Decode(b []byte) (*T, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("katalis: missing optional presence marker")
+	}
+
+	if b[0] == 0 {
+		return nil, nil
+	}
+
+	t, err := oc.inner.Decode(b[1:])
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
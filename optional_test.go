@@ -0,0 +1,56 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionalCodec(t *testing.T) {
+	codec := katalis.Optional[int](katalis.IntCodec)
+
+	tests := []struct {
+		name  string
+		input *int
+	}{
+		{"nil", nil},
+		{"zero", new(int)},
+		{"value", ptr(42)},
+		{"negative", ptr(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := codec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := codec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+}
+
+func TestOptionalCodecNilVsZero(t *testing.T) {
+	codec := katalis.Optional[string](katalis.StringCodec)
+
+	nilEnc, err := codec.Encode(nil)
+	require.NoError(t, err)
+
+	zeroEnc, err := codec.Encode(ptr(""))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, nilEnc, zeroEnc)
+}
+
+func TestOptionalCodecEmptyInput(t *testing.T) {
+	codec := katalis.Optional[int](katalis.IntCodec)
+
+	_, err := codec.Decode(nil)
+	assert.Error(t, err)
+}
+
+func ptr[T any](t T) *T { return &t }
@@ -0,0 +1,201 @@
+package katalis
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"sync"
+)
+
+// OrderedDB wraps a DB with an opt-in insertion-order log: every key is
+// recorded the first time it is written through the wrapper, so
+// ItemsInInsertionOrder replays the records deterministically even though
+// pogreb's hash layout randomizes plain iteration. A key keeps its original
+// position across overwrites and across delete-then-rewrite.
+//
+// Writes performed on the underlying DB directly bypass the log and never
+// show up in the ordered iteration.
+type OrderedDB[KT, VT any] struct {
+	DB[KT, VT]
+
+	mu    sync.Mutex
+	f     *os.File
+	order [][]byte        // encoded keys, first-write order
+	seen  map[string]bool // keys already in order
+}
+
+// Ordered wraps the DB with an insertion-order log kept in an append-only
+// file next to the store directory. Reopening the DB resumes the log where
+// it left off.
+func Ordered[KT, VT any](db DB[KT, VT]) (*OrderedDB[KT, VT], error) {
+	path := orderPath(db.path)
+
+	order, seen, err := readOrder(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderedDB[KT, VT]{DB: db, f: f, order: order, seen: seen}, nil
+}
+
+// Put applies the write to the DB, recording the key in the insertion-order
+// log the first time it is seen.
+func (o *OrderedDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := o.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := o.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.seen[string(kb)] {
+		if _, err := o.f.Write(appendFrame(nil, kb)); err != nil {
+			return err
+		}
+		o.order = append(o.order, kb)
+		o.seen[string(kb)] = true
+	}
+	return o.DB.rawPut(kb, vb)
+}
+
+// ItemsInInsertionOrder returns an iterator over the records in the order
+// their keys were first written through the wrapper. Keys deleted since are
+// skipped. Errors terminate the iteration silently.
+func (o *OrderedDB[KT, VT]) ItemsInInsertionOrder() iter.Seq2[KT, VT] {
+	o.mu.Lock()
+	order := make([][]byte, len(o.order))
+	copy(order, o.order)
+	o.mu.Unlock()
+
+	return func(yield func(KT, VT) bool) {
+		for _, kb := range order {
+			vb, err := o.DB.DB.Get(kb)
+			if err != nil {
+				return
+			}
+			if vb == nil {
+				continue
+			}
+
+			key, err := o.keyCodec.Decode(kb)
+			if err != nil {
+				return
+			}
+			val, err := o.valCodec.Decode(vb)
+			if err != nil {
+				return
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+	}
+}
+
+// CompactOrder rewrites the insertion-order log dropping keys no longer in
+// the store, bounding its disk usage after heavy churn. Relative order of
+// the surviving keys is preserved.
+func (o *OrderedDB[KT, VT]) CompactOrder() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var (
+		kept [][]byte
+		buf  []byte
+	)
+	for _, kb := range o.order {
+		has, err := o.DB.DB.Has(kb)
+		if err != nil {
+			return err
+		}
+		if !has {
+			delete(o.seen, string(kb))
+			continue
+		}
+		kept = append(kept, kb)
+		buf = appendFrame(buf, kb)
+	}
+
+	path := orderPath(o.path)
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, buf); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	// Reopen the append handle on the rewritten file.
+	if err := o.f.Close(); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	o.f = f
+	o.order = kept
+	return nil
+}
+
+// Close closes the insertion-order log and the underlying DB.
+func (o *OrderedDB[KT, VT]) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.f.Close(); err != nil {
+		o.DB.Close()
+		return err
+	}
+	return o.DB.Close()
+}
+
+// orderPath returns the location of the insertion-order log, kept next to
+// the store directory like the change journal.
+func orderPath(path string) string {
+	return path + ".order"
+}
+
+// readOrder loads the insertion-order log. A missing log yields an empty
+// order.
+func readOrder(path string) (order [][]byte, seen map[string]bool, err error) {
+	seen = make(map[string]bool)
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, seen, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		kb, err := readStreamFrame(r)
+		if errors.Is(err, io.EOF) {
+			return order, seen, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !seen[string(kb)] {
+			order = append(order, kb)
+			seen[string(kb)] = true
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectOrdered[KT, VT any](o *katalis.OrderedDB[KT, VT]) (keys []KT, vals []VT) {
+	for k, v := range o.ItemsInInsertionOrder() {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	}
+	return keys, vals
+}
+
+func TestItemsInInsertionOrder(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	o, err := katalis.Ordered(db)
+	require.NoError(t, err)
+	defer o.Close()
+
+	require.NoError(t, o.Put("c", 3))
+	require.NoError(t, o.Put("a", 1))
+	require.NoError(t, o.Put("b", 2))
+
+	keys, vals := collectOrdered(o)
+	assert.Equal(t, []string{"c", "a", "b"}, keys)
+	assert.Equal(t, []int{3, 1, 2}, vals)
+}
+
+func TestInsertionOrderStableAcrossOverwrites(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	o, err := katalis.Ordered(db)
+	require.NoError(t, err)
+	defer o.Close()
+
+	require.NoError(t, o.Put("a", 1))
+	require.NoError(t, o.Put("b", 2))
+	require.NoError(t, o.Put("a", 10))
+
+	keys, vals := collectOrdered(o)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.Equal(t, []int{10, 2}, vals)
+}
+
+func TestInsertionOrderSkipsDeleted(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+
+	o, err := katalis.Ordered(db)
+	require.NoError(t, err)
+	defer o.Close()
+
+	require.NoError(t, o.Put("a", "one"))
+	require.NoError(t, o.Put("b", "two"))
+	require.NoError(t, o.Put("c", "three"))
+	require.NoError(t, o.Del("b"))
+
+	keys, _ := collectOrdered(o)
+	assert.Equal(t, []string{"a", "c"}, keys)
+}
+
+func TestInsertionOrderSurvivesReopen(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	o, err := katalis.Ordered(db)
+	require.NoError(t, err)
+
+	require.NoError(t, o.Put("z", 26))
+	require.NoError(t, o.Put("m", 13))
+	require.NoError(t, o.Close())
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	o, err = katalis.Ordered(db)
+	require.NoError(t, err)
+	defer o.Close()
+
+	require.NoError(t, o.Put("a", 1))
+
+	keys, _ := collectOrdered(o)
+	assert.Equal(t, []string{"z", "m", "a"}, keys)
+}
+
+func TestCompactOrder(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	o, err := katalis.Ordered(db)
+	require.NoError(t, err)
+	defer o.Close()
+
+	require.NoError(t, o.Put("a", 1))
+	require.NoError(t, o.Put("b", 2))
+	require.NoError(t, o.Put("c", 3))
+	require.NoError(t, o.Del("b"))
+	require.NoError(t, o.CompactOrder())
+
+	keys, _ := collectOrdered(o)
+	assert.Equal(t, []string{"a", "c"}, keys)
+
+	// A compacted key rewritten later goes to the back of the order.
+	require.NoError(t, o.Put("b", 20))
+	keys, _ = collectOrdered(o)
+	assert.Equal(t, []string{"a", "c", "b"}, keys)
+}
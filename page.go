@@ -0,0 +1,76 @@
+package katalis
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+)
+
+// Cursor is an opaque resumption token returned by Page. The zero Cursor
+// starts from the beginning; a token returned by Page resumes right after the
+// last entry of that page, even from a different process. Tokens stay valid
+// as long as the record they point at is not deleted or compacted away.
+type Cursor string
+
+// Page returns up to limit entries starting after cursor, together with the
+// cursor for the next page. The returned cursor is empty once the store is
+// exhausted, so HTTP APIs can expose it directly as a next-page token.
+func (db DB[KT, VT]) Page(cursor Cursor, limit int) ([]Entry[KT, VT], Cursor, error) {
+	if err := db.closedErr(); err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("katalis: page limit must be positive, got %d", limit)
+	}
+
+	var after []byte
+	if cursor != "" {
+		b, err := hex.DecodeString(string(cursor))
+		if err != nil {
+			return nil, "", fmt.Errorf("katalis: malformed page cursor: %w", err)
+		}
+		after = b
+	}
+
+	var (
+		entries  []Entry[KT, VT]
+		skipping = after != nil
+		it       = db.DB.Items()
+	)
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			if skipping {
+				return nil, "", fmt.Errorf("katalis: page cursor points to a record that no longer exists")
+			}
+			return entries, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if !db.inView(kb) {
+			continue
+		}
+
+		// Resume by walking up to and past the record the cursor points at.
+		if skipping {
+			if bytes.Equal(kb, after) {
+				skipping = false
+			}
+			continue
+		}
+
+		var entry Entry[KT, VT]
+		if entry.Key, err = db.keyCodec.Decode(kb); err != nil {
+			return nil, "", opErr("page", fmt.Sprintf("%x", kb), KindDecodeKey, err)
+		}
+		if entry.Value, err = db.valCodec.Decode(vb); err != nil {
+			return nil, "", opErr("page", entry.Key, KindDecodeValue, err)
+		}
+
+		entries = append(entries, entry)
+		if len(entries) == limit {
+			return entries, Cursor(hex.EncodeToString(kb)), nil
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 25 {
+		require.NoError(t, db.Put(i, "value"))
+	}
+
+	got := make(map[int]string)
+	var (
+		cursor katalis.Cursor
+		pages  int
+	)
+	for {
+		entries, next, err := db.Page(cursor, 10)
+		require.NoError(t, err)
+
+		for _, e := range entries {
+			got[e.Key] = e.Value
+		}
+		pages++
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	assert.Len(t, got, 25)
+	assert.Equal(t, 3, pages)
+}
+
+func TestPageCursorSurvivesReopen(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	for i := range 10 {
+		require.NoError(t, db.Put(i, "value"))
+	}
+
+	first, cursor, err := db.Page("", 4)
+	require.NoError(t, err)
+	require.Len(t, first, 4)
+	require.NotEmpty(t, cursor)
+	require.NoError(t, db.Close())
+
+	db, err = katalis.Open(path, katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	rest := make(map[int]bool)
+	for {
+		entries, next, err := db.Page(cursor, 4)
+		require.NoError(t, err)
+		for _, e := range entries {
+			rest[e.Key] = true
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	assert.Len(t, rest, 6)
+	for _, e := range first {
+		assert.False(t, rest[e.Key], "entry %d returned twice", e.Key)
+	}
+}
+
+func TestPageErrors(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put(1, "value"))
+
+	_, _, err = db.Page("", 0)
+	assert.ErrorContains(t, err, "limit")
+
+	_, _, err = db.Page("not-hex", 10)
+	assert.ErrorContains(t, err, "malformed")
+
+	// A syntactically valid cursor pointing at a record that never existed.
+	_, _, err = db.Page("beef", 10)
+	assert.ErrorContains(t, err, "no longer exists")
+}
@@ -0,0 +1,47 @@
+package katalis
+
+import "fmt"
+
+// IterationPanicError is the value Fold, Items and AllItems re-panic with
+// when a user callback panics mid-iteration. The iterator unwinds cleanly
+// first, and the new panic names the record being processed, which a bare
+// index-out-of-range never does.
+type IterationPanicError struct {
+	// Key is the decoded key the callback was handling.
+	Key any
+	// Value is the original panic value.
+	Value any
+}
+
+func (e *IterationPanicError) Error() string {
+	return fmt.Sprintf("katalis: iteration callback panicked on key %v: %v", e.Key, e.Value)
+}
+
+// callFold invokes a Fold callback, re-panicking callback panics wrapped
+// with the current key.
+func callFold[KT, VT any](fn func(KT, VT, error) error, key KT, val VT, ferr error) error {
+	defer repanic(key)
+	return fn(key, val, ferr)
+}
+
+// callYield invokes an iterator yield, re-panicking callback panics wrapped
+// with the current key.
+func callYield[KT, VT any](yield func(KT, VT) bool, key KT, val VT) bool {
+	defer repanic(key)
+	return yield(key, val)
+}
+
+// callYieldEntry invokes an AllItems yield, re-panicking callback panics
+// wrapped with the entry's key.
+func callYieldEntry[KT, VT any](yield func(Entry[KT, VT], error) bool, entry Entry[KT, VT], err error) bool {
+	defer repanic(entry.Key)
+	return yield(entry, err)
+}
+
+// repanic rethrows a recovered panic as an IterationPanicError carrying the
+// key. It must be invoked through defer.
+func repanic(key any) {
+	if p := recover(); p != nil {
+		panic(&IterationPanicError{Key: key, Value: p})
+	}
+}
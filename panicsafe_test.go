@@ -0,0 +1,86 @@
+package katalis_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recoverIterationPanic runs fn and returns the IterationPanicError it
+// panicked with.
+func recoverIterationPanic(t *testing.T, fn func()) (pe *katalis.IterationPanicError) {
+	t.Helper()
+	defer func() {
+		p := recover()
+		if p == nil {
+			t.Fatal("expected a panic")
+		}
+		var ok bool
+		if pe, ok = p.(*katalis.IterationPanicError); !ok {
+			t.Fatalf("panic is not an IterationPanicError: %v", p)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestFoldRepanicsWithKey(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("boom", 1))
+
+	pe := recoverIterationPanic(t, func() {
+		db.Fold(func(key string, val int, err error) error {
+			panic("kaboom")
+		})
+	})
+	assert.Equal(t, "boom", pe.Key)
+	assert.Equal(t, "kaboom", pe.Value)
+	assert.Contains(t, pe.Error(), "boom")
+	assert.Contains(t, pe.Error(), "kaboom")
+
+	// The store is still usable after the panic unwound.
+	v, err := db.Get("boom")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	require.NoError(t, db.Put("after", 2))
+}
+
+func TestItemsRepanicsWithKey(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("only", 1))
+
+	pe := recoverIterationPanic(t, func() {
+		for range db.Items() {
+			panic(errors.New("loop body blew up"))
+		}
+	})
+	assert.Equal(t, "only", pe.Key)
+
+	has, err := db.Has("only")
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestAllItemsRepanicsWithKey(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("only", 1))
+
+	pe := recoverIterationPanic(t, func() {
+		for range db.AllItems() {
+			panic("loop body blew up")
+		}
+	})
+	assert.Equal(t, "only", pe.Key)
+}
@@ -0,0 +1,95 @@
+package katalis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotPatchable is returned by Patch and UpdateField when the stored value
+// does not expose fields, e.g. a plain int or string.
+var ErrNotPatchable = errors.New("katalis: value does not expose fields")
+
+// Patch applies a partial update to the value stored under key: the value is
+// decoded, the patched fields replaced, and the result re-encoded, all while
+// holding the key's advisory lock so concurrent patches of different fields
+// never lose each other's writes.
+//
+// Fields are addressed by their encoding/json names, so the value must be a
+// struct or a map; patching anything else returns ErrNotPatchable. Values
+// that are themselves maps merge recursively, a nil patch value removes the
+// field (struct fields revert to their zero value), anything else replaces
+// it wholesale.
+func (db DB[KT, VT]) Patch(key KT, patch map[string]any) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	unlock, err := db.Lock(key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	val, err := db.Get(key)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Errorf("katalis: patch: %w", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return ErrNotPatchable
+	}
+	applyPatch(fields, patch)
+
+	if b, err = json.Marshal(fields); err != nil {
+		return fmt.Errorf("katalis: patch: %w", err)
+	}
+	var out VT
+	if err := json.Unmarshal(b, &out); err != nil {
+		return fmt.Errorf("katalis: patch: %w", err)
+	}
+	return db.Put(key, out)
+}
+
+// UpdateField sets a single field of the value stored under key, addressed
+// by a dot-separated path, e.g. "address.city". It is Patch for the common
+// one-field case.
+func (db DB[KT, VT]) UpdateField(key KT, path string, value any) error {
+	patch := map[string]any{}
+
+	leaf := patch
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next := map[string]any{}
+		leaf[part] = next
+		leaf = next
+	}
+	leaf[parts[len(parts)-1]] = value
+
+	return db.Patch(key, patch)
+}
+
+// applyPatch merges patch into dst: nested maps merge recursively, nil
+// removes, everything else replaces.
+func applyPatch(dst, patch map[string]any) {
+	for k, v := range patch {
+		if vm, ok := v.(map[string]any); ok {
+			if dm, ok := dst[k].(map[string]any); ok {
+				applyPatch(dm, vm)
+				continue
+			}
+		}
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+		dst[k] = v
+	}
+}
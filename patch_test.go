@@ -0,0 +1,114 @@
+package katalis_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type account struct {
+	Name    string  `json:"name"`
+	Balance float64 `json:"balance"`
+	Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	} `json:"address"`
+}
+
+func TestPatchStruct(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.Gob[account]())
+	require.NoError(t, err)
+	defer db.Close()
+
+	var acc account
+	acc.Name = "ada"
+	acc.Balance = 10
+	acc.Address.City = "Turin"
+	acc.Address.Zip = "10100"
+	require.NoError(t, db.Put("acc", acc))
+
+	require.NoError(t, db.Patch("acc", map[string]any{
+		"balance": 25.5,
+		"address": map[string]any{"city": "Rome"},
+	}))
+
+	got, err := db.Get("acc")
+	require.NoError(t, err)
+	assert.Equal(t, "ada", got.Name)
+	assert.Equal(t, 25.5, got.Balance)
+	assert.Equal(t, "Rome", got.Address.City)
+	assert.Equal(t, "10100", got.Address.Zip)
+}
+
+func TestPatchMapValue(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.Gob[map[string]any]())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("cfg", map[string]any{"debug": false, "level": "info"}))
+
+	require.NoError(t, db.Patch("cfg", map[string]any{"debug": true, "level": nil}))
+
+	got, err := db.Get("cfg")
+	require.NoError(t, err)
+	assert.Equal(t, true, got["debug"])
+	assert.NotContains(t, got, "level")
+}
+
+func TestUpdateField(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.Gob[account]())
+	require.NoError(t, err)
+	defer db.Close()
+
+	var acc account
+	acc.Name = "ada"
+	acc.Address.City = "Turin"
+	require.NoError(t, db.Put("acc", acc))
+
+	require.NoError(t, db.UpdateField("acc", "address.city", "Milan"))
+
+	got, err := db.Get("acc")
+	require.NoError(t, err)
+	assert.Equal(t, "Milan", got.Address.City)
+	assert.Equal(t, "ada", got.Name)
+}
+
+func TestPatchNotPatchable(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("n", 42))
+	assert.ErrorIs(t, db.Patch("n", map[string]any{"x": 1}), katalis.ErrNotPatchable)
+}
+
+func TestPatchConcurrentFields(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.Gob[account]())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("acc", account{Name: "ada", Balance: 1}))
+
+	// Concurrent single-field patches must not lose each other's writes.
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, db.UpdateField("acc", "name", "grace"))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, db.UpdateField("acc", "balance", 2.0))
+		}()
+	}
+	wg.Wait()
+
+	got, err := db.Get("acc")
+	require.NoError(t, err)
+	assert.Equal(t, "grace", got.Name)
+	assert.Equal(t, 2.0, got.Balance)
+}
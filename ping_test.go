@@ -0,0 +1,76 @@
+package katalis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPing(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Ping(context.Background()))
+
+	// The probe record does not linger in the store.
+	records, err := db.Records()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, records)
+
+	require.NoError(t, db.Put("key", 1))
+	require.NoError(t, db.Ping(context.Background()))
+	records, err = db.Records()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, records)
+}
+
+func TestPingCancelled(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, db.Ping(ctx), context.Canceled)
+}
+
+func TestPingClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	assert.ErrorIs(t, db.Ping(context.Background()), katalis.ErrClosed)
+}
+
+func TestPingDegraded(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for range 3 {
+		require.Error(t, db.Put(hugeKey, 1))
+	}
+	require.Equal(t, katalis.DegradedReadOnly, db.Health().State)
+
+	// A degraded store is still readable, so the probe passes without
+	// attempting a write.
+	assert.NoError(t, db.Ping(context.Background()))
+	assert.Equal(t, katalis.DegradedReadOnly, db.Health().State)
+}
+
+func TestPingTenant(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	view := db.Tenant("acme")
+	require.NoError(t, view.Ping(context.Background()))
+
+	records, err := view.Records()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, records)
+}
@@ -0,0 +1,47 @@
+package katalis
+
+import "sync"
+
+// EncoderTo is an optional interface codecs can implement to append the
+// encoding of v to dst instead of returning a fresh slice per call. The hot
+// Put/Get/Del/Has paths detect it and encode into pooled scratch buffers,
+// cutting one allocation per codec call. All the fixed-width builtin codecs
+// plus StringCodec and BytesCodec implement it.
+type EncoderTo[T any] interface {
+	EncodeTo(dst []byte, v T) ([]byte, error)
+}
+
+// maxPooledBuf keeps oversized scratch buffers out of the pool so one huge
+// value doesn't pin memory forever.
+const maxPooledBuf = 1 << 16
+
+// bufPool recycles the scratch buffers used by encodeInto.
+var bufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 512)
+		return &b
+	},
+}
+
+func getBuf() *[]byte { return bufPool.Get().(*[]byte) }
+
+func putBuf(b *[]byte) {
+	if cap(*b) <= maxPooledBuf {
+		bufPool.Put(b)
+	}
+}
+
+// encodeInto encodes v with c, appending into *buf when c implements
+// EncoderTo and falling back to a plain Encode otherwise. The returned slice
+// is only valid until *buf goes back to the pool.
+func encodeInto[T any](c Codec[T], buf *[]byte, v T) ([]byte, error) {
+	if e, ok := c.(EncoderTo[T]); ok {
+		b, err := e.EncodeTo((*buf)[:0], v)
+		if err == nil {
+			// Keep any growth so the pool hands back the bigger buffer.
+			*buf = b
+		}
+		return b, err
+	}
+	return c.Encode(v)
+}
@@ -0,0 +1,62 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeToMatchesEncode(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		testEncodeTo[int](t, katalis.IntCodec, -42)
+	})
+	t.Run("uint64", func(t *testing.T) {
+		testEncodeTo[uint64](t, katalis.Uint64Codec, 1<<40)
+	})
+	t.Run("float64", func(t *testing.T) {
+		testEncodeTo[float64](t, katalis.Float64Codec, 3.14)
+	})
+	t.Run("string", func(t *testing.T) {
+		testEncodeTo[string](t, katalis.StringCodec, "hello")
+	})
+	t.Run("bytes", func(t *testing.T) {
+		testEncodeTo[[]byte](t, katalis.BytesCodec, []byte{1, 2, 3})
+	})
+}
+
+// testEncodeTo checks that the codec implements EncoderTo, that EncodeTo
+// produces the same bytes as Encode, and that it appends to dst instead of
+// overwriting it.
+func testEncodeTo[T any](t *testing.T, codec katalis.Codec[T], sample T) {
+	enc, ok := codec.(katalis.EncoderTo[T])
+	require.True(t, ok, "codec does not implement EncoderTo")
+
+	want, err := codec.Encode(sample)
+	require.NoError(t, err)
+
+	got, err := enc.EncodeTo(nil, sample)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	prefixed, err := enc.EncodeTo([]byte("prefix"), sample)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte("prefix"), want...), prefixed)
+}
+
+func TestPooledPutGetRoundTrip(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Hammer the pooled encode path from the public API.
+	for i := range 100 {
+		require.NoError(t, db.Put(string(rune('a'+i%26)), i))
+	}
+	for i := 74; i < 100; i++ {
+		val, err := db.Get(string(rune('a' + i%26)))
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
@@ -0,0 +1,290 @@
+package katalis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// viewPrefix is the reserved raw-key prefix of the projection records.
+var viewPrefix = []byte("\x00katalis.view\x00")
+
+// ProjectionFunc derives a view entry from a record. Returning false
+// excludes the record from the view.
+type ProjectionFunc[KT, VT any] func(key KT, val VT) (string, bool)
+
+// ProjectedDB wraps a DB with materialized views: named projection functions
+// maintained incrementally on every write, generalizing secondary indexes to
+// arbitrary derived data. Each view entry is stored under a composite key in
+// the same store, so Lookup resolves derived values without touching the
+// records themselves.
+//
+// The views live under reserved key prefixes, so the wrapper assumes it owns
+// its keys: writing records whose encoded keys start with a NUL byte leads
+// to undefined behavior. Records must be written through the wrapper for the
+// views to stay fresh; use Rebuild after touching the store directly or
+// after registering a projection over existing data.
+type ProjectedDB[KT, VT any] struct {
+	db    DB[KT, VT]
+	mu    sync.Mutex // serializes writes with the view maintenance
+	views map[string]ProjectionFunc[KT, VT]
+}
+
+// Projected wraps the DB with materialized view maintenance.
+func Projected[KT, VT any](db DB[KT, VT]) *ProjectedDB[KT, VT] {
+	return &ProjectedDB[KT, VT]{
+		db:    db,
+		views: make(map[string]ProjectionFunc[KT, VT]),
+	}
+}
+
+// Project registers the named projection. Writes from here on maintain the
+// view incrementally; call Rebuild to bootstrap it over data already in the
+// store.
+func (p *ProjectedDB[KT, VT]) Project(name string, fn ProjectionFunc[KT, VT]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.views[name] = fn
+}
+
+// Get returns the value for the given key stored in the DB, or an empty
+// value if the key doesn't exist.
+func (p *ProjectedDB[KT, VT]) Get(key KT) (VT, error) { return p.db.Get(key) }
+
+// Has returns true if the DB contains the given key.
+func (p *ProjectedDB[KT, VT]) Has(key KT) (bool, error) { return p.db.Has(key) }
+
+// Close closes the underlying DB.
+func (p *ProjectedDB[KT, VT]) Close() error { return p.db.Close() }
+
+// Put stores the value under key and updates every registered view: entries
+// derived from the previous value are dropped, entries derived from the new
+// one written.
+func (p *ProjectedDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := p.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	old, hadOld, err := p.lookup(key)
+	if err != nil {
+		return err
+	}
+	if err := p.db.Put(key, val); err != nil {
+		return err
+	}
+
+	for name, fn := range p.views {
+		if hadOld {
+			if d, ok := fn(key, old); ok {
+				if err := p.db.rawDel(viewEntryKey(name, d, kb)); err != nil {
+					return err
+				}
+			}
+		}
+		if d, ok := fn(key, val); ok {
+			if err := p.db.rawPut(viewEntryKey(name, d, kb), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Del deletes the record and its entries from every registered view.
+func (p *ProjectedDB[KT, VT]) Del(key KT) error {
+	kb, err := p.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	old, hadOld, err := p.lookup(key)
+	if err != nil {
+		return err
+	}
+	if err := p.db.Del(key); err != nil {
+		return err
+	}
+	if !hadOld {
+		return nil
+	}
+
+	for name, fn := range p.views {
+		if d, ok := fn(key, old); ok {
+			if err := p.db.rawDel(viewEntryKey(name, d, kb)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Lookup returns an iterator over the keys of the records the named view
+// maps to the given derived value, in encoded-key order.
+func (p *ProjectedDB[KT, VT]) Lookup(view, derived string) iter.Seq[KT] {
+	return func(yield func(KT) bool) {
+		prefix := viewEntryKey(view, derived, nil)
+
+		var kbs [][]byte
+		for kb := range p.db.RawItems() {
+			if bytes.HasPrefix(kb, prefix) {
+				kbs = append(kbs, slices.Clone(kb[len(prefix):]))
+			}
+		}
+
+		slices.SortFunc(kbs, bytes.Compare)
+		for _, kb := range kbs {
+			key, err := p.db.keyCodec.Decode(kb)
+			if err != nil {
+				continue
+			}
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// ViewItems returns an iterator over the named view's entries as derived
+// value and record key pairs, sorted by derived value.
+func (p *ProjectedDB[KT, VT]) ViewItems(view string) iter.Seq2[string, KT] {
+	return func(yield func(string, KT) bool) {
+		prefix := namePrefix(view)
+
+		type entry struct {
+			derived string
+			kb      []byte
+		}
+		var entries []entry
+		for kb := range p.db.RawItems() {
+			if d, rest, ok := cutViewKey(kb, prefix); ok {
+				entries = append(entries, entry{derived: d, kb: slices.Clone(rest)})
+			}
+		}
+
+		slices.SortFunc(entries, func(a, b entry) int {
+			if c := bytes.Compare([]byte(a.derived), []byte(b.derived)); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.kb, b.kb)
+		})
+		for _, e := range entries {
+			key, err := p.db.keyCodec.Decode(e.kb)
+			if err != nil {
+				continue
+			}
+			if !yield(e.derived, key) {
+				return
+			}
+		}
+	}
+}
+
+// Items returns an iterator over the records of the store, view entries
+// excluded. Decode errors are silently skipped, like in DB.Items.
+func (p *ProjectedDB[KT, VT]) Items() iter.Seq2[KT, VT] {
+	return func(yield func(KT, VT) bool) {
+		for kb, vb := range p.db.RawItems() {
+			if bytes.HasPrefix(kb, viewPrefix) {
+				continue
+			}
+			key, err := p.db.keyCodec.Decode(kb)
+			if err != nil {
+				continue
+			}
+			val, err := p.db.valCodec.Decode(vb)
+			if err != nil {
+				continue
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+	}
+}
+
+// Rebuild drops the named view and recomputes it from the records already in
+// the store, for bootstrapping a projection registered after the fact.
+func (p *ProjectedDB[KT, VT]) Rebuild(view string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fn, ok := p.views[view]
+	if !ok {
+		return fmt.Errorf("katalis: no projection registered as %q", view)
+	}
+
+	prefix := namePrefix(view)
+	var stale [][]byte
+	for kb := range p.db.RawItems() {
+		if bytes.HasPrefix(kb, prefix) {
+			stale = append(stale, slices.Clone(kb))
+		}
+	}
+	for _, kb := range stale {
+		if err := p.db.rawDel(kb); err != nil {
+			return err
+		}
+	}
+
+	for key, val := range p.Items() {
+		kb, err := p.db.keyCodec.Encode(key)
+		if err != nil {
+			return err
+		}
+		if d, ok := fn(key, val); ok {
+			if err := p.db.rawPut(viewEntryKey(view, d, kb), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookup returns the value currently stored under key, reporting whether the
+// key exists.
+func (p *ProjectedDB[KT, VT]) lookup(key KT) (val VT, ok bool, err error) {
+	if ok, err = p.db.Has(key); err != nil || !ok {
+		return val, false, err
+	}
+	val, err = p.db.Get(key)
+	return val, err == nil, err
+}
+
+// namePrefix returns the raw-key prefix shared by the named view's entries.
+func namePrefix(view string) []byte {
+	p := binary.AppendUvarint(slices.Clone(viewPrefix), uint64(len(view)))
+	return append(p, view...)
+}
+
+// viewEntryKey returns the raw key of the view entry mapping derived to the
+// encoded record key kb.
+func viewEntryKey(view, derived string, kb []byte) []byte {
+	k := binary.AppendUvarint(namePrefix(view), uint64(len(derived)))
+	k = append(k, derived...)
+	return append(k, kb...)
+}
+
+// cutViewKey parses a raw view-entry key into derived value and encoded
+// record key, reporting whether kb belongs to the view identified by prefix.
+func cutViewKey(kb, prefix []byte) (derived string, rest []byte, ok bool) {
+	if !bytes.HasPrefix(kb, prefix) {
+		return "", nil, false
+	}
+	rest = kb[len(prefix):]
+
+	n, read := binary.Uvarint(rest)
+	if read <= 0 || uint64(len(rest)-read) < n {
+		return "", nil, false
+	}
+	return string(rest[read : read+int(n)]), rest[read+int(n):], true
+}
@@ -0,0 +1,115 @@
+package katalis_test
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	Name  string
+	Email string
+	Admin bool
+}
+
+func newProjected(t *testing.T) *katalis.ProjectedDB[string, user] {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.Gob[user]())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return katalis.Projected(db)
+}
+
+func TestProjectLookup(t *testing.T) {
+	p := newProjected(t)
+	p.Project("domains", func(k string, u user) (string, bool) {
+		_, domain, ok := strings.Cut(u.Email, "@")
+		return domain, ok
+	})
+
+	require.NoError(t, p.Put("u1", user{Name: "Ada", Email: "ada@example.com"}))
+	require.NoError(t, p.Put("u2", user{Name: "Bob", Email: "bob@example.com"}))
+	require.NoError(t, p.Put("u3", user{Name: "Eve", Email: "eve@corp.io"}))
+
+	assert.Equal(t, []string{"u1", "u2"}, slices.Collect(p.Lookup("domains", "example.com")))
+	assert.Equal(t, []string{"u3"}, slices.Collect(p.Lookup("domains", "corp.io")))
+	assert.Empty(t, slices.Collect(p.Lookup("domains", "absent.net")))
+}
+
+func TestProjectFiltering(t *testing.T) {
+	p := newProjected(t)
+
+	// ok=false excludes the record from the view entirely.
+	p.Project("admins", func(k string, u user) (string, bool) {
+		return u.Name, u.Admin
+	})
+
+	require.NoError(t, p.Put("u1", user{Name: "Ada", Admin: true}))
+	require.NoError(t, p.Put("u2", user{Name: "Bob"}))
+
+	got := map[string]string{}
+	for name, key := range p.ViewItems("admins") {
+		got[name] = key
+	}
+	assert.Equal(t, map[string]string{"Ada": "u1"}, got)
+}
+
+func TestProjectIncrementalMaintenance(t *testing.T) {
+	p := newProjected(t)
+	p.Project("emails", func(k string, u user) (string, bool) {
+		return u.Email, u.Email != ""
+	})
+
+	require.NoError(t, p.Put("u1", user{Email: "old@example.com"}))
+	require.NoError(t, p.Put("u1", user{Email: "new@example.com"}))
+
+	// The entry derived from the previous value is gone.
+	assert.Empty(t, slices.Collect(p.Lookup("emails", "old@example.com")))
+	assert.Equal(t, []string{"u1"}, slices.Collect(p.Lookup("emails", "new@example.com")))
+
+	require.NoError(t, p.Del("u1"))
+	assert.Empty(t, slices.Collect(p.Lookup("emails", "new@example.com")))
+}
+
+func TestProjectRebuild(t *testing.T) {
+	p := newProjected(t)
+
+	// Data written before the projection existed.
+	require.NoError(t, p.Put("u1", user{Email: "ada@example.com"}))
+	require.NoError(t, p.Put("u2", user{Email: "bob@corp.io"}))
+
+	p.Project("emails", func(k string, u user) (string, bool) {
+		return u.Email, u.Email != ""
+	})
+	assert.Empty(t, slices.Collect(p.Lookup("emails", "ada@example.com")))
+
+	require.NoError(t, p.Rebuild("emails"))
+	assert.Equal(t, []string{"u1"}, slices.Collect(p.Lookup("emails", "ada@example.com")))
+	assert.Equal(t, []string{"u2"}, slices.Collect(p.Lookup("emails", "bob@corp.io")))
+
+	assert.Error(t, p.Rebuild("unregistered"))
+}
+
+func TestProjectItemsSkipViewEntries(t *testing.T) {
+	p := newProjected(t)
+	p.Project("emails", func(k string, u user) (string, bool) {
+		return u.Email, u.Email != ""
+	})
+
+	require.NoError(t, p.Put("u1", user{Name: "Ada", Email: "ada@example.com"}))
+	require.NoError(t, p.Put("u2", user{Name: "Bob", Email: "bob@corp.io"}))
+
+	names := map[string]string{}
+	for key, u := range p.Items() {
+		names[key] = u.Name
+	}
+	assert.Equal(t, map[string]string{"u1": "Ada", "u2": "Bob"}, names)
+}
@@ -0,0 +1,92 @@
+package katalis
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrQuotaExceeded is returned by writes that would push the store past the
+// limit set with WithMaxDiskUsage.
+var ErrQuotaExceeded = errors.New("katalis: disk usage quota exceeded")
+
+// WithMaxDiskUsage caps the estimated live data footprint of the store at n
+// bytes: once crossed, writes fail with ErrQuotaExceeded until records are
+// deleted — through Put and the byte-level paths alike, large values and
+// transactions included. The estimate counts live keys and values and
+// ignores space a compaction would reclaim, so deleting records frees quota
+// immediately. Meant for embedded devices that must never fill their flash
+// partition.
+func WithMaxDiskUsage(n int64) OpenOption {
+	return func(c *openConfig) { c.maxDiskUsage = n }
+}
+
+// quotaState tracks the live data footprint of a quota-limited DB.
+type quotaState struct {
+	limit int64
+	used  atomic.Int64
+}
+
+// initQuota walks the store once to seed the usage estimate.
+func (db *DB[KT, VT]) initQuota(limit int64) error {
+	db.quota = &quotaState{limit: limit}
+
+	var used int64
+	it := db.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		used += entrySize(kb, vb)
+	}
+	db.quota.used.Store(used)
+	return nil
+}
+
+// quotaDelta returns how much the live footprint grows if kb is written with
+// a value of len(vb) bytes, accounting for any record it overwrites.
+func (db DB[KT, VT]) quotaDelta(kb, vb []byte) int64 {
+	delta := entrySize(kb, vb)
+	if old, err := db.DB.Get(kb); err == nil && old != nil {
+		delta -= entrySize(kb, old)
+	}
+	return delta
+}
+
+// checkQuota validates a write of kb/vb against the quota, if one is set,
+// returning the footprint delta to commit with commitQuota once the write
+// lands.
+func (db DB[KT, VT]) checkQuota(key any, kb, vb []byte) (int64, error) {
+	if db.quota == nil {
+		return 0, nil
+	}
+
+	delta := db.quotaDelta(kb, vb)
+	if db.quota.used.Load()+delta > db.quota.limit {
+		return 0, opErr("put", key, KindQuota,
+			fmt.Errorf("%d bytes in use, limit is %d", db.quota.used.Load(), db.quota.limit))
+	}
+	return delta, nil
+}
+
+// commitQuota applies a footprint delta after a successful write or delete.
+func (db DB[KT, VT]) commitQuota(delta int64) {
+	if db.quota != nil {
+		db.quota.used.Add(delta)
+	}
+}
+
+// deleteFootprint returns how much live footprint deleting kb frees.
+func (db DB[KT, VT]) deleteFootprint(kb []byte) int64 {
+	if db.quota == nil {
+		return 0
+	}
+	if old, err := db.DB.Get(kb); err == nil && old != nil {
+		return entrySize(kb, old)
+	}
+	return 0
+}
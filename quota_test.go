@@ -0,0 +1,97 @@
+package katalis_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxDiskUsage(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.StringCodec,
+		katalis.WithMaxDiskUsage(64),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", strings.Repeat("v", 30)))
+
+	err = db.Put("b", strings.Repeat("v", 60))
+	assert.ErrorIs(t, err, katalis.ErrQuotaExceeded)
+
+	// Deleting frees quota immediately.
+	require.NoError(t, db.Del("a"))
+	assert.NoError(t, db.Put("b", strings.Repeat("v", 60)))
+}
+
+func TestQuotaCountsOverwritesOnce(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.StringCodec,
+		katalis.StringCodec,
+		katalis.WithMaxDiskUsage(64),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Overwriting the same key must not accumulate footprint.
+	for range 10 {
+		require.NoError(t, db.Put("key", strings.Repeat("v", 40)))
+	}
+}
+
+func TestQuotaSeededFromExistingStore(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("a", strings.Repeat("v", 50)))
+	require.NoError(t, db.Close())
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.StringCodec, katalis.WithMaxDiskUsage(64))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The pre-existing record already counts against the quota.
+	assert.ErrorIs(t, db.Put("b", strings.Repeat("v", 30)), katalis.ErrQuotaExceeded)
+}
+
+func TestQuotaCoversByteLevelWrites(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+	db, err := katalis.Open(
+		path,
+		katalis.StringCodec,
+		katalis.StringCodec,
+		katalis.WithMaxDiskUsage(128),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// A large value blows the budget even though Put is never called.
+	err = db.PutLarge("blob", strings.NewReader(strings.Repeat("v", 512)))
+	assert.ErrorIs(t, err, katalis.ErrQuotaExceeded)
+
+	// Transactions are charged too, before their WAL is written.
+	err = db.Tx(func(tx *katalis.Tx[string, string]) error {
+		return tx.Put("staged", strings.Repeat("v", 512))
+	})
+	assert.ErrorIs(t, err, katalis.ErrQuotaExceeded)
+
+	// Writes that fit keep working afterwards.
+	assert.NoError(t, db.Put("small", "v"))
+
+	// The rejected transaction must not resurrect from a leftover WAL.
+	require.NoError(t, db.Close())
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	has, err := db.Has("staged")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
@@ -0,0 +1,109 @@
+package katalis
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by writes that exceed a rate limit configured in
+// Fail mode.
+var ErrRateLimited = errors.New("katalis: write rate limit exceeded")
+
+// RateLimitMode selects what happens to a write once the budget of the
+// limiter is exhausted.
+type RateLimitMode int
+
+const (
+	// Block makes the write wait until the token bucket refills. Importers
+	// slow down instead of failing.
+	Block RateLimitMode = iota
+	// Fail makes the write return ErrRateLimited immediately, so callers can
+	// shed load themselves.
+	Fail
+)
+
+// WithWriteRateLimit caps writes at n per second with a burst of the same
+// size, using a token bucket.
+func WithWriteRateLimit(n int, mode RateLimitMode) OpenOption {
+	return func(c *openConfig) {
+		c.writeLimit = newRateLimiter(float64(n), mode)
+	}
+}
+
+// WithWriteByteRateLimit caps the encoded bytes written at n per second with
+// a burst of the same size, using a token bucket.
+func WithWriteByteRateLimit(n int64, mode RateLimitMode) OpenOption {
+	return func(c *openConfig) {
+		c.byteLimit = newRateLimiter(float64(n), mode)
+	}
+}
+
+// rateLimiter is a token bucket refilling at rate tokens per second, with a
+// one-second burst.
+type rateLimiter struct {
+	mode RateLimitMode
+
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, mode RateLimitMode) *rateLimiter {
+	return &rateLimiter{
+		mode:   mode,
+		rate:   rate,
+		tokens: rate,
+		last:   time.Now(),
+	}
+}
+
+// take consumes n tokens, blocking until they are available or returning
+// ErrRateLimited depending on the mode.
+func (l *rateLimiter) take(n float64) error {
+	l.mu.Lock()
+
+	now := time.Now()
+	l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= n
+
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	if l.mode == Fail {
+		// Refund: a failed write consumes no budget.
+		l.tokens += n
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+	time.Sleep(wait)
+	return nil
+}
+
+// limitWrite runs a write of size bytes through the DB's rate limiters.
+func (db DB[KT, VT]) limitWrite(size int) error {
+	if db.writeLimit != nil {
+		if err := db.writeLimit.take(1); err != nil {
+			return err
+		}
+	}
+	if db.byteLimit != nil {
+		if err := db.byteLimit.take(float64(size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PendingCompaction reports the store's background pressure as the estimated
+// number of bytes the next compaction will have to reclaim. Bulk writers can
+// poll it and back off while foreground readers catch up.
+func (db DB[KT, VT]) PendingCompaction() (int64, error) {
+	return db.ReclaimableBytes()
+}
@@ -0,0 +1,80 @@
+package katalis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRateLimitFail(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.IntCodec,
+		katalis.StringCodec,
+		katalis.WithWriteRateLimit(5, katalis.Fail),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The burst allows 5 writes, the 6th must be rejected.
+	for i := range 5 {
+		require.NoError(t, db.Put(i, "value"))
+	}
+	assert.ErrorIs(t, db.Put(6, "value"), katalis.ErrRateLimited)
+
+	// A rejected write consumes no budget: waiting refills the bucket.
+	time.Sleep(250 * time.Millisecond)
+	assert.NoError(t, db.Put(6, "value"))
+}
+
+func TestWriteRateLimitBlock(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.IntCodec,
+		katalis.StringCodec,
+		katalis.WithWriteRateLimit(50, katalis.Block),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Exhaust the burst, then one more write must block instead of failing.
+	start := time.Now()
+	for i := range 51 {
+		require.NoError(t, db.Put(i, "value"))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestWriteByteRateLimit(t *testing.T) {
+	db, err := katalis.Open(
+		t.TempDir()+"/test.db",
+		katalis.IntCodec,
+		katalis.StringCodec,
+		katalis.WithWriteByteRateLimit(64, katalis.Fail),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put(1, "0123456789012345678901234567890123456789"))
+	assert.ErrorIs(t, db.Put(2, "0123456789012345678901234567890123456789"), katalis.ErrRateLimited)
+}
+
+func TestPendingCompaction(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 50 {
+		require.NoError(t, db.Put(i, "some value that takes up a bit of room"))
+	}
+	for i := range 50 {
+		require.NoError(t, db.Del(i))
+	}
+
+	pending, err := db.PendingCompaction()
+	require.NoError(t, err)
+	assert.Positive(t, pending)
+}
@@ -0,0 +1,98 @@
+package katalis
+
+import (
+	"fmt"
+	"iter"
+)
+
+// rawPut writes an already-encoded record through the engine on behalf of
+// the internal byte-level paths — transactions, large values, the wrapper
+// types. Funneling them through one place keeps the store's bookkeeping in
+// step with writes that never go through Put: the degraded-mode gate and
+// failure streak behind Health, the disk usage quota and the bloom filter.
+func (db DB[KT, VT]) rawPut(kb, vb []byte) error {
+	if err := db.degradedErr(); err != nil {
+		return err
+	}
+	var delta int64
+	if db.quota != nil {
+		var err error
+		if delta, err = db.checkQuota(fmt.Sprintf("%x", kb), kb, vb); err != nil {
+			return err
+		}
+	}
+	if err := db.enginePut(kb, vb); err != nil {
+		db.noteWriteError(err)
+		return err
+	}
+	db.noteWriteSuccess()
+	db.commitQuota(delta)
+	if db.bloom != nil {
+		db.bloom.add(kb)
+	}
+	return nil
+}
+
+// rawDel deletes an already-encoded key through the engine, with the same
+// bookkeeping as rawPut. Deletions leave the bloom filter untouched by
+// design.
+func (db DB[KT, VT]) rawDel(kb []byte) error {
+	if err := db.degradedErr(); err != nil {
+		return err
+	}
+	freed := db.deleteFootprint(kb)
+	if err := db.engineDelete(kb); err != nil {
+		db.noteWriteError(err)
+		return err
+	}
+	db.noteWriteSuccess()
+	db.commitQuota(-freed)
+	return nil
+}
+
+// RawPut stores an already-encoded record exactly as given, without running
+// either codec: the byte-level counterpart of Put, meant for migration and
+// replication tools that shuttle records between stores. Unlike writing to
+// the embedded engine directly, it keeps the store's bookkeeping — such as
+// the bloom filter — consistent with the data.
+func (db DB[KT, VT]) RawPut(kb, vb []byte) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	return db.rawPut(kb, vb)
+}
+
+// RawDel deletes the record stored under the exact encoded key, the
+// byte-level counterpart of Del.
+func (db DB[KT, VT]) RawDel(kb []byte) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	return db.rawDel(kb)
+}
+
+// RawItems returns an iterator over all key-value pairs exactly as they are
+// stored on disk, without running either codec. It is meant for migration,
+// backup and debugging tools that need to see the encoded bytes; for regular
+// access use Items or AllItems.
+func (db DB[KT, VT]) RawItems() iter.Seq2[[]byte, []byte] {
+	if db.closedErr() != nil {
+		return func(yield func([]byte, []byte) bool) {}
+	}
+	items := db.DB.Items()
+
+	return func(yield func([]byte, []byte) bool) {
+		for {
+			kb, vb, err := items.Next()
+			if isTerminate(err) || err != nil {
+				return
+			}
+			if !db.inView(kb) {
+				continue
+			}
+			if !yield(kb, vb) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawItems(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", "value"))
+
+	got := make(map[string]string)
+	for kb, vb := range db.RawItems() {
+		got[string(kb)] = string(vb)
+	}
+
+	assert.Equal(t, map[string]string{"key": "value"}, got)
+}
+
+func TestRawItemsEarlyStop(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.IntCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 10 {
+		require.NoError(t, db.Put(i, i))
+	}
+
+	var seen int
+	for range db.RawItems() {
+		seen++
+		break
+	}
+	assert.Equal(t, 1, seen)
+}
@@ -0,0 +1,149 @@
+package katalis
+
+import (
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+)
+
+// ReadOnlyDB is a read-only view of a DB directory that can be opened while
+// another process holds the write lock. It works on a private point-in-time
+// copy of the store, so the writer is never disturbed; call Refresh to pick
+// up writes committed since the view was taken.
+type ReadOnlyDB[KT, VT any] struct {
+	db       DB[KT, VT]
+	src      string
+	tmp      string
+	keyCodec Codec[KT]
+	valCodec Codec[VT]
+}
+
+// OpenReadOnly opens a read-only view of the DB directory at path. Unlike
+// Open it does not take the write lock, so it can be used by reader processes
+// alongside a single writer. The view must be closed after use, by calling
+// Close.
+func OpenReadOnly[KT, VT any](path string, keyCodec Codec[KT], valCodec Codec[VT]) (*ReadOnlyDB[KT, VT], error) {
+	r := &ReadOnlyDB[KT, VT]{
+		src:      path,
+		keyCodec: keyCodec,
+		valCodec: valCodec,
+	}
+	if err := r.Refresh(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Refresh replaces the view with a fresh point-in-time copy of the store,
+// making writes committed since the last refresh visible. The writer should
+// have synced for the refresh to observe its latest puts.
+func (r *ReadOnlyDB[KT, VT]) Refresh() error {
+	tmp, err := os.MkdirTemp("", "katalis-readonly-*")
+	if err != nil {
+		return err
+	}
+
+	if err := copyStore(r.src, tmp); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+
+	db, err := Open(tmp, r.keyCodec, r.valCodec)
+	if err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+
+	old, oldTmp := r.db, r.tmp
+	r.db, r.tmp = db, tmp
+
+	if oldTmp != "" {
+		old.Close()
+		os.RemoveAll(oldTmp)
+	}
+	return nil
+}
+
+// Get returns the value for the given key stored in the view or an empty
+// value if the key doesn't exist.
+func (r *ReadOnlyDB[KT, VT]) Get(key KT) (VT, error) {
+	return r.db.Get(key)
+}
+
+// Has returns true if the view contains the given key.
+func (r *ReadOnlyDB[KT, VT]) Has(key KT) (bool, error) {
+	return r.db.Has(key)
+}
+
+// Count returns the number of keys in the view.
+func (r *ReadOnlyDB[KT, VT]) Count() uint32 {
+	return r.db.Count()
+}
+
+// Fold iterates over all keys in the view calling the function `fn` for each
+// key.
+func (r *ReadOnlyDB[KT, VT]) Fold(fn func(key KT, val VT, err error) error) error {
+	return r.db.Fold(fn)
+}
+
+// Items returns an iterator over all key-value pairs in the view.
+func (r *ReadOnlyDB[KT, VT]) Items() iter.Seq2[KT, VT] {
+	return r.db.Items()
+}
+
+// AllItems returns an iterator over all key-value pairs in the view with
+// error reporting.
+func (r *ReadOnlyDB[KT, VT]) AllItems() iter.Seq2[Entry[KT, VT], error] {
+	return r.db.AllItems()
+}
+
+// Close closes the view and removes its private copy of the store.
+func (r *ReadOnlyDB[KT, VT]) Close() error {
+	err := r.db.Close()
+	if r.tmp != "" {
+		os.RemoveAll(r.tmp)
+		r.tmp = ""
+	}
+	return err
+}
+
+// copyStore copies the regular files of a store directory into dst. The lock
+// file is copied as well: it makes the copy look like a crashed store, so
+// opening it rebuilds the index from the copied segments, which is exactly
+// what a view taken while the writer is live needs.
+func copyStore(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if err := copyFile(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
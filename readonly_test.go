@@ -0,0 +1,87 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenReadOnly(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	w, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer w.Close()
+
+	require.NoError(t, w.Put("k", 1))
+	require.NoError(t, w.Sync())
+
+	// The writer still holds the lock while the read-only view opens.
+	r, err := katalis.OpenReadOnly(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer r.Close()
+
+	val, err := r.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	has, err := r.Has("missing")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestReadOnlyRefresh(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	w, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer w.Close()
+
+	require.NoError(t, w.Put("old", 1))
+	require.NoError(t, w.Sync())
+
+	r, err := katalis.OpenReadOnly(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer r.Close()
+
+	// A write after the view was taken is invisible until Refresh.
+	require.NoError(t, w.Put("new", 2))
+	require.NoError(t, w.Sync())
+
+	has, err := r.Has("new")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	require.NoError(t, r.Refresh())
+
+	val, err := r.Get("new")
+	require.NoError(t, err)
+	assert.Equal(t, 2, val)
+}
+
+func TestReadOnlyIteration(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	w, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer w.Close()
+
+	for i, key := range []string{"a", "b", "c"} {
+		require.NoError(t, w.Put(key, i))
+	}
+	require.NoError(t, w.Sync())
+
+	r, err := katalis.OpenReadOnly(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer r.Close()
+
+	assert.Equal(t, uint32(3), r.Count())
+
+	got := map[string]int{}
+	for key, val := range r.Items() {
+		got[key] = val
+	}
+	assert.Equal(t, map[string]int{"a": 0, "b": 1, "c": 2}, got)
+}
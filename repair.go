@@ -0,0 +1,131 @@
+package katalis
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Repair rebuilds the store at path in place, salvaging every record whose
+// key and value still read back and decode with the given codecs, and
+// dropping the rest. It returns how many records were recovered and dropped.
+//
+// The store is first copied aside and run through the engine's crash
+// recovery, so even a directory with a torn segment that no longer opens can
+// be salvaged. The original directory is replaced only once the rebuilt
+// store is complete.
+func Repair[KT, VT any](path string, keyCodec Codec[KT], valCodec Codec[VT]) (recovered, dropped uint64, err error) {
+	// Work on a scratch copy with the lock file included: opening it forces
+	// crash recovery, which truncates torn segments and rebuilds the index.
+	scratch, err := os.MkdirTemp(filepath.Dir(path), "katalis-repair-src-*")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.RemoveAll(scratch)
+
+	if err := copyStore(path, scratch); err != nil {
+		return 0, 0, err
+	}
+
+	src, err := Open(scratch, keyCodec, valCodec)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer src.Close()
+
+	// Build the replacement next to the original so the final rename never
+	// crosses filesystems.
+	rebuilt, err := os.MkdirTemp(filepath.Dir(path), "katalis-repair-dst-*")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.RemoveAll(rebuilt)
+
+	dst, err := Open(rebuilt, keyCodec, valCodec)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	it := src.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			break
+		}
+		if err != nil {
+			dropped++
+			continue
+		}
+
+		// Salvage only records that fully decode.
+		if _, err := keyCodec.Decode(kb); err != nil {
+			dropped++
+			continue
+		}
+		if _, err := valCodec.Decode(vb); err != nil {
+			dropped++
+			continue
+		}
+
+		if err := dst.rawPut(kb, vb); err != nil {
+			dst.Close()
+			return recovered, dropped, err
+		}
+		recovered++
+	}
+
+	if err := dst.Close(); err != nil {
+		return recovered, dropped, err
+	}
+
+	// Swap the rebuilt store in.
+	if err := os.RemoveAll(path); err != nil {
+		return recovered, dropped, err
+	}
+	return recovered, dropped, os.Rename(rebuilt, path)
+}
+
+// RecoveryReport describes what OpenOrRecover had to do to hand back a
+// working store.
+type RecoveryReport struct {
+	// Repaired is true when the normal open failed and the store went
+	// through Repair before opening.
+	Repaired bool
+	// Recovered and Dropped count the records the repair salvaged and lost.
+	// Both are zero when Repaired is false.
+	Recovered uint64
+	Dropped   uint64
+}
+
+// OpenOrRecover opens the DB like Open and, when the open fails because the
+// data on disk is damaged — a torn segment, an index that no longer matches
+// its segments — automatically runs Repair and opens the salvaged store,
+// reporting how many records made it through. Meant for unattended edge
+// deployments where a store that refuses to open cannot wait for an operator
+// with a backup; the price is that a failed open is always answered with a
+// destructive rebuild of the directory.
+func OpenOrRecover[KT, VT any](path string, keyCodec Codec[KT], valCodec Codec[VT], opts ...OpenOption) (DB[KT, VT], RecoveryReport, error) {
+	db, err := Open(path, keyCodec, valCodec, opts...)
+	if err == nil {
+		return db, RecoveryReport{}, nil
+	}
+	openErr := err
+
+	// Plant the engine's lock file so the repair's scratch copy goes through
+	// crash recovery: the index is then rebuilt from the segments instead of
+	// trusted, which also salvages stores whose index itself is damaged.
+	if f, err := os.OpenFile(filepath.Join(path, "lock"), os.O_CREATE, 0644); err == nil {
+		f.Close()
+	}
+
+	recovered, dropped, err := Repair(path, keyCodec, valCodec)
+	if err != nil {
+		return DB[KT, VT]{}, RecoveryReport{}, errors.Join(openErr, err)
+	}
+
+	db, err = Open(path, keyCodec, valCodec, opts...)
+	if err != nil {
+		return DB[KT, VT]{}, RecoveryReport{}, errors.Join(openErr, err)
+	}
+	return db, RecoveryReport{Repaired: true, Recovered: recovered, Dropped: dropped}, nil
+}
@@ -0,0 +1,132 @@
+package katalis_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairCleanStore(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+	require.NoError(t, db.Close())
+
+	recovered, dropped, err := katalis.Repair(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), recovered)
+	assert.Zero(t, dropped)
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	val, err := db.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func TestRepairDropsUndecodableRecords(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("good", 42))
+	// Plant a record whose value cannot decode as int.
+	require.NoError(t, db.DB.Put([]byte("bad"), []byte{1}))
+	require.NoError(t, db.Close())
+
+	recovered, dropped, err := katalis.Repair(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), recovered)
+	assert.Equal(t, uint64(1), dropped)
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	val, err := db.Get("good")
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+
+	has, err := db.Has("bad")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestRepairUncleanlyClosedStore(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("survivor", 7))
+	require.NoError(t, db.Sync())
+
+	// Leave the store locked, as a crashed process would.
+	recovered, dropped, err := katalis.Repair(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), recovered)
+	assert.Zero(t, dropped)
+
+	// The stale handle's directory was swapped out from under it, so its
+	// Close is best-effort only.
+	db.Close()
+}
+
+func TestOpenOrRecoverHealthyStore(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("k", 1))
+	require.NoError(t, db.Close())
+
+	db, report, err := katalis.OpenOrRecover(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// A store that opens normally is not touched.
+	assert.False(t, report.Repaired)
+	assert.Zero(t, report.Recovered)
+
+	val, err := db.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func TestOpenOrRecoverCorruptIndex(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	for i := range 10 {
+		require.NoError(t, db.Put(fmt.Sprintf("key%d", i), i))
+	}
+	require.NoError(t, db.Close())
+
+	// Trash the main index: the store no longer opens at all.
+	require.NoError(t, os.WriteFile(path+"/main.pix", []byte("garbage"), 0644))
+	_, err = katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.Error(t, err)
+
+	db, report, err := katalis.OpenOrRecover(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.True(t, report.Repaired)
+	assert.Equal(t, uint64(10), report.Recovered)
+	assert.Zero(t, report.Dropped)
+
+	for i := range 10 {
+		val, err := db.Get(fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
@@ -0,0 +1,355 @@
+// Package replicate implements leader-follower replication for katalis
+// stores. A leader serves the change journal of a JournaledDB over TCP and
+// followers apply the stream to their local DB, keeping a warm standby of an
+// embedded store. Followers persist their cursor and resume where they left
+// off; a follower too far behind a truncated journal is caught up with a
+// full snapshot first.
+package replicate
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Record tags of the wire protocol.
+const (
+	tagPut          = 1
+	tagDel          = 2
+	tagSnapshot     = 3
+	tagSnapshotDone = 4
+)
+
+// pollInterval is how often the leader checks the journal for new changes to
+// stream to connected followers.
+const pollInterval = 100 * time.Millisecond
+
+// cursorKey is the reserved key under which a follower persists its
+// replication cursor in its own store.
+var cursorKey = []byte("\xffkatalis.replicate.cursor")
+
+// Leader serves a DB's change stream to followers.
+type Leader[KT, VT any] struct {
+	j  *katalis.JournaledDB[KT, VT]
+	ln net.Listener
+
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Serve starts serving the change stream of the journaled DB on addr. Use
+// ":0" to pick a free port and Addr to discover it. The leader must be
+// closed after use, by calling Close.
+func Serve[KT, VT any](j *katalis.JournaledDB[KT, VT], addr string) (*Leader[KT, VT], error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Leader[KT, VT]{j: j, ln: ln}
+	l.wg.Add(1)
+	go l.accept()
+	return l, nil
+}
+
+// Addr returns the address the leader is listening on.
+func (l *Leader[KT, VT]) Addr() net.Addr {
+	return l.ln.Addr()
+}
+
+// Close stops serving and disconnects all followers.
+func (l *Leader[KT, VT]) Close() error {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+
+	err := l.ln.Close()
+	l.wg.Wait()
+	return err
+}
+
+func (l *Leader[KT, VT]) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
+
+func (l *Leader[KT, VT]) accept() {
+	defer l.wg.Done()
+
+	for {
+		conn, err := l.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		l.wg.Add(1)
+		go func() {
+			defer l.wg.Done()
+			defer conn.Close()
+			l.serveFollower(conn)
+		}()
+	}
+}
+
+// serveFollower streams changes to one follower until it disconnects or the
+// leader closes.
+func (l *Leader[KT, VT]) serveFollower(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	var cursor uint64
+	if err := binary.Read(r, binary.BigEndian, &cursor); err != nil {
+		return
+	}
+
+	// A follower behind a truncated journal cannot replay the missing
+	// changes, so catch it up with a full snapshot first.
+	if l.needsSnapshot(cursor) {
+		var err error
+		if cursor, err = l.sendSnapshot(w); err != nil {
+			return
+		}
+	}
+
+	for !l.isClosed() {
+		for change, err := range l.j.Changes(cursor) {
+			if err != nil {
+				return
+			}
+			if err := writeRecord(w, byte(change.Op), change.Key, change.Value); err != nil {
+				return
+			}
+			cursor = change.Seq
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for !l.isClosed() && l.j.Seq() <= cursor {
+			time.Sleep(pollInterval)
+		}
+	}
+}
+
+// needsSnapshot reports whether the journal no longer covers the changes
+// right after the given cursor.
+func (l *Leader[KT, VT]) needsSnapshot(cursor uint64) bool {
+	if cursor >= l.j.Seq() {
+		return false
+	}
+
+	for change, err := range l.j.Changes(0) {
+		if err != nil {
+			return false
+		}
+		return change.Seq > cursor+1
+	}
+	// Empty journal but a cursor behind the leader's sequence.
+	return true
+}
+
+// sendSnapshot streams every record of the store followed by the sequence
+// number the snapshot corresponds to, which becomes the follower's cursor.
+func (l *Leader[KT, VT]) sendSnapshot(w *bufio.Writer) (uint64, error) {
+	seq := l.j.Seq()
+
+	it := l.j.DB.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if errors.Is(err, katalis.ErrIterationDone) || errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if err := writeRecord(w, tagSnapshot, kb, vb); err != nil {
+			return 0, err
+		}
+	}
+
+	seqb := binary.BigEndian.AppendUint64(nil, seq)
+	if err := writeRecord(w, tagSnapshotDone, seqb, nil); err != nil {
+		return 0, err
+	}
+	return seq, w.Flush()
+}
+
+// Follower applies a leader's change stream to a local DB.
+type Follower[KT, VT any] struct {
+	db   katalis.DB[KT, VT]
+	addr string
+
+	mu  sync.Mutex
+	seq uint64
+}
+
+// NewFollower creates a follower replicating from the leader at addr into
+// the local DB. Call Run to start applying the stream.
+func NewFollower[KT, VT any](db katalis.DB[KT, VT], addr string) *Follower[KT, VT] {
+	return &Follower[KT, VT]{db: db, addr: addr}
+}
+
+// Seq returns the sequence number of the last applied change.
+func (f *Follower[KT, VT]) Seq() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.seq
+}
+
+// Run connects to the leader and applies its change stream until ctx is
+// canceled or the connection fails. The replication cursor is persisted in
+// the local store, so a restarted follower resumes where it stopped.
+func (f *Follower[KT, VT]) Run(ctx context.Context) error {
+	cursor, err := f.loadCursor()
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.seq = cursor
+	f.mu.Unlock()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", f.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock reads when the context is canceled.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	w := bufio.NewWriter(conn)
+	if err := binary.Write(w, binary.BigEndian, cursor); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(conn)
+	for {
+		tag, key, val, err := readRecord(r)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		if err := f.apply(tag, key, val); err != nil {
+			return err
+		}
+	}
+}
+
+// apply performs one replicated operation and advances the cursor.
+func (f *Follower[KT, VT]) apply(tag byte, key, val []byte) error {
+	switch tag {
+	case tagPut:
+		if err := f.db.RawPut(key, val); err != nil {
+			return err
+		}
+
+	case tagDel:
+		if err := f.db.RawDel(key); err != nil {
+			return err
+		}
+
+	case tagSnapshot:
+		// Snapshot records carry no sequence number of their own: the cursor
+		// advances once the snapshot completes.
+		return f.db.RawPut(key, val)
+
+	case tagSnapshotDone:
+		return f.saveCursor(binary.BigEndian.Uint64(key))
+
+	default:
+		return fmt.Errorf("replicate: unknown record tag %d", tag)
+	}
+
+	f.mu.Lock()
+	seq := f.seq + 1
+	f.mu.Unlock()
+	return f.saveCursor(seq)
+}
+
+func (f *Follower[KT, VT]) loadCursor() (uint64, error) {
+	has, err := f.db.DB.Has(cursorKey)
+	if err != nil || !has {
+		return 0, err
+	}
+
+	b, err := f.db.DB.Get(cursorKey)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+func (f *Follower[KT, VT]) saveCursor(seq uint64) error {
+	if err := f.db.RawPut(cursorKey, binary.BigEndian.AppendUint64(nil, seq)); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.seq = seq
+	f.mu.Unlock()
+	return nil
+}
+
+// writeRecord writes one wire record: a tag followed by two length-prefixed
+// frames.
+func writeRecord(w *bufio.Writer, tag byte, key, val []byte) error {
+	if err := w.WriteByte(tag); err != nil {
+		return err
+	}
+	if err := writeFrame(w, key); err != nil {
+		return err
+	}
+	return writeFrame(w, val)
+}
+
+func readRecord(r *bufio.Reader) (tag byte, key, val []byte, err error) {
+	if tag, err = r.ReadByte(); err != nil {
+		return 0, nil, nil, err
+	}
+	if key, err = readWireFrame(r); err != nil {
+		return 0, nil, nil, err
+	}
+	if val, err = readWireFrame(r); err != nil {
+		return 0, nil, nil, err
+	}
+	return tag, key, val, nil
+}
+
+func writeFrame(w *bufio.Writer, b []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func readWireFrame(r *bufio.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,128 @@
+package replicate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/replicate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openLeader(t *testing.T) (*katalis.JournaledDB[string, string], *replicate.Leader[string, string]) {
+	t.Helper()
+
+	db, err := katalis.Open(t.TempDir()+"/leader.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+
+	j, err := katalis.Journaled(db)
+	require.NoError(t, err)
+	t.Cleanup(func() { j.Close() })
+
+	l, err := replicate.Serve(j, "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	return j, l
+}
+
+func openFollowerDB(t *testing.T, path string) katalis.DB[string, string] {
+	t.Helper()
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestFollowerAppliesChanges(t *testing.T) {
+	j, l := openLeader(t)
+
+	require.NoError(t, j.Put("a", "1"))
+	require.NoError(t, j.Put("b", "2"))
+
+	fdb := openFollowerDB(t, t.TempDir()+"/follower.db")
+	f := replicate.NewFollower(fdb, l.Addr().String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go f.Run(ctx)
+
+	require.Eventually(t, func() bool { return f.Seq() >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	val, err := fdb.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", val)
+
+	// Live changes keep streaming.
+	require.NoError(t, j.Del("a"))
+	require.NoError(t, j.Put("c", "3"))
+
+	require.Eventually(t, func() bool { return f.Seq() >= 4 }, 5*time.Second, 10*time.Millisecond)
+
+	has, err := fdb.Has("a")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	val, err = fdb.Get("c")
+	require.NoError(t, err)
+	assert.Equal(t, "3", val)
+}
+
+func TestFollowerResumes(t *testing.T) {
+	j, l := openLeader(t)
+	path := t.TempDir() + "/follower.db"
+
+	require.NoError(t, j.Put("a", "1"))
+
+	fdb := openFollowerDB(t, path)
+	f := replicate.NewFollower(fdb, l.Addr().String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go f.Run(ctx)
+	require.Eventually(t, func() bool { return f.Seq() >= 1 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+
+	// More writes while the follower is offline.
+	require.NoError(t, j.Put("b", "2"))
+
+	f = replicate.NewFollower(fdb, l.Addr().String())
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go f.Run(ctx)
+
+	require.Eventually(t, func() bool { return f.Seq() >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	val, err := fdb.Get("b")
+	require.NoError(t, err)
+	assert.Equal(t, "2", val)
+}
+
+func TestFollowerCatchesUpFromSnapshot(t *testing.T) {
+	j, l := openLeader(t)
+
+	require.NoError(t, j.Put("a", "1"))
+	require.NoError(t, j.Put("b", "2"))
+
+	// Truncating the journal forces new followers through the snapshot path.
+	require.NoError(t, j.Truncate(2))
+
+	fdb := openFollowerDB(t, t.TempDir()+"/follower.db")
+	f := replicate.NewFollower(fdb, l.Addr().String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go f.Run(ctx)
+
+	require.Eventually(t, func() bool { return f.Seq() >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	val, err := fdb.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", val)
+
+	val, err = fdb.Get("b")
+	require.NoError(t, err)
+	assert.Equal(t, "2", val)
+}
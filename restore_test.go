@@ -0,0 +1,105 @@
+package katalis_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBackupAndRestore(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/src.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", "1"))
+	require.NoError(t, db.Put("b", "2"))
+
+	var backup bytes.Buffer
+	require.NoError(t, db.WriteBackup(&backup))
+
+	require.NoError(t, katalis.RestoreTo(dir+"/restored.db", &backup, nil, time.Now()))
+
+	restored, err := katalis.Open(dir+"/restored.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer restored.Close()
+
+	val, err := restored.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", val)
+
+	assert.Equal(t, uint32(2), restored.Count())
+}
+
+func TestRestoreToPointInTime(t *testing.T) {
+	dir := t.TempDir()
+
+	j := openJournaled(t, dir+"/src.db")
+	defer j.Close()
+
+	require.NoError(t, j.Put("a", "before"))
+	require.NoError(t, j.Put("b", "kept"))
+
+	cutoff := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	// Changes after the cutoff must not appear in the restored store.
+	require.NoError(t, j.Put("a", "after"))
+	require.NoError(t, j.Del("b"))
+
+	journal, err := os.Open(dir + "/src.db.journal")
+	require.NoError(t, err)
+	defer journal.Close()
+
+	require.NoError(t, katalis.RestoreTo(dir+"/restored.db", nil, journal, cutoff))
+
+	restored, err := katalis.Open(dir+"/restored.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer restored.Close()
+
+	val, err := restored.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "before", val)
+
+	val, err = restored.Get("b")
+	require.NoError(t, err)
+	assert.Equal(t, "kept", val)
+}
+
+func TestRestoreToBackupPlusJournal(t *testing.T) {
+	dir := t.TempDir()
+
+	j := openJournaled(t, dir+"/src.db")
+	defer j.Close()
+
+	require.NoError(t, j.DB.Put("base", "snapshot"))
+
+	var backup bytes.Buffer
+	require.NoError(t, j.DB.WriteBackup(&backup))
+
+	require.NoError(t, j.Put("delta", "journaled"))
+
+	journal, err := os.Open(dir + "/src.db.journal")
+	require.NoError(t, err)
+	defer journal.Close()
+
+	require.NoError(t, katalis.RestoreTo(dir+"/restored.db", &backup, journal, time.Now()))
+
+	restored, err := katalis.Open(dir+"/restored.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer restored.Close()
+
+	val, err := restored.Get("base")
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", val)
+
+	val, err = restored.Get("delta")
+	require.NoError(t, err)
+	assert.Equal(t, "journaled", val)
+}
@@ -0,0 +1,132 @@
+// Package auth is the shared access control for the katalis server modes.
+// An Authenticator maps credentials — bearer tokens or mTLS client
+// certificates — to an ACL of key-prefix rules, each granting read or
+// read-write access to one namespace. The HTTP, gRPC and RESP servers all
+// consult the same Authenticator, so one policy covers every way into the
+// store.
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnauthenticated means the request carried no credentials, or
+	// credentials nobody registered.
+	ErrUnauthenticated = errors.New("auth: unknown or missing credentials")
+	// ErrForbidden means the identity is known but its ACL does not cover
+	// the attempted operation.
+	ErrForbidden = errors.New("auth: permission denied")
+)
+
+// Perm is a bitmask of the operations a rule grants.
+type Perm uint8
+
+const (
+	PermRead Perm = 1 << iota
+	PermWrite
+
+	PermReadWrite = PermRead | PermWrite
+)
+
+// Rule grants permissions on one namespace: every key starting with Prefix.
+// An empty Prefix covers the whole store.
+type Rule struct {
+	Prefix string
+	Perm   Perm
+}
+
+// ACL is the set of rules attached to one identity.
+type ACL []Rule
+
+// Allows reports whether the ACL grants all the permissions in p on key.
+func (a ACL) Allows(key []byte, p Perm) bool {
+	for _, r := range a {
+		if strings.HasPrefix(string(key), r.Prefix) && r.Perm&p == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator resolves credentials to ACLs. The zero value is not usable;
+// create one with New. A nil *Authenticator passed to a server disables
+// authentication entirely.
+type Authenticator struct {
+	mu     sync.RWMutex
+	tokens map[string]ACL
+	certs  map[string]ACL
+}
+
+// New returns an Authenticator with no identities: until some are added,
+// every request is rejected.
+func New() *Authenticator {
+	return &Authenticator{tokens: make(map[string]ACL), certs: make(map[string]ACL)}
+}
+
+// AllowToken grants acl to clients presenting the given bearer token.
+func (a *Authenticator) AllowToken(token string, acl ACL) {
+	a.mu.Lock()
+	a.tokens[token] = acl
+	a.mu.Unlock()
+}
+
+// AllowCommonName grants acl to clients presenting a verified TLS
+// certificate with the given subject common name.
+func (a *Authenticator) AllowCommonName(cn string, acl ACL) {
+	a.mu.Lock()
+	a.certs[cn] = acl
+	a.mu.Unlock()
+}
+
+// Authenticate resolves a request's credentials: the bearer token when one
+// was sent, otherwise the peer certificates of the TLS connection. It
+// returns ErrUnauthenticated when neither matches a known identity.
+func (a *Authenticator) Authenticate(token string, certs []*x509.Certificate) (ACL, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if token != "" {
+		if acl, ok := a.tokens[token]; ok {
+			return acl, nil
+		}
+		return nil, ErrUnauthenticated
+	}
+	for _, cert := range certs {
+		if acl, ok := a.certs[cert.Subject.CommonName]; ok {
+			return acl, nil
+		}
+	}
+	return nil, ErrUnauthenticated
+}
+
+// ServerTLS builds the tls.Config for a server from PEM files. When
+// clientCAFile is non-empty the config also demands and verifies client
+// certificates, which is what makes AllowCommonName identities work.
+func ServerTLS(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("auth: no certificates found in %s", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
@@ -0,0 +1,118 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis/server/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLAllows(t *testing.T) {
+	acl := auth.ACL{
+		{Prefix: "public:", Perm: auth.PermRead},
+		{Prefix: "mine:", Perm: auth.PermReadWrite},
+	}
+
+	assert.True(t, acl.Allows([]byte("public:doc"), auth.PermRead))
+	assert.False(t, acl.Allows([]byte("public:doc"), auth.PermWrite))
+	assert.True(t, acl.Allows([]byte("mine:doc"), auth.PermWrite))
+	assert.True(t, acl.Allows([]byte("mine:doc"), auth.PermReadWrite))
+	assert.False(t, acl.Allows([]byte("other:doc"), auth.PermRead))
+}
+
+func TestACLEmptyPrefix(t *testing.T) {
+	acl := auth.ACL{{Perm: auth.PermReadWrite}}
+	assert.True(t, acl.Allows([]byte("anything"), auth.PermReadWrite))
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	a := auth.New()
+	a.AllowToken("s3cret", auth.ACL{{Perm: auth.PermRead}})
+
+	acl, err := a.Authenticate("s3cret", nil)
+	require.NoError(t, err)
+	assert.True(t, acl.Allows([]byte("k"), auth.PermRead))
+
+	_, err = a.Authenticate("wrong", nil)
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+
+	_, err = a.Authenticate("", nil)
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+}
+
+func TestAuthenticateCert(t *testing.T) {
+	a := auth.New()
+	a.AllowCommonName("backup-job", auth.ACL{{Perm: auth.PermRead}})
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "backup-job"}}
+	acl, err := a.Authenticate("", []*x509.Certificate{cert})
+	require.NoError(t, err)
+	assert.True(t, acl.Allows([]byte("k"), auth.PermRead))
+
+	stranger := &x509.Certificate{Subject: pkix.Name{CommonName: "stranger"}}
+	_, err = a.Authenticate("", []*x509.Certificate{stranger})
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+}
+
+// writeTestCert generates a self-signed certificate and writes the PEM pair
+// into dir, returning the file paths.
+func writeTestCert(t *testing.T, dir, cn string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, cn+".crt")
+	keyFile = filepath.Join(dir, cn+".key")
+
+	require.NoError(t, os.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+
+	kb, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kb}), 0600))
+	return certFile, keyFile
+}
+
+func TestServerTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir, "server")
+
+	cfg, err := auth.ServerTLS(certFile, keyFile, "")
+	require.NoError(t, err)
+	assert.Len(t, cfg.Certificates, 1)
+	assert.Equal(t, tls.NoClientCert, cfg.ClientAuth)
+
+	caFile, _ := writeTestCert(t, dir, "ca")
+	cfg, err = auth.ServerTLS(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+	assert.NotNil(t, cfg.ClientCAs)
+
+	_, err = auth.ServerTLS(certFile, keyFile, keyFile)
+	assert.ErrorContains(t, err, "no certificates")
+}
@@ -0,0 +1,175 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/grpcapi/pb"
+	"google.golang.org/grpc"
+)
+
+// Client speaks to a remote katalis Server while satisfying katalis.Store, so
+// code written against Store does not care whether the store is embedded or
+// on the other end of a connection.
+type Client[KT, VT any] struct {
+	rpc pb.KatalisClient
+	kc  katalis.Codec[KT]
+	vc  katalis.Codec[VT]
+}
+
+var _ katalis.Store[string, string] = Client[string, string]{}
+
+// NewClient returns a Client on the given connection. The codecs must match
+// the ones the remote store was opened with.
+func NewClient[KT, VT any](conn grpc.ClientConnInterface, keyCodec katalis.Codec[KT], valCodec katalis.Codec[VT]) Client[KT, VT] {
+	return Client[KT, VT]{rpc: pb.NewKatalisClient(conn), kc: keyCodec, vc: valCodec}
+}
+
+// Get returns the value for the given key, or an empty value if the key
+// doesn't exist, mirroring the Store semantics of DB and MemStore.
+func (c Client[KT, VT]) Get(key KT) (val VT, err error) {
+	kb, err := c.kc.Encode(key)
+	if err != nil {
+		return val, err
+	}
+	resp, err := c.rpc.Get(context.Background(), &pb.GetRequest{Key: kb})
+	if err != nil || !resp.Found {
+		return val, err
+	}
+	return c.vc.Decode(resp.Value)
+}
+
+// Put sets the value for the given key.
+func (c Client[KT, VT]) Put(key KT, val VT) error {
+	kb, err := c.kc.Encode(key)
+	if err != nil {
+		return err
+	}
+	vb, err := c.vc.Encode(val)
+	if err != nil {
+		return err
+	}
+	_, err = c.rpc.Put(context.Background(), &pb.PutRequest{Key: kb, Value: vb})
+	return err
+}
+
+// Del deletes the value for the given key.
+func (c Client[KT, VT]) Del(key KT) error {
+	kb, err := c.kc.Encode(key)
+	if err != nil {
+		return err
+	}
+	_, err = c.rpc.Delete(context.Background(), &pb.DeleteRequest{Key: kb})
+	return err
+}
+
+// Has returns true if the remote store contains the given key.
+func (c Client[KT, VT]) Has(key KT) (bool, error) {
+	kb, err := c.kc.Encode(key)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.rpc.Get(context.Background(), &pb.GetRequest{Key: kb})
+	if err != nil {
+		return false, err
+	}
+	return resp.Found, nil
+}
+
+// Items returns an iterator over all remote key-value pairs. Pairs that fail
+// to stream or decode end the iteration; use Scan to observe the error.
+func (c Client[KT, VT]) Items() iter.Seq2[KT, VT] {
+	return func(yield func(KT, VT) bool) {
+		for entry, err := range c.Scan(context.Background()) {
+			if err != nil {
+				return
+			}
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Scan streams every remote record as it arrives, reporting stream and
+// decode errors to the caller in the same shape as DB.AllItems.
+func (c Client[KT, VT]) Scan(ctx context.Context) iter.Seq2[katalis.Entry[KT, VT], error] {
+	return func(yield func(katalis.Entry[KT, VT], error) bool) {
+		var zero katalis.Entry[KT, VT]
+
+		stream, err := c.rpc.Scan(ctx, &pb.ScanRequest{})
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if !isEOF(err) {
+					yield(zero, err)
+				}
+				return
+			}
+			entry, err := c.decode(resp.Key, resp.Value)
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
+
+// WatchEvent is a single change streamed by Watch.
+type WatchEvent[KT, VT any] struct {
+	// Deleted reports whether the change removed the key; Value is empty
+	// when it did.
+	Deleted bool
+	Key     KT
+	Value   VT
+}
+
+// Watch streams changes applied through the remote server from now on, until
+// ctx is cancelled or the stream fails.
+func (c Client[KT, VT]) Watch(ctx context.Context) iter.Seq2[WatchEvent[KT, VT], error] {
+	return func(yield func(WatchEvent[KT, VT], error) bool) {
+		var zero WatchEvent[KT, VT]
+
+		stream, err := c.rpc.Watch(ctx, &pb.WatchRequest{})
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if !isEOF(err) {
+					yield(zero, err)
+				}
+				return
+			}
+
+			ev := WatchEvent[KT, VT]{Deleted: resp.Op == pb.WatchResponse_DELETE}
+			if ev.Key, err = c.kc.Decode(resp.Key); err == nil && !ev.Deleted {
+				ev.Value, err = c.vc.Decode(resp.Value)
+			}
+			if !yield(ev, err) {
+				return
+			}
+		}
+	}
+}
+
+// isEOF reports whether a stream ended normally.
+func isEOF(err error) bool {
+	return errors.Is(err, io.EOF)
+}
+
+func (c Client[KT, VT]) decode(kb, vb []byte) (entry katalis.Entry[KT, VT], err error) {
+	if entry.Key, err = c.kc.Decode(kb); err != nil {
+		return entry, err
+	}
+	entry.Value, err = c.vc.Decode(vb)
+	return entry, err
+}
@@ -0,0 +1,177 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/auth"
+	"github.com/NicoNex/katalis/server/grpcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newClient spins up a Server on an in-memory connection and returns a typed
+// Client talking to it.
+func newClient(t *testing.T) grpcapi.Client[string, string] {
+	t.Helper()
+
+	raw, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { raw.Close() })
+
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	grpcapi.NewServer(raw).Register(srv)
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return grpcapi.NewClient(conn, katalis.StringCodec, katalis.StringCodec)
+}
+
+func TestClientStoreRoundTrip(t *testing.T) {
+	client := newClient(t)
+
+	require.NoError(t, client.Put("name", "ada"))
+
+	val, err := client.Get("name")
+	require.NoError(t, err)
+	assert.Equal(t, "ada", val)
+
+	has, err := client.Has("name")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	require.NoError(t, client.Del("name"))
+
+	has, err = client.Has("name")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// Missing keys behave like Store: empty value, no error.
+	val, err = client.Get("name")
+	require.NoError(t, err)
+	assert.Empty(t, val)
+}
+
+func TestClientItems(t *testing.T) {
+	client := newClient(t)
+	want := map[string]string{"a": "1", "b": "2", "c": "3"}
+	for k, v := range want {
+		require.NoError(t, client.Put(k, v))
+	}
+
+	got := make(map[string]string)
+	for k, v := range client.Items() {
+		got[k] = v
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestClientScan(t *testing.T) {
+	client := newClient(t)
+	require.NoError(t, client.Put("k", "v"))
+
+	var n int
+	for entry, err := range client.Scan(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, "k", entry.Key)
+		n++
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestWatch(t *testing.T) {
+	client := newClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan grpcapi.WatchEvent[string, string], 8)
+	go func() {
+		for ev, err := range client.Watch(ctx) {
+			if err != nil {
+				return
+			}
+			events <- ev
+		}
+	}()
+
+	// Give the watch stream a moment to be established before writing.
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, client.Put("watched", "v1"))
+	require.NoError(t, client.Del("watched"))
+
+	ev := <-events
+	assert.False(t, ev.Deleted)
+	assert.Equal(t, "watched", ev.Key)
+	assert.Equal(t, "v1", ev.Value)
+
+	ev = <-events
+	assert.True(t, ev.Deleted)
+	assert.Equal(t, "watched", ev.Key)
+}
+
+func TestAuthToken(t *testing.T) {
+	raw, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { raw.Close() })
+	require.NoError(t, raw.Put([]byte("public:doc"), []byte("hi")))
+	require.NoError(t, raw.Put([]byte("private:doc"), []byte("secret")))
+
+	a := auth.New()
+	a.AllowToken("reader", auth.ACL{{Prefix: "public:", Perm: auth.PermRead}})
+
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	grpcapi.NewServer(raw, grpcapi.WithAuth(a)).Register(srv)
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	dial := func(opts ...grpc.DialOption) grpcapi.Client[string, string] {
+		opts = append(opts,
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := grpc.NewClient("passthrough:///bufconn", opts...)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return grpcapi.NewClient(conn, katalis.StringCodec, katalis.StringCodec)
+	}
+
+	// No credentials.
+	_, err = dial().Get("public:doc")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	reader := dial(grpc.WithPerRPCCredentials(grpcapi.TokenCredentials("reader")))
+
+	val, err := reader.Get("public:doc")
+	require.NoError(t, err)
+	assert.Equal(t, "hi", val)
+
+	// Writes and foreign namespaces are denied.
+	err = reader.Put("public:doc", "x")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = reader.Get("private:doc")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Scan only streams readable keys.
+	var keys []string
+	for entry, err := range reader.Scan(context.Background()) {
+		require.NoError(t, err)
+		keys = append(keys, entry.Key)
+	}
+	assert.Equal(t, []string{"public:doc"}, keys)
+}
@@ -0,0 +1,278 @@
+// Package grpcapi turns an embedded katalis store into a small network KV
+// service. The wire contract lives in pb/katalis.proto; Server implements it
+// in front of a raw DB and Client speaks it while satisfying the same
+// katalis.Store interface the DB does, so code written against Store runs
+// unchanged on either side of the network.
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/auth"
+	"github.com/NicoNex/katalis/server/grpcapi/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// watchBuffer is how many pending events a watcher can lag behind before it
+// starts missing updates.
+const watchBuffer = 64
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAuth makes the server demand credentials known to a on every RPC: a
+// bearer token in the authorization metadata (see TokenCredentials) or an
+// mTLS client certificate whose common name a recognises. The resolved ACL
+// is enforced per key; Scan and Watch silently skip keys outside the
+// caller's readable namespaces.
+func WithAuth(a *auth.Authenticator) Option {
+	return func(s *Server) { s.auth = a }
+}
+
+// Server implements the Katalis gRPC service in front of a raw store.
+type Server struct {
+	pb.UnimplementedKatalisServer
+	db   katalis.DB[[]byte, []byte]
+	hub  *watchHub
+	auth *auth.Authenticator
+}
+
+// NewServer returns a Server for db. Register it on a grpc.Server with
+// Register before serving.
+func NewServer(db katalis.DB[[]byte, []byte], opts ...Option) *Server {
+	s := &Server{db: db, hub: newWatchHub()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers the service on reg, typically a *grpc.Server.
+func (s *Server) Register(reg grpc.ServiceRegistrar) {
+	pb.RegisterKatalisServer(reg, s)
+}
+
+// rpcErr maps a store error onto the gRPC status code it should travel as.
+func rpcErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, katalis.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, katalis.ErrTooLarge), errors.Is(err, katalis.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, katalis.ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// aclFor resolves the credentials of the RPC. With no authenticator
+// configured every call passes with a nil ACL.
+func (s *Server) aclFor(ctx context.Context) (auth.ACL, error) {
+	if s.auth == nil {
+		return nil, nil
+	}
+
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get("authorization") {
+			token, _ = strings.CutPrefix(v, "Bearer ")
+		}
+	}
+
+	var certs []*x509.Certificate
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			certs = info.State.PeerCertificates
+		}
+	}
+
+	acl, err := s.auth.Authenticate(token, certs)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return acl, nil
+}
+
+// authorize resolves credentials and checks p on key in one step, for the
+// unary RPCs that touch a single key.
+func (s *Server) authorize(ctx context.Context, key []byte, p auth.Perm) error {
+	acl, err := s.aclFor(ctx)
+	if err != nil {
+		return err
+	}
+	if s.auth != nil && !acl.Allows(key, p) {
+		return status.Error(codes.PermissionDenied, auth.ErrForbidden.Error())
+	}
+	return nil
+}
+
+// readable reports whether the ACL resolved by aclFor lets the caller see
+// key; a nil ACL with no authenticator configured sees everything.
+func (s *Server) readable(acl auth.ACL, key []byte) bool {
+	return s.auth == nil || acl.Allows(key, auth.PermRead)
+}
+
+func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	if err := s.authorize(ctx, req.Key, auth.PermRead); err != nil {
+		return nil, err
+	}
+	has, err := s.db.Has(req.Key)
+	if err != nil {
+		return nil, rpcErr(err)
+	}
+	if !has {
+		return &pb.GetResponse{}, nil
+	}
+	val, err := s.db.Get(req.Key)
+	if err != nil {
+		return nil, rpcErr(err)
+	}
+	return &pb.GetResponse{Value: val, Found: true}, nil
+}
+
+func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (*pb.PutResponse, error) {
+	if err := s.authorize(ctx, req.Key, auth.PermWrite); err != nil {
+		return nil, err
+	}
+	if err := s.db.Put(req.Key, req.Value); err != nil {
+		return nil, rpcErr(err)
+	}
+	s.hub.notify(pb.WatchResponse_PUT, req.Key, req.Value)
+	return &pb.PutResponse{}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	if err := s.authorize(ctx, req.Key, auth.PermWrite); err != nil {
+		return nil, err
+	}
+	if err := s.db.Del(req.Key); err != nil {
+		return nil, rpcErr(err)
+	}
+	s.hub.notify(pb.WatchResponse_DELETE, req.Key, nil)
+	return &pb.DeleteResponse{}, nil
+}
+
+func (s *Server) Scan(req *pb.ScanRequest, stream grpc.ServerStreamingServer[pb.ScanResponse]) error {
+	acl, err := s.aclFor(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	for entry, err := range s.db.AllItems() {
+		if err != nil {
+			return rpcErr(err)
+		}
+		if !bytes.HasPrefix(entry.Key, req.Prefix) || !s.readable(acl, entry.Key) {
+			continue
+		}
+		if err := stream.Send(&pb.ScanResponse{Key: entry.Key, Value: entry.Value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) Watch(req *pb.WatchRequest, stream grpc.ServerStreamingServer[pb.WatchResponse]) error {
+	ctx := stream.Context()
+	acl, err := s.aclFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := s.hub.subscribe(req.Prefix)
+	defer s.hub.unsubscribe(w)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev := <-w.ch:
+			if !s.readable(acl, ev.Key) {
+				continue
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// TokenCredentials returns per-RPC credentials sending token as a bearer
+// token, for dialing a server configured with WithAuth:
+//
+//	grpc.NewClient(addr, grpc.WithPerRPCCredentials(grpcapi.TokenCredentials("s3cret")), ...)
+func TokenCredentials(token string) credentials.PerRPCCredentials {
+	return tokenCreds(token)
+}
+
+type tokenCreds string
+
+func (t tokenCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity returns false so tokens also work on the
+// in-process and test connections; production deployments should still pair
+// tokens with TLS.
+func (t tokenCreds) RequireTransportSecurity() bool { return false }
+
+type watcher struct {
+	prefix []byte
+	ch     chan *pb.WatchResponse
+}
+
+// watchHub fans writes applied through this server out to Watch streams.
+// Writes done by the embedding process directly on the DB are not seen here.
+type watchHub struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+}
+
+func newWatchHub() *watchHub {
+	return &watchHub{watchers: make(map[*watcher]struct{})}
+}
+
+func (h *watchHub) subscribe(prefix []byte) *watcher {
+	w := &watcher{prefix: prefix, ch: make(chan *pb.WatchResponse, watchBuffer)}
+	h.mu.Lock()
+	h.watchers[w] = struct{}{}
+	h.mu.Unlock()
+	return w
+}
+
+func (h *watchHub) unsubscribe(w *watcher) {
+	h.mu.Lock()
+	delete(h.watchers, w)
+	h.mu.Unlock()
+}
+
+// notify delivers an event to every matching watcher. A watcher that has
+// fallen watchBuffer events behind misses the update instead of stalling
+// writes.
+func (h *watchHub) notify(op pb.WatchResponse_Op, key, val []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for w := range h.watchers {
+		if !bytes.HasPrefix(key, w.prefix) {
+			continue
+		}
+		select {
+		case w.ch <- &pb.WatchResponse{Op: op, Key: key, Value: val}:
+		default:
+		}
+	}
+}
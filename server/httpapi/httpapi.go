@@ -0,0 +1,432 @@
+// Package httpapi exposes a katalis store over plain HTTP so that processes
+// written in other languages can read and write it without linking Go code.
+//
+// The handler serves:
+//
+//	GET    /keys/{key}   fetch a value
+//	PUT    /keys/{key}   set a value from the request body
+//	DELETE /keys/{key}   delete a key
+//	GET    /keys         list entries, paginated with ?cursor= and ?limit=
+//	POST   /batch/get    fetch many values in one round trip
+//	POST   /batch/put    set many values in one round trip
+//	POST   /batch/del    delete many keys in one round trip
+//
+// Values travel either as raw codec-encoded bytes (the default) or as JSON:
+// send "Content-Type: application/json" on writes and "Accept:
+// application/json" on reads to switch. Keys in URLs are parsed with the
+// store's key codec, so string keys appear verbatim in paths.
+package httpapi
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/auth"
+)
+
+// defaultPageLimit caps list responses when the client gives no ?limit=.
+const defaultPageLimit = 100
+
+// Option configures a Server.
+type Option func(*config)
+
+type config struct {
+	auth *auth.Authenticator
+}
+
+// WithAuth makes the server demand credentials known to a on every request:
+// a bearer token in the Authorization header or, when serving TLS with
+// client certificates, a certificate whose common name a recognises. The
+// resolved ACL is enforced per key, so read-only identities get 403 on
+// writes and listings only show the namespaces they may read.
+func WithAuth(a *auth.Authenticator) Option {
+	return func(c *config) { c.auth = a }
+}
+
+// Server serves a katalis store over HTTP. It implements http.Handler.
+type Server[KT, VT any] struct {
+	db   katalis.DB[KT, VT]
+	kc   katalis.Codec[KT]
+	vc   katalis.Codec[VT]
+	mux  *http.ServeMux
+	auth *auth.Authenticator
+}
+
+// New returns a Server for db. The codecs must be the same ones the store was
+// opened with: the key codec parses keys out of URLs and the value codec
+// produces the raw byte representation of values.
+func New[KT, VT any](db katalis.DB[KT, VT], keyCodec katalis.Codec[KT], valCodec katalis.Codec[VT], opts ...Option) *Server[KT, VT] {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Server[KT, VT]{db: db, kc: keyCodec, vc: valCodec, mux: http.NewServeMux(), auth: cfg.auth}
+	s.mux.HandleFunc("GET /keys", s.handleList)
+	s.mux.HandleFunc("GET /keys/{key...}", s.handleGet)
+	s.mux.HandleFunc("PUT /keys/{key...}", s.handlePut)
+	s.mux.HandleFunc("DELETE /keys/{key...}", s.handleDel)
+	s.mux.HandleFunc("POST /batch/get", s.handleBatchGet)
+	s.mux.HandleFunc("POST /batch/put", s.handleBatchPut)
+	s.mux.HandleFunc("POST /batch/del", s.handleBatchDel)
+	return s
+}
+
+func (s *Server[KT, VT]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// httpError reports err as a JSON body so scripted clients can always parse
+// failures the same way.
+func httpError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+// statusOf maps a store error to the HTTP status it should surface as.
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, katalis.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, katalis.ErrTooLarge), errors.Is(err, katalis.ErrQuotaExceeded):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, katalis.ErrClosed):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// aclFor resolves the request's credentials, writing the 401 itself when
+// they are missing or unknown. With no authenticator configured every
+// request passes with a nil ACL.
+func (s *Server[KT, VT]) aclFor(w http.ResponseWriter, r *http.Request) (auth.ACL, bool) {
+	if s.auth == nil {
+		return nil, true
+	}
+
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == r.Header.Get("Authorization") {
+		token = ""
+	}
+	var certs []*x509.Certificate
+	if r.TLS != nil {
+		certs = r.TLS.PeerCertificates
+	}
+
+	acl, err := s.auth.Authenticate(token, certs)
+	if err != nil {
+		httpError(w, http.StatusUnauthorized, err)
+		return nil, false
+	}
+	return acl, true
+}
+
+// allowed reports whether acl grants p on key, writing the 403 when not.
+func (s *Server[KT, VT]) allowed(w http.ResponseWriter, acl auth.ACL, key []byte, p auth.Perm) bool {
+	if s.auth == nil || acl.Allows(key, p) {
+		return true
+	}
+	httpError(w, http.StatusForbidden, auth.ErrForbidden)
+	return false
+}
+
+func (s *Server[KT, VT]) key(r *http.Request) (KT, error) {
+	key, err := s.kc.Decode([]byte(r.PathValue("key")))
+	if err != nil {
+		return key, fmt.Errorf("key: %w", err)
+	}
+	return key, nil
+}
+
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func sendsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Content-Type"), "application/json")
+}
+
+func (s *Server[KT, VT]) handleGet(w http.ResponseWriter, r *http.Request) {
+	acl, ok := s.aclFor(w, r)
+	if !ok || !s.allowed(w, acl, []byte(r.PathValue("key")), auth.PermRead) {
+		return
+	}
+
+	key, err := s.key(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	has, err := s.db.Has(key)
+	if err != nil {
+		httpError(w, statusOf(err), err)
+		return
+	}
+	if !has {
+		httpError(w, http.StatusNotFound, fmt.Errorf("key %q not found", r.PathValue("key")))
+		return
+	}
+
+	val, err := s.db.Get(key)
+	if err != nil {
+		httpError(w, statusOf(err), err)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(val)
+		return
+	}
+	raw, err := s.vc.Encode(val)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(raw)
+}
+
+// value decodes a request body into a VT, honouring the Content-Type header.
+func (s *Server[KT, VT]) value(r *http.Request) (val VT, err error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return val, err
+	}
+	if sendsJSON(r) {
+		if err := json.Unmarshal(body, &val); err != nil {
+			return val, fmt.Errorf("value: %w", err)
+		}
+		return val, nil
+	}
+	if val, err = s.vc.Decode(body); err != nil {
+		return val, fmt.Errorf("value: %w", err)
+	}
+	return val, nil
+}
+
+func (s *Server[KT, VT]) handlePut(w http.ResponseWriter, r *http.Request) {
+	acl, ok := s.aclFor(w, r)
+	if !ok || !s.allowed(w, acl, []byte(r.PathValue("key")), auth.PermWrite) {
+		return
+	}
+
+	key, err := s.key(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+	val, err := s.value(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.db.Put(key, val); err != nil {
+		httpError(w, statusOf(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server[KT, VT]) handleDel(w http.ResponseWriter, r *http.Request) {
+	acl, ok := s.aclFor(w, r)
+	if !ok || !s.allowed(w, acl, []byte(r.PathValue("key")), auth.PermWrite) {
+		return
+	}
+
+	key, err := s.key(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.db.Del(key); err != nil {
+		httpError(w, statusOf(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server[KT, VT]) handleList(w http.ResponseWriter, r *http.Request) {
+	acl, ok := s.aclFor(w, r)
+	if !ok {
+		return
+	}
+
+	limit := defaultPageLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			httpError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", raw))
+			return
+		}
+		limit = n
+	}
+
+	cursor := katalis.Cursor(r.URL.Query().Get("cursor"))
+	entries, next, err := s.db.Page(cursor, limit)
+	if err != nil {
+		httpError(w, statusOf(err), err)
+		return
+	}
+
+	// Listings never fail on ACLs: entries outside the caller's readable
+	// namespaces are simply left out.
+	if s.auth != nil {
+		kept := entries[:0]
+		for _, e := range entries {
+			kb, err := s.kc.Encode(e.Key)
+			if err != nil {
+				httpError(w, http.StatusInternalServerError, err)
+				return
+			}
+			if acl.Allows(kb, auth.PermRead) {
+				kept = append(kept, e)
+			}
+		}
+		entries = kept
+	}
+
+	type item struct {
+		Key   KT `json:"key"`
+		Value VT `json:"value"`
+	}
+	resp := struct {
+		Items      []item `json:"items"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}{Items: make([]item, len(entries)), NextCursor: string(next)}
+	for i, e := range entries {
+		resp.Items[i] = item{Key: e.Key, Value: e.Value}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server[KT, VT]) handleBatchGet(w http.ResponseWriter, r *http.Request) {
+	acl, ok := s.aclFor(w, r)
+	if !ok {
+		return
+	}
+
+	var keys []KT
+	if err := json.NewDecoder(r.Body).Decode(&keys); err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	type item struct {
+		Key   KT   `json:"key"`
+		Value VT   `json:"value,omitempty"`
+		Found bool `json:"found"`
+	}
+	items := make([]item, len(keys))
+	for i, key := range keys {
+		items[i].Key = key
+		if s.auth != nil {
+			kb, err := s.kc.Encode(key)
+			if err != nil {
+				httpError(w, http.StatusBadRequest, err)
+				return
+			}
+			if !s.allowed(w, acl, kb, auth.PermRead) {
+				return
+			}
+		}
+		has, err := s.db.Has(key)
+		if err != nil {
+			httpError(w, statusOf(err), err)
+			return
+		}
+		if !has {
+			continue
+		}
+		val, err := s.db.Get(key)
+		if err != nil {
+			httpError(w, statusOf(err), err)
+			return
+		}
+		items[i].Value, items[i].Found = val, true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Items []item `json:"items"`
+	}{Items: items})
+}
+
+func (s *Server[KT, VT]) handleBatchPut(w http.ResponseWriter, r *http.Request) {
+	acl, ok := s.aclFor(w, r)
+	if !ok {
+		return
+	}
+
+	var entries []struct {
+		Key   KT `json:"key"`
+		Value VT `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	for _, e := range entries {
+		if s.auth != nil {
+			kb, err := s.kc.Encode(e.Key)
+			if err != nil {
+				httpError(w, http.StatusBadRequest, err)
+				return
+			}
+			if !s.allowed(w, acl, kb, auth.PermWrite) {
+				return
+			}
+		}
+		if err := s.db.Put(e.Key, e.Value); err != nil {
+			httpError(w, statusOf(err), err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server[KT, VT]) handleBatchDel(w http.ResponseWriter, r *http.Request) {
+	acl, ok := s.aclFor(w, r)
+	if !ok {
+		return
+	}
+
+	var keys []KT
+	if err := json.NewDecoder(r.Body).Decode(&keys); err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	for _, key := range keys {
+		if s.auth != nil {
+			kb, err := s.kc.Encode(key)
+			if err != nil {
+				httpError(w, http.StatusBadRequest, err)
+				return
+			}
+			if !s.allowed(w, acl, kb, auth.PermWrite) {
+				return
+			}
+		}
+		if err := s.db.Del(key); err != nil {
+			httpError(w, statusOf(err), err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
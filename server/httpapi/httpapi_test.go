@@ -0,0 +1,227 @@
+package httpapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/auth"
+	"github.com/NicoNex/katalis/server/httpapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) (katalis.DB[string, string], *httptest.Server) {
+	t.Helper()
+
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	srv := httptest.NewServer(httpapi.New(db, katalis.StringCodec, katalis.StringCodec))
+	t.Cleanup(srv.Close)
+	return db, srv
+}
+
+func do(t *testing.T, method, url, contentType, body string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(raw)
+}
+
+func TestPutGetDelete(t *testing.T) {
+	db, srv := newServer(t)
+
+	resp, _ := do(t, http.MethodPut, srv.URL+"/keys/name", "", "ada")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, body := do(t, http.MethodGet, srv.URL+"/keys/name", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ada", body)
+	assert.Equal(t, "application/octet-stream", resp.Header.Get("Content-Type"))
+
+	resp, _ = do(t, http.MethodDelete, srv.URL+"/keys/name", "", "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	has, err := db.Has("name")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestGetNotFound(t *testing.T) {
+	_, srv := newServer(t)
+
+	resp, body := do(t, http.MethodGet, srv.URL+"/keys/missing", "", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, body, "not found")
+}
+
+func TestJSONNegotiation(t *testing.T) {
+	db, srv := newServer(t)
+
+	// JSON write: the body is a JSON string, not raw bytes.
+	resp, _ := do(t, http.MethodPut, srv.URL+"/keys/greet", "application/json", `"hello"`)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	val, err := db.Get("greet")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", val)
+
+	// JSON read: Accept switches the response encoding.
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/keys/greet", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	httpResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+
+	raw, err := io.ReadAll(httpResp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", httpResp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `"hello"`, string(raw))
+}
+
+func TestListPagination(t *testing.T) {
+	db, srv := newServer(t)
+	for i := range 5 {
+		require.NoError(t, db.Put(fmt.Sprintf("k%d", i), "v"))
+	}
+
+	type listResponse struct {
+		Items []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"items"`
+		NextCursor string `json:"next_cursor"`
+	}
+
+	seen := make(map[string]bool)
+	cursor := ""
+	for range 5 {
+		resp, body := do(t, http.MethodGet, srv.URL+"/keys?limit=2&cursor="+cursor, "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page listResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &page))
+		for _, item := range page.Items {
+			seen[item.Key] = true
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Len(t, seen, 5)
+}
+
+func TestListBadLimit(t *testing.T) {
+	_, srv := newServer(t)
+
+	resp, body := do(t, http.MethodGet, srv.URL+"/keys?limit=nope", "", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, body, "invalid limit")
+}
+
+func TestBatch(t *testing.T) {
+	db, srv := newServer(t)
+
+	resp, _ := do(t, http.MethodPost, srv.URL+"/batch/put", "application/json",
+		`[{"key":"a","value":"1"},{"key":"b","value":"2"}]`)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, body := do(t, http.MethodPost, srv.URL+"/batch/get", "application/json", `["a","b","missing"]`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var got struct {
+		Items []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+			Found bool   `json:"found"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &got))
+	require.Len(t, got.Items, 3)
+	assert.Equal(t, "1", got.Items[0].Value)
+	assert.True(t, got.Items[1].Found)
+	assert.False(t, got.Items[2].Found)
+
+	resp, _ = do(t, http.MethodPost, srv.URL+"/batch/del", "application/json", `["a","b"]`)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	has, err := db.Has("a")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestAuth(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Put("public:doc", "hi"))
+	require.NoError(t, db.Put("private:doc", "secret"))
+
+	a := auth.New()
+	a.AllowToken("reader", auth.ACL{{Prefix: "public:", Perm: auth.PermRead}})
+	a.AllowToken("admin", auth.ACL{{Perm: auth.PermReadWrite}})
+
+	srv := httptest.NewServer(httpapi.New(db, katalis.StringCodec, katalis.StringCodec, httpapi.WithAuth(a)))
+	defer srv.Close()
+
+	authed := func(method, url, token string) *http.Response {
+		req, err := http.NewRequest(method, url, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	// No credentials.
+	resp := authed(http.MethodGet, srv.URL+"/keys/public:doc", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Read within the granted namespace.
+	resp = authed(http.MethodGet, srv.URL+"/keys/public:doc", "reader")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Read outside it, and a write with a read-only grant.
+	resp = authed(http.MethodGet, srv.URL+"/keys/private:doc", "reader")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = authed(http.MethodPut, srv.URL+"/keys/public:doc", "reader")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Admin sees and writes everything.
+	resp = authed(http.MethodPut, srv.URL+"/keys/private:doc", "admin")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Listings only show readable namespaces.
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/keys", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer reader")
+	listResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer listResp.Body.Close()
+	body, err := io.ReadAll(listResp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "public:doc")
+	assert.NotContains(t, string(body), "private:doc")
+}
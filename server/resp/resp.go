@@ -0,0 +1,449 @@
+// Package resp serves a katalis store over a subset of the Redis protocol
+// (RESP2), so any existing redis client library can read and write it. It
+// understands GET, SET, DEL, EXISTS, SCAN, TTL and the handshake commands
+// clients send on connect (PING, ECHO, COMMAND, QUIT).
+//
+// Keys never expire: TTL answers -1 for live keys and -2 for missing ones,
+// and SET rejects the EX/PX options instead of silently dropping them.
+package resp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/auth"
+)
+
+// maxBulkLen bounds a single RESP bulk string so a malformed length prefix
+// cannot make the server allocate arbitrary memory.
+const maxBulkLen = 512 << 20
+
+// maxArrayLen bounds the element count of a command array for the same
+// reason; no supported command comes anywhere near it.
+const maxArrayLen = 1024
+
+// defaultScanCount mirrors the redis default when SCAN has no COUNT option.
+const defaultScanCount = 10
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAuth makes the server demand credentials known to a before serving
+// data commands: a token sent with AUTH (the password slot of redis clients)
+// or, on TLS connections, a client certificate whose common name a
+// recognises. The resolved ACL is enforced per key.
+func WithAuth(a *auth.Authenticator) Option {
+	return func(s *Server) { s.auth = a }
+}
+
+// Server speaks RESP2 in front of a raw store.
+type Server struct {
+	db   katalis.DB[[]byte, []byte]
+	auth *auth.Authenticator
+}
+
+// New returns a Server for db.
+func New(db katalis.DB[[]byte, []byte], opts ...Option) *Server {
+	s := &Server{db: db}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Serve accepts connections on ln until it is closed, handling each
+// connection on its own goroutine.
+func (s *Server) Serve(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// ServeTLS is Serve behind TLS. Pair it with auth.ServerTLS to require and
+// verify client certificates.
+func (s *Server) ServeTLS(ln net.Listener, cfg *tls.Config) error {
+	return s.Serve(tls.NewListener(ln, cfg))
+}
+
+// session is the per-connection state: the ACL resolved from AUTH or from
+// the client certificate, if any.
+type session struct {
+	acl    auth.ACL
+	authed bool
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	var sess session
+	if tc, ok := conn.(*tls.Conn); ok && s.auth != nil {
+		// Resolve certificate identities up front so cert-only clients
+		// never need to send AUTH.
+		if tc.Handshake() == nil {
+			certs := tc.ConnectionState().PeerCertificates
+			if acl, err := s.auth.Authenticate("", certs); err == nil {
+				sess.acl, sess.authed = acl, true
+			}
+		}
+	}
+
+	br := bufio.NewReader(conn)
+	bw := bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(br)
+		if err != nil {
+			return
+		}
+		quit := s.dispatch(bw, &sess, args)
+		if err := bw.Flush(); err != nil || quit {
+			return
+		}
+	}
+}
+
+// dispatch runs one command and reports whether the connection should close.
+func (s *Server) dispatch(w *bufio.Writer, sess *session, args [][]byte) bool {
+	if len(args) == 0 {
+		writeError(w, "empty command")
+		return false
+	}
+
+	cmd := strings.ToUpper(string(args[0]))
+	if s.auth != nil && !sess.authed {
+		switch cmd {
+		case "AUTH", "PING", "ECHO", "COMMAND", "QUIT":
+		default:
+			w.WriteString("-NOAUTH Authentication required.\r\n")
+			return false
+		}
+	}
+
+	var err error
+	switch cmd {
+	case "AUTH":
+		err = s.authCmd(w, sess, args[1:])
+	case "PING":
+		err = s.ping(w, args[1:])
+	case "ECHO":
+		err = s.echo(w, args[1:])
+	case "GET":
+		err = s.get(w, sess, args[1:])
+	case "SET":
+		err = s.set(w, sess, args[1:])
+	case "DEL":
+		err = s.del(w, sess, args[1:])
+	case "EXISTS":
+		err = s.exists(w, sess, args[1:])
+	case "SCAN":
+		err = s.scan(w, sess, args[1:])
+	case "TTL", "PTTL":
+		err = s.ttl(w, sess, args[1:])
+	case "COMMAND":
+		// Clients probe COMMAND on connect; an empty reply satisfies them.
+		writeArrayHeader(w, 0)
+	case "QUIT":
+		writeSimple(w, "OK")
+		return true
+	default:
+		writeError(w, fmt.Sprintf("unknown command '%s'", args[0]))
+	}
+	if err != nil {
+		writeError(w, err.Error())
+	}
+	return false
+}
+
+// authCmd handles AUTH [user] password: the token travels in the password
+// slot, so both the one and two argument redis forms work.
+func (s *Server) authCmd(w *bufio.Writer, sess *session, args [][]byte) error {
+	if len(args) != 1 && len(args) != 2 {
+		return errors.New("wrong number of arguments for 'auth' command")
+	}
+	if s.auth == nil {
+		return errors.New("client sent AUTH, but no authentication is configured")
+	}
+
+	acl, err := s.auth.Authenticate(string(args[len(args)-1]), nil)
+	if err != nil {
+		return errors.New("invalid token")
+	}
+	sess.acl, sess.authed = acl, true
+	writeSimple(w, "OK")
+	return nil
+}
+
+// allowed reports whether the session may perform p on key.
+func (s *Server) allowed(sess *session, key []byte, p auth.Perm) bool {
+	return s.auth == nil || sess.acl.Allows(key, p)
+}
+
+func (s *Server) ping(w *bufio.Writer, args [][]byte) error {
+	if len(args) > 0 {
+		writeBulk(w, args[0])
+		return nil
+	}
+	writeSimple(w, "PONG")
+	return nil
+}
+
+func (s *Server) echo(w *bufio.Writer, args [][]byte) error {
+	if len(args) != 1 {
+		return errors.New("wrong number of arguments for 'echo' command")
+	}
+	writeBulk(w, args[0])
+	return nil
+}
+
+func (s *Server) get(w *bufio.Writer, sess *session, args [][]byte) error {
+	if len(args) != 1 {
+		return errors.New("wrong number of arguments for 'get' command")
+	}
+	if !s.allowed(sess, args[0], auth.PermRead) {
+		return auth.ErrForbidden
+	}
+	has, err := s.db.Has(args[0])
+	if err != nil {
+		return err
+	}
+	if !has {
+		writeNil(w)
+		return nil
+	}
+	val, err := s.db.Get(args[0])
+	if err != nil {
+		return err
+	}
+	writeBulk(w, val)
+	return nil
+}
+
+func (s *Server) set(w *bufio.Writer, sess *session, args [][]byte) error {
+	if len(args) < 2 {
+		return errors.New("wrong number of arguments for 'set' command")
+	}
+	if !s.allowed(sess, args[0], auth.PermWrite) {
+		return auth.ErrForbidden
+	}
+	for _, opt := range args[2:] {
+		switch strings.ToUpper(string(opt)) {
+		case "EX", "PX", "EXAT", "PXAT", "KEEPTTL":
+			return errors.New("katalis keys do not expire, SET expiration options are not supported")
+		}
+	}
+	if len(args) > 2 {
+		return errors.New("syntax error")
+	}
+	if err := s.db.Put(args[0], args[1]); err != nil {
+		return err
+	}
+	writeSimple(w, "OK")
+	return nil
+}
+
+func (s *Server) del(w *bufio.Writer, sess *session, args [][]byte) error {
+	if len(args) == 0 {
+		return errors.New("wrong number of arguments for 'del' command")
+	}
+	var n int64
+	for _, key := range args {
+		if !s.allowed(sess, key, auth.PermWrite) {
+			return auth.ErrForbidden
+		}
+		has, err := s.db.Has(key)
+		if err != nil {
+			return err
+		}
+		if !has {
+			continue
+		}
+		if err := s.db.Del(key); err != nil {
+			return err
+		}
+		n++
+	}
+	writeInt(w, n)
+	return nil
+}
+
+func (s *Server) exists(w *bufio.Writer, sess *session, args [][]byte) error {
+	if len(args) == 0 {
+		return errors.New("wrong number of arguments for 'exists' command")
+	}
+	var n int64
+	for _, key := range args {
+		if !s.allowed(sess, key, auth.PermRead) {
+			return auth.ErrForbidden
+		}
+		has, err := s.db.Has(key)
+		if err != nil {
+			return err
+		}
+		if has {
+			n++
+		}
+	}
+	writeInt(w, n)
+	return nil
+}
+
+func (s *Server) scan(w *bufio.Writer, sess *session, args [][]byte) error {
+	if len(args) == 0 {
+		return errors.New("wrong number of arguments for 'scan' command")
+	}
+
+	cursor := string(args[0])
+	if cursor == "0" {
+		// Redis starts iterations at cursor 0; katalis pages from "".
+		cursor = ""
+	}
+
+	match := ""
+	count := defaultScanCount
+	for i := 1; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return errors.New("syntax error")
+		}
+		switch strings.ToUpper(string(args[i])) {
+		case "MATCH":
+			match = string(args[i+1])
+		case "COUNT":
+			n, err := strconv.Atoi(string(args[i+1]))
+			if err != nil || n <= 0 {
+				return errors.New("value is not an integer or out of range")
+			}
+			count = n
+		default:
+			return errors.New("syntax error")
+		}
+	}
+
+	entries, next, err := s.db.Page(katalis.Cursor(cursor), count)
+	if err != nil {
+		return err
+	}
+
+	keys := make([][]byte, 0, len(entries))
+	for _, e := range entries {
+		// Keys outside the readable namespaces are left out, like an
+		// unmatched MATCH pattern.
+		if !s.allowed(sess, e.Key, auth.PermRead) {
+			continue
+		}
+		if match != "" {
+			ok, err := filepath.Match(match, string(e.Key))
+			if err != nil || !ok {
+				continue
+			}
+		}
+		keys = append(keys, e.Key)
+	}
+
+	nextCursor := string(next)
+	if nextCursor == "" {
+		nextCursor = "0"
+	}
+	writeArrayHeader(w, 2)
+	writeBulk(w, []byte(nextCursor))
+	writeArrayHeader(w, len(keys))
+	for _, key := range keys {
+		writeBulk(w, key)
+	}
+	return nil
+}
+
+func (s *Server) ttl(w *bufio.Writer, sess *session, args [][]byte) error {
+	if len(args) != 1 {
+		return errors.New("wrong number of arguments for 'ttl' command")
+	}
+	if !s.allowed(sess, args[0], auth.PermRead) {
+		return auth.ErrForbidden
+	}
+	has, err := s.db.Has(args[0])
+	if err != nil {
+		return err
+	}
+	if !has {
+		writeInt(w, -2)
+		return nil
+	}
+	writeInt(w, -1)
+	return nil
+}
+
+// readCommand reads one RESP array of bulk strings.
+func readCommand(br *bufio.Reader) ([][]byte, error) {
+	line, err := readLine(br)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		return nil, fmt.Errorf("expected array, got %q", line)
+	}
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n < 0 || n > maxArrayLen {
+		return nil, fmt.Errorf("bad array length %q", line[1:])
+	}
+
+	args := make([][]byte, n)
+	for i := range args {
+		if args[i], err = readBulk(br); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+func readBulk(br *bufio.Reader) ([]byte, error) {
+	line, err := readLine(br)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '$' {
+		return nil, fmt.Errorf("expected bulk string, got %q", line)
+	}
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n < 0 || n > maxBulkLen {
+		return nil, fmt.Errorf("bad bulk length %q", line[1:])
+	}
+
+	buf := make([]byte, n+2)
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+func readLine(br *bufio.Reader) ([]byte, error) {
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(line, "\r\n"), nil
+}
+
+func writeSimple(w *bufio.Writer, s string)  { fmt.Fprintf(w, "+%s\r\n", s) }
+func writeError(w *bufio.Writer, msg string) { fmt.Fprintf(w, "-ERR %s\r\n", msg) }
+func writeInt(w *bufio.Writer, n int64)      { fmt.Fprintf(w, ":%d\r\n", n) }
+func writeNil(w *bufio.Writer)               { w.WriteString("$-1\r\n") }
+func writeArrayHeader(w *bufio.Writer, n int) {
+	fmt.Fprintf(w, "*%d\r\n", n)
+}
+
+func writeBulk(w *bufio.Writer, b []byte) {
+	fmt.Fprintf(w, "$%d\r\n", len(b))
+	w.Write(b)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,224 @@
+package resp_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/server/auth"
+	"github.com/NicoNex/katalis/server/resp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// respConn is a minimal redis client for the tests, enough to send commands
+// and read the handful of reply types the server produces.
+type respConn struct {
+	t  *testing.T
+	c  net.Conn
+	br *bufio.Reader
+}
+
+func dial(t *testing.T) *respConn {
+	t.Helper()
+
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go resp.New(db).Serve(ln)
+	t.Cleanup(func() { ln.Close() })
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &respConn{t: t, c: conn, br: bufio.NewReader(conn)}
+}
+
+func (rc *respConn) send(args ...string) {
+	rc.t.Helper()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(a), a)
+	}
+	_, err := rc.c.Write([]byte(sb.String()))
+	require.NoError(rc.t, err)
+}
+
+// reply reads one reply and renders it as a string: simple strings and bulks
+// verbatim, integers as digits, nil as "<nil>", arrays as space-joined items.
+func (rc *respConn) reply() string {
+	rc.t.Helper()
+
+	line, err := rc.br.ReadString('\n')
+	require.NoError(rc.t, err)
+	line = strings.TrimRight(line, "\r\n")
+	require.NotEmpty(rc.t, line)
+
+	switch line[0] {
+	case '+', '-', ':':
+		return line[1:]
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		require.NoError(rc.t, err)
+		if n < 0 {
+			return "<nil>"
+		}
+		buf := make([]byte, n+2)
+		_, err = io.ReadFull(rc.br, buf)
+		require.NoError(rc.t, err)
+		return string(buf[:n])
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		require.NoError(rc.t, err)
+		items := make([]string, n)
+		for i := range items {
+			items[i] = rc.reply()
+		}
+		return strings.Join(items, " ")
+	default:
+		rc.t.Fatalf("unexpected reply %q", line)
+		return ""
+	}
+}
+
+func TestPingSetGetDel(t *testing.T) {
+	rc := dial(t)
+
+	rc.send("PING")
+	assert.Equal(t, "PONG", rc.reply())
+
+	rc.send("SET", "name", "ada")
+	assert.Equal(t, "OK", rc.reply())
+
+	rc.send("GET", "name")
+	assert.Equal(t, "ada", rc.reply())
+
+	rc.send("DEL", "name", "missing")
+	assert.Equal(t, "1", rc.reply())
+
+	rc.send("GET", "name")
+	assert.Equal(t, "<nil>", rc.reply())
+}
+
+func TestExistsAndTTL(t *testing.T) {
+	rc := dial(t)
+
+	rc.send("SET", "a", "1")
+	rc.reply()
+
+	rc.send("EXISTS", "a", "b", "a")
+	assert.Equal(t, "2", rc.reply())
+
+	rc.send("TTL", "a")
+	assert.Equal(t, "-1", rc.reply())
+
+	rc.send("TTL", "b")
+	assert.Equal(t, "-2", rc.reply())
+}
+
+func TestSetRejectsExpiration(t *testing.T) {
+	rc := dial(t)
+
+	rc.send("SET", "a", "1", "EX", "10")
+	assert.Contains(t, rc.reply(), "do not expire")
+}
+
+func TestScan(t *testing.T) {
+	rc := dial(t)
+	for i := range 5 {
+		rc.send("SET", fmt.Sprintf("user:%d", i), "v")
+		rc.reply()
+	}
+	rc.send("SET", "other", "v")
+	rc.reply()
+
+	seen := make(map[string]bool)
+	cursor := "0"
+	for {
+		rc.send("SCAN", cursor, "MATCH", "user:*", "COUNT", "2")
+		fields := strings.Fields(rc.reply())
+		require.NotEmpty(t, fields)
+		cursor = fields[0]
+		for _, key := range fields[1:] {
+			seen[key] = true
+		}
+		if cursor == "0" {
+			break
+		}
+	}
+	assert.Len(t, seen, 5)
+}
+
+func TestUnknownCommand(t *testing.T) {
+	rc := dial(t)
+
+	rc.send("FROBNICATE")
+	assert.Contains(t, rc.reply(), "unknown command")
+}
+
+func dialAuthed(t *testing.T) *respConn {
+	t.Helper()
+
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.Put([]byte("public:doc"), []byte("hi")))
+
+	a := auth.New()
+	a.AllowToken("reader", auth.ACL{{Prefix: "public:", Perm: auth.PermRead}})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go resp.New(db, resp.WithAuth(a)).Serve(ln)
+	t.Cleanup(func() { ln.Close() })
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &respConn{t: t, c: conn, br: bufio.NewReader(conn)}
+}
+
+func TestAuth(t *testing.T) {
+	rc := dialAuthed(t)
+
+	// Data commands are refused before AUTH.
+	rc.send("GET", "public:doc")
+	assert.Contains(t, rc.reply(), "NOAUTH")
+
+	rc.send("AUTH", "wrong")
+	assert.Contains(t, rc.reply(), "invalid token")
+
+	rc.send("AUTH", "reader")
+	assert.Equal(t, "OK", rc.reply())
+
+	rc.send("GET", "public:doc")
+	assert.Equal(t, "hi", rc.reply())
+
+	// The grant is read-only and scoped to public:.
+	rc.send("SET", "public:doc", "x")
+	assert.Contains(t, rc.reply(), "permission denied")
+	rc.send("GET", "private:doc")
+	assert.Contains(t, rc.reply(), "permission denied")
+}
+
+func TestOversizedArrayHeaderRejected(t *testing.T) {
+	rc := dial(t)
+
+	// A huge element count must not translate into a huge allocation: the
+	// server treats it as a protocol error and drops the connection.
+	_, err := rc.c.Write([]byte("*1000000000\r\n"))
+	require.NoError(t, err)
+
+	_, err = rc.br.ReadByte()
+	assert.ErrorIs(t, err, io.EOF)
+}
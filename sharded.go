@@ -0,0 +1,288 @@
+package katalis
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"iter"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+)
+
+// ShardRouter maps an encoded key to a shard index in [0, n). Routers must
+// be deterministic: the same key must land on the same shard across calls
+// and process restarts.
+type ShardRouter func(kb []byte, n int) int
+
+// ShardedDB distributes keys across multiple pogreb stores opened in
+// subdirectories of a common path, so writes to different shards can proceed
+// in parallel instead of contending on a single store's write lock. It
+// exposes the same operations as DB.
+type ShardedDB[KT, VT any] struct {
+	shards []DB[KT, VT]
+	route  ShardRouter
+
+	// Set between a router change and the Rebalance that makes it real, so
+	// reads can fall back to a key's old shard in the meantime.
+	rebalancing atomic.Bool
+}
+
+// OpenSharded opens or creates a DB sharded over n underlying stores in
+// subdirectories of path, routing keys by hash. The same shard count must be
+// used every time a sharded DB is reopened, otherwise keys route to the
+// wrong shard. The DB must be closed after use, by calling Close.
+func OpenSharded[KT, VT any](path string, n int, keyCodec Codec[KT], valCodec Codec[VT]) (*ShardedDB[KT, VT], error) {
+	return OpenShardedRouter(path, n, keyCodec, valCodec, hashRouter)
+}
+
+// OpenShardedRouter is OpenSharded with a caller-supplied routing function,
+// for placement policies the default hash can't express, such as keeping a
+// tenant's keys on one shard. Reopening with a different router requires a
+// Rebalance to move the records already on disk.
+func OpenShardedRouter[KT, VT any](path string, n int, keyCodec Codec[KT], valCodec Codec[VT], router ShardRouter) (*ShardedDB[KT, VT], error) {
+	if n < 1 {
+		return nil, fmt.Errorf("katalis: shard count must be positive, got %d", n)
+	}
+
+	sdb := &ShardedDB[KT, VT]{route: router}
+	for i := range n {
+		db, err := Open(filepath.Join(path, fmt.Sprintf("shard-%03d", i)), keyCodec, valCodec)
+		if err != nil {
+			sdb.Close()
+			return nil, err
+		}
+		sdb.shards = append(sdb.shards, db)
+	}
+	return sdb, nil
+}
+
+// NumShards returns the number of underlying stores.
+func (sdb *ShardedDB[KT, VT]) NumShards() int {
+	return len(sdb.shards)
+}
+
+// SetRouter swaps the routing function, the first step of an online
+// reshard: reads keep finding records at their old location until the
+// Rebalance that follows has moved them.
+func (sdb *ShardedDB[KT, VT]) SetRouter(router ShardRouter) {
+	sdb.route = router
+	sdb.rebalancing.Store(true)
+}
+
+// shard returns the store responsible for the given key.
+func (sdb *ShardedDB[KT, VT]) shard(key KT) (DB[KT, VT], error) {
+	kb, err := sdb.shards[0].keyCodec.Encode(key)
+	if err != nil {
+		return DB[KT, VT]{}, err
+	}
+	return sdb.shards[sdb.route(kb, len(sdb.shards))], nil
+}
+
+// Get returns the value for the given key stored in the DB or an empty value
+// if the key doesn't exist.
+func (sdb *ShardedDB[KT, VT]) Get(key KT) (res VT, err error) {
+	db, err := sdb.shard(key)
+	if err != nil {
+		return res, err
+	}
+	// Mid-reshard the record may still sit on the shard the previous router
+	// picked.
+	if sdb.rebalancing.Load() {
+		if has, err := db.Has(key); err == nil && !has {
+			return sdb.getAnywhere(key)
+		}
+	}
+	return db.Get(key)
+}
+
+// getAnywhere scans every shard for the key, for reads that race a
+// rebalance.
+func (sdb *ShardedDB[KT, VT]) getAnywhere(key KT) (res VT, err error) {
+	for _, db := range sdb.shards {
+		if has, err := db.Has(key); err == nil && has {
+			return db.Get(key)
+		}
+	}
+	return res, nil
+}
+
+// Put sets the value for the given key. It updates the value for the existing key.
+func (sdb *ShardedDB[KT, VT]) Put(key KT, val VT) error {
+	db, err := sdb.shard(key)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, val)
+}
+
+// Del deletes the value for the given key from the DB.
+func (sdb *ShardedDB[KT, VT]) Del(key KT) error {
+	db, err := sdb.shard(key)
+	if err != nil {
+		return err
+	}
+
+	// Mid-reshard the record may live on another shard, or on both while it
+	// is being copied: delete it wherever it is.
+	if sdb.rebalancing.Load() {
+		for _, db := range sdb.shards {
+			if err := db.Del(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return db.Del(key)
+}
+
+// Has returns true if the DB contains the given key.
+func (sdb *ShardedDB[KT, VT]) Has(key KT) (bool, error) {
+	db, err := sdb.shard(key)
+	if err != nil {
+		return false, err
+	}
+	has, err := db.Has(key)
+
+	if err == nil && !has && sdb.rebalancing.Load() {
+		for _, db := range sdb.shards {
+			if has, err = db.Has(key); err != nil || has {
+				break
+			}
+		}
+	}
+	return has, err
+}
+
+// Count returns the number of keys across all shards.
+func (sdb *ShardedDB[KT, VT]) Count() uint32 {
+	var count uint32
+	for _, db := range sdb.shards {
+		count += db.Count()
+	}
+	return count
+}
+
+// Fold iterates over all keys in every shard calling the function `fn` for
+// each key. If the function returns an error, no further keys are processed
+// and the error returned.
+func (sdb *ShardedDB[KT, VT]) Fold(fn func(key KT, val VT, err error) error) error {
+	for _, db := range sdb.shards {
+		if err := db.Fold(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Items returns an iterator over all key-value pairs in every shard. Decode
+// errors are silently skipped, allowing iteration to continue.
+func (sdb *ShardedDB[KT, VT]) Items() iter.Seq2[KT, VT] {
+	return func(yield func(KT, VT) bool) {
+		for _, db := range sdb.shards {
+			for key, val := range db.Items() {
+				if !yield(key, val) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AllItems returns an iterator over all key-value pairs in every shard with
+// error reporting.
+func (sdb *ShardedDB[KT, VT]) AllItems() iter.Seq2[Entry[KT, VT], error] {
+	return func(yield func(Entry[KT, VT], error) bool) {
+		for _, db := range sdb.shards {
+			for entry, err := range db.AllItems() {
+				if !yield(entry, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Sync commits all pending writes in every shard to stable storage.
+func (sdb *ShardedDB[KT, VT]) Sync() (err error) {
+	for _, db := range sdb.shards {
+		err = errors.Join(err, db.Sync())
+	}
+	return err
+}
+
+// Close closes every shard.
+func (sdb *ShardedDB[KT, VT]) Close() (err error) {
+	for _, db := range sdb.shards {
+		err = errors.Join(err, db.Close())
+	}
+	return err
+}
+
+// Rebalance scans every shard and moves the records the router no longer
+// places there, making a router change effective for the data already on
+// disk. It runs online: reads fall back to a record's old shard until it has
+// moved, and a record already rewritten at its new home is never overwritten
+// with the stale copy. Writes racing the move of their own key can still
+// land on the losing side, so reshard during a quiet period when possible.
+// It returns the number of records moved.
+func (sdb *ShardedDB[KT, VT]) Rebalance() (moved int, err error) {
+	sdb.rebalancing.Store(true)
+
+	for i, db := range sdb.shards {
+		// Collect first: the move deletes from the shard being scanned.
+		type record struct{ kb, vb []byte }
+		var misplaced []record
+		for kb, vb := range db.RawItems() {
+			if sdb.route(kb, len(sdb.shards)) != i {
+				misplaced = append(misplaced, record{kb: slices.Clone(kb), vb: slices.Clone(vb)})
+			}
+		}
+
+		for _, rec := range misplaced {
+			target := sdb.shards[sdb.route(rec.kb, len(sdb.shards))]
+
+			// A concurrent Put may already have written a fresher value at
+			// the new home; keep it.
+			has, err := target.DB.Has(rec.kb)
+			if err != nil {
+				return moved, err
+			}
+			if !has {
+				if err := target.rawPut(rec.kb, rec.vb); err != nil {
+					return moved, err
+				}
+			}
+			if err := db.rawDel(rec.kb); err != nil {
+				return moved, err
+			}
+			moved++
+		}
+	}
+
+	sdb.rebalancing.Store(false)
+	return moved, nil
+}
+
+// RebalanceAsync runs Rebalance in a background goroutine, reporting its
+// error on the returned channel once done.
+func (sdb *ShardedDB[KT, VT]) RebalanceAsync() <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := sdb.Rebalance()
+		done <- err
+	}()
+	return done
+}
+
+// hashRouter is the default routing function.
+func hashRouter(kb []byte, n int) int {
+	return int(fnvHash(kb) % uint32(n))
+}
+
+// fnvHash is the default key-routing hash.
+func fnvHash(b []byte) uint32 {
+	h := fnv.New32a()
+	h.Write(b)
+	return h.Sum32()
+}
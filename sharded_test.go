@@ -0,0 +1,193 @@
+package katalis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenShardedCRUD(t *testing.T) {
+	sdb, err := katalis.OpenSharded(t.TempDir(), 4, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer sdb.Close()
+
+	assert.Equal(t, 4, sdb.NumShards())
+
+	for i := range 100 {
+		require.NoError(t, sdb.Put(fmt.Sprintf("key%d", i), i))
+	}
+
+	for i := range 100 {
+		val, err := sdb.Get(fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+
+	has, err := sdb.Has("key42")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	require.NoError(t, sdb.Del("key42"))
+
+	has, err = sdb.Has("key42")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	assert.Equal(t, uint32(99), sdb.Count())
+}
+
+func TestShardedIteration(t *testing.T) {
+	sdb, err := katalis.OpenSharded(t.TempDir(), 3, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer sdb.Close()
+
+	want := map[string]int{}
+	for i := range 50 {
+		key := fmt.Sprintf("key%d", i)
+		want[key] = i
+		require.NoError(t, sdb.Put(key, i))
+	}
+
+	got := map[string]int{}
+	for key, val := range sdb.Items() {
+		got[key] = val
+	}
+	assert.Equal(t, want, got)
+
+	count := 0
+	for _, err := range sdb.AllItems() {
+		require.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, 50, count)
+
+	sum := 0
+	require.NoError(t, sdb.Fold(func(key string, val int, err error) error {
+		require.NoError(t, err)
+		sum += val
+		return nil
+	}))
+	assert.Equal(t, 49*50/2, sum)
+}
+
+func TestShardedRoutingStable(t *testing.T) {
+	dir := t.TempDir()
+
+	sdb, err := katalis.OpenSharded(dir, 4, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, sdb.Put("stable", 7))
+	require.NoError(t, sdb.Close())
+
+	sdb, err = katalis.OpenSharded(dir, 4, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer sdb.Close()
+
+	val, err := sdb.Get("stable")
+	require.NoError(t, err)
+	assert.Equal(t, 7, val)
+}
+
+func TestOpenShardedInvalidCount(t *testing.T) {
+	_, err := katalis.OpenSharded(t.TempDir(), 0, katalis.StringCodec, katalis.IntCodec)
+	assert.Error(t, err)
+}
+
+func TestOpenShardedRouter(t *testing.T) {
+	dir := t.TempDir()
+
+	// Route by first key byte so every record's placement is predictable.
+	byFirstByte := func(kb []byte, n int) int { return int(kb[0]) % n }
+
+	sdb, err := katalis.OpenShardedRouter(dir, 2, katalis.StringCodec, katalis.IntCodec, byFirstByte)
+	require.NoError(t, err)
+
+	require.NoError(t, sdb.Put("a1", 1)) // 'a' = 97, shard 1
+	require.NoError(t, sdb.Put("a2", 2))
+	require.NoError(t, sdb.Put("b1", 3)) // 'b' = 98, shard 0
+	require.NoError(t, sdb.Close())
+
+	shard0, err := katalis.Open(dir+"/shard-000", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer shard0.Close()
+	shard1, err := katalis.Open(dir+"/shard-001", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer shard1.Close()
+
+	assert.Equal(t, uint32(1), shard0.Count())
+	assert.Equal(t, uint32(2), shard1.Count())
+}
+
+func TestShardedRebalance(t *testing.T) {
+	sdb, err := katalis.OpenSharded(t.TempDir(), 3, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer sdb.Close()
+
+	for i := range 50 {
+		require.NoError(t, sdb.Put(fmt.Sprintf("key%d", i), i))
+	}
+
+	// Reshard everything onto shard 0. Until the rebalance has moved a key,
+	// reads must still find it at its old location.
+	sdb.SetRouter(func(kb []byte, n int) int { return 0 })
+
+	val, err := sdb.Get("key42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+	has, err := sdb.Has("key7")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	moved, err := sdb.Rebalance()
+	require.NoError(t, err)
+	assert.Positive(t, moved)
+
+	// A second pass finds nothing left to move.
+	moved, err = sdb.Rebalance()
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+
+	for i := range 50 {
+		val, err := sdb.Get(fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+	assert.Equal(t, uint32(50), sdb.Count())
+}
+
+func TestShardedRebalanceAsync(t *testing.T) {
+	sdb, err := katalis.OpenSharded(t.TempDir(), 2, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer sdb.Close()
+
+	for i := range 20 {
+		require.NoError(t, sdb.Put(fmt.Sprintf("key%d", i), i))
+	}
+
+	sdb.SetRouter(func(kb []byte, n int) int { return int(kb[len(kb)-1]) % n })
+	require.NoError(t, <-sdb.RebalanceAsync())
+
+	for i := range 20 {
+		val, err := sdb.Get(fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
+
+func TestShardedDelDuringRebalance(t *testing.T) {
+	sdb, err := katalis.OpenSharded(t.TempDir(), 2, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer sdb.Close()
+
+	require.NoError(t, sdb.Put("stale", 1))
+
+	// Deleting mid-reshard must reach the record wherever it still lives.
+	sdb.SetRouter(func(kb []byte, n int) int { return 0 })
+	require.NoError(t, sdb.Del("stale"))
+
+	has, err := sdb.Has("stale")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
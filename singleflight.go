@@ -0,0 +1,55 @@
+package katalis
+
+import "sync"
+
+// WithReadDedup collapses concurrent Gets for the same key into one
+// underlying read and decode, with every caller receiving the shared result.
+// Fan-out services issuing bursts of identical Gets trade one disk read for
+// hundreds without any caching involved: once the burst is over nothing is
+// retained.
+//
+// Callers of the same key share the decoded value, so with pointer-bearing
+// value types none of them may mutate it.
+func WithReadDedup() OpenOption {
+	return func(c *openConfig) { c.readDedup = true }
+}
+
+// flightGroup collapses concurrent calls per encoded key: the first caller
+// runs fn, the rest wait and share its result.
+type flightGroup[VT any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[VT]
+}
+
+// flightCall is one in-flight read, with done closed once val and err are
+// set.
+type flightCall[VT any] struct {
+	done chan struct{}
+	val  VT
+	err  error
+}
+
+func (g *flightGroup[VT]) do(key string, fn func() (VT, error)) (VT, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-c.done
+		return c.val, c.err
+	}
+
+	c := &flightCall[VT]{done: make(chan struct{})}
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall[VT])
+	}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(c.done)
+
+	return c.val, c.err
+}
@@ -0,0 +1,96 @@
+package katalis_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingIntCodec counts decodes, making read deduplication observable.
+type countingIntCodec struct {
+	decodes *atomic.Int32
+	slow    bool
+}
+
+func (c countingIntCodec) Encode(i int) ([]byte, error) { return katalis.IntCodec.Encode(i) }
+
+func (c countingIntCodec) Decode(b []byte) (int, error) {
+	c.decodes.Add(1)
+	if c.slow {
+		time.Sleep(50 * time.Millisecond)
+	}
+	return katalis.IntCodec.Decode(b)
+}
+
+func TestReadDedupCollapsesConcurrentGets(t *testing.T) {
+	var decodes atomic.Int32
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec,
+		countingIntCodec{decodes: &decodes, slow: true}, katalis.WithReadDedup())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 42))
+	decodes.Store(0)
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			v, err := db.Get("key")
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// The burst overlaps the 50ms decode, so most callers share one read.
+	assert.Less(t, decodes.Load(), int32(20))
+}
+
+func TestReadDedupDisabledByDefault(t *testing.T) {
+	var decodes atomic.Int32
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec,
+		countingIntCodec{decodes: &decodes})
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 1))
+	decodes.Store(0)
+
+	for range 5 {
+		_, err := db.Get("key")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(5), decodes.Load())
+}
+
+func TestReadDedupSequentialGets(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithReadDedup())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	v, err := db.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, err = db.Get("b")
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	// A missing key still behaves like a plain Get.
+	_, err = db.Get("missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package katalis
+
+import "os"
+
+// Snapshot writes an independent, immediately openable copy of the DB to
+// destPath while the source keeps serving writes. Pending writes are synced
+// first so the snapshot contains everything put before the call; writes that
+// land during the copy may or may not be included.
+//
+// The segment files are copied rather than hard-linked: the writer keeps
+// appending to its current segment, so sharing inodes would let later writes
+// leak into the frozen view.
+func (db DB[KT, VT]) Snapshot(destPath string) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	if err := db.DB.Sync(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(destPath, 0755); err != nil {
+		return err
+	}
+	return copyStore(db.path, destPath)
+}
@@ -0,0 +1,45 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/live.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i, key := range []string{"a", "b", "c"} {
+		require.NoError(t, db.Put(key, i))
+	}
+
+	// The source stays open and writable while the snapshot is taken.
+	require.NoError(t, db.Snapshot(dir+"/frozen.db"))
+	require.NoError(t, db.Put("d", 3))
+
+	snap, err := katalis.Open(dir+"/frozen.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer snap.Close()
+
+	val, err := snap.Get("b")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	// The write after the snapshot is only in the source.
+	has, err := snap.Has("d")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// The copy is fully independent of the source.
+	require.NoError(t, snap.Put("only-in-snap", 9))
+
+	has, err = db.Has("only-in-snap")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
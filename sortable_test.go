@@ -0,0 +1,56 @@
+package katalis_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortableInt64Codec(t *testing.T) {
+	values := []int64{math.MinInt64, -1000, -1, 0, 1, 1000, math.MaxInt64}
+
+	var prev []byte
+	for _, v := range values {
+		encoded, err := katalis.SortableInt64Codec.Encode(v)
+		require.NoError(t, err)
+		assert.Len(t, encoded, 8)
+
+		decoded, err := katalis.SortableInt64Codec.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, v, decoded)
+
+		if prev != nil {
+			assert.Negative(t, bytes.Compare(prev, encoded),
+				"encoding of %d should sort after its predecessor", v)
+		}
+		prev = encoded
+	}
+}
+
+func TestSortableFloat64Codec(t *testing.T) {
+	values := []float64{
+		math.Inf(-1), -math.MaxFloat64, -1.5, -math.SmallestNonzeroFloat64,
+		0, math.SmallestNonzeroFloat64, 1.5, math.MaxFloat64, math.Inf(1),
+	}
+
+	var prev []byte
+	for _, v := range values {
+		encoded, err := katalis.SortableFloat64Codec.Encode(v)
+		require.NoError(t, err)
+		assert.Len(t, encoded, 8)
+
+		decoded, err := katalis.SortableFloat64Codec.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, v, decoded)
+
+		if prev != nil {
+			assert.Negative(t, bytes.Compare(prev, encoded),
+				"encoding of %g should sort after its predecessor", v)
+		}
+		prev = encoded
+	}
+}
@@ -0,0 +1,58 @@
+package katalis
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+)
+
+// ItemsSorted returns an iterator over the records in lexical encoded-key
+// order, making iteration deterministic where plain Items follows pogreb's
+// randomized hash layout. Only the encoded keys are buffered and sorted up
+// front; each value is read and decoded lazily when its turn comes, so two
+// stores can be diffed side by side without collecting either into memory.
+// Keys deleted between the call and their turn are skipped, like records
+// that fail to decode.
+//
+// Key order is byte order of the encoding: use order-preserving codecs such
+// as SortableInt64Codec when it should match the natural order of the keys.
+func (db DB[KT, VT]) ItemsSorted() iter.Seq2[KT, VT] {
+	if db.closedErr() != nil {
+		return func(yield func(KT, VT) bool) {}
+	}
+
+	var keys [][]byte
+	it := db.DB.Items()
+	for {
+		kb, _, err := it.Next()
+		if isTerminate(err) || err != nil {
+			break
+		}
+		if !db.inView(kb) {
+			continue
+		}
+		keys = append(keys, slices.Clone(kb))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	return func(yield func(KT, VT) bool) {
+		for _, kb := range keys {
+			vb, err := db.DB.Get(kb)
+			if err != nil || vb == nil {
+				continue
+			}
+
+			key, err := db.keyCodec.Decode(kb)
+			if err != nil {
+				continue
+			}
+			val, err := db.valCodec.Decode(vb)
+			if err != nil {
+				continue
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsSorted(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("banana", 2))
+	require.NoError(t, db.Put("cherry", 3))
+	require.NoError(t, db.Put("apple", 1))
+
+	var keys []string
+	var vals []int
+	for k, v := range db.ItemsSorted() {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	}
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, keys)
+	assert.Equal(t, []int{1, 2, 3}, vals)
+}
+
+func TestItemsSortedSkipsDeleted(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", "one"))
+	require.NoError(t, db.Put("b", "two"))
+	require.NoError(t, db.Put("c", "three"))
+
+	// Deleting after the key buffer was captured hides the record.
+	var keys []string
+	for k := range db.ItemsSorted() {
+		if k == "a" {
+			require.NoError(t, db.Del("b"))
+		}
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"a", "c"}, keys)
+}
+
+func TestItemsSortedSortableCodec(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.SortableInt64Codec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, k := range []int64{42, -7, 0, -100, 9} {
+		require.NoError(t, db.Put(k, "v"))
+	}
+
+	var keys []int64
+	for k := range db.ItemsSorted() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int64{-100, -7, 0, 9, 42}, keys)
+}
+
+func TestItemsSortedClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Close())
+
+	for range db.ItemsSorted() {
+		t.Fatal("ItemsSorted yielded on a closed DB")
+	}
+}
@@ -0,0 +1,77 @@
+package katalis
+
+import (
+	"errors"
+	"iter"
+	"slices"
+)
+
+// rawSnapshot walks the store once and returns clones of the raw pairs
+// visible in this view, frozen at the time of the call.
+func (db DB[KT, VT]) rawSnapshot() (keys, vals [][]byte) {
+	it := db.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) || err != nil {
+			return
+		}
+		if !db.inView(kb) {
+			continue
+		}
+		keys = append(keys, slices.Clone(kb))
+		vals = append(vals, slices.Clone(vb))
+	}
+}
+
+// StableItems is Items over a point-in-time snapshot: the raw pairs are
+// captured when StableItems is called, so Puts and Dels that land during a
+// long scan can neither hide entries from it nor show them twice. The
+// snapshot is held in memory, which suits exports and audits but not stores
+// larger than RAM; values are still decoded lazily, one record at a time.
+// Like Items, records that fail to decode are silently skipped.
+func (db DB[KT, VT]) StableItems() iter.Seq2[KT, VT] {
+	if db.closedErr() != nil {
+		return func(yield func(KT, VT) bool) {}
+	}
+	keys, vals := db.rawSnapshot()
+
+	return func(yield func(KT, VT) bool) {
+		for i, kb := range keys {
+			key, err := db.keyCodec.Decode(kb)
+			if err != nil {
+				continue
+			}
+			val, err := db.valCodec.Decode(vals[i])
+			if err != nil {
+				continue
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+	}
+}
+
+// FoldStable is Fold over the same point-in-time snapshot as StableItems:
+// fn sees exactly the records that were live when FoldStable was called,
+// regardless of concurrent writes. Decode errors are passed to fn like in
+// Fold, and a non-nil error from fn stops the walk.
+func (db DB[KT, VT]) FoldStable(fn func(key KT, val VT, err error) error) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+	keys, vals := db.rawSnapshot()
+
+	for i, kb := range keys {
+		key, e := db.keyCodec.Decode(kb)
+		err := e
+
+		val, e := db.valCodec.Decode(vals[i])
+		err = errors.Join(err, e)
+
+		if err := fn(key, val, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package katalis_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStableItemsIgnoresConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+	require.NoError(t, db.Put("c", 3))
+
+	// The snapshot is frozen here; writes landing after this call must not
+	// surface in the iteration.
+	snap := db.StableItems()
+	require.NoError(t, db.Put("d", 4))
+	require.NoError(t, db.Del("b"))
+	require.NoError(t, db.Put("a", 10))
+
+	got := map[string]int{}
+	for k, v := range snap {
+		got[k] = v
+	}
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, got)
+}
+
+func TestFoldStable(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	sum := 0
+	err = db.FoldStable(func(key string, val int, err error) error {
+		require.NoError(t, err)
+		// Writes from inside the fold must not feed back into it.
+		if e := db.Put(key+"'", val*10); e != nil {
+			return e
+		}
+		sum += val
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, sum)
+
+	// The writes themselves landed in the store.
+	val, err := db.Get("a'")
+	require.NoError(t, err)
+	assert.Equal(t, 10, val)
+}
+
+func TestStableItemsTenantScoped(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Tenant("alice").Put("a", 1))
+	require.NoError(t, db.Tenant("bob").Put("b", 2))
+
+	got := map[string]int{}
+	for k, v := range db.Tenant("alice").StableItems() {
+		got[k] = v
+	}
+	assert.Equal(t, map[string]int{"a": 1}, got)
+}
+
+func TestStableItemsClosed(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Close())
+
+	count := 0
+	for range db.StableItems() {
+		count++
+	}
+	assert.Zero(t, count)
+	assert.ErrorIs(t, db.FoldStable(func(string, int, error) error { return nil }), katalis.ErrClosed)
+}
@@ -0,0 +1,50 @@
+package katalis
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of store statistics.
+type Stats struct {
+	// Records is the number of live records.
+	Records uint32
+	// FileSizeBytes is the on-disk size of the store.
+	FileSizeBytes int64
+	// ReclaimableBytes is how much a compaction would free.
+	ReclaimableBytes int64
+	// CompactionPolicy describes the active background compaction policy,
+	// empty when background compaction is disabled.
+	CompactionPolicy string
+	// NextCompaction is when the policy will be consulted next, zero when
+	// background compaction is disabled.
+	NextCompaction time.Time
+}
+
+// Stats returns a snapshot of the store's statistics, including the active
+// background compaction schedule.
+func (db DB[KT, VT]) Stats() (Stats, error) {
+	if err := db.closedErr(); err != nil {
+		return Stats{}, err
+	}
+
+	size, err := db.FileSize()
+	if err != nil {
+		return Stats{}, err
+	}
+	reclaimable, err := db.ReclaimableBytes()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	s := Stats{
+		Records:          db.Count(),
+		FileSizeBytes:    size,
+		ReclaimableBytes: reclaimable,
+	}
+	if db.compactor != nil {
+		s.CompactionPolicy = fmt.Sprint(db.compactor.policyNow())
+		_, s.NextCompaction = db.compactor.schedule()
+	}
+	return s, nil
+}
@@ -0,0 +1,75 @@
+package katalis
+
+import (
+	"iter"
+	"maps"
+	"sync"
+)
+
+// Store is the subset of DB that services should depend on, so that tests can
+// swap in a MemStore (or the scripted fake in katalistest) without touching
+// disk.
+type Store[KT, VT any] interface {
+	Get(key KT) (VT, error)
+	Put(key KT, val VT) error
+	Del(key KT) error
+	Has(key KT) (bool, error)
+	Items() iter.Seq2[KT, VT]
+}
+
+var _ Store[string, int] = DB[string, int]{}
+var _ Store[string, int] = (*MemStore[string, int])(nil)
+
+// MemStore is an in-memory Store backed by a map, safe for concurrent use.
+// It involves no codecs and no disk, which makes it the natural stand-in for
+// a DB in unit tests.
+type MemStore[KT comparable, VT any] struct {
+	mu sync.RWMutex
+	m  map[KT]VT
+}
+
+// NewMemStore returns an empty in-memory store.
+func NewMemStore[KT comparable, VT any]() *MemStore[KT, VT] {
+	return &MemStore[KT, VT]{m: make(map[KT]VT)}
+}
+
+// Get returns the value for the given key, or an empty value if the key
+// doesn't exist.
+func (s *MemStore[KT, VT]) Get(key KT) (VT, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m[key], nil
+}
+
+// Put sets the value for the given key.
+func (s *MemStore[KT, VT]) Put(key KT, val VT) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[key] = val
+	return nil
+}
+
+// Del deletes the value for the given key.
+func (s *MemStore[KT, VT]) Del(key KT) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+	return nil
+}
+
+// Has returns true if the store contains the given key.
+func (s *MemStore[KT, VT]) Has(key KT) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.m[key]
+	return ok, nil
+}
+
+// Items returns an iterator over all key-value pairs. It iterates over a
+// snapshot, so the store can be modified while ranging.
+func (s *MemStore[KT, VT]) Items() iter.Seq2[KT, VT] {
+	s.mu.RLock()
+	snapshot := maps.Clone(s.m)
+	s.mu.RUnlock()
+	return maps.All(snapshot)
+}
@@ -0,0 +1,71 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sumValues exercises a Store the way a service depending on the interface
+// would, so it runs identically against DB and MemStore.
+func sumValues(s katalis.Store[string, int]) (sum int) {
+	for _, v := range s.Items() {
+		sum += v
+	}
+	return sum
+}
+
+func TestStoreInterface(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	stores := []struct {
+		name  string
+		store katalis.Store[string, int]
+	}{
+		{"db", db},
+		{"mem", katalis.NewMemStore[string, int]()},
+	}
+
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.store
+
+			require.NoError(t, s.Put("a", 1))
+			require.NoError(t, s.Put("b", 2))
+
+			val, err := s.Get("a")
+			require.NoError(t, err)
+			assert.Equal(t, 1, val)
+
+			has, err := s.Has("b")
+			require.NoError(t, err)
+			assert.True(t, has)
+
+			assert.Equal(t, 3, sumValues(s))
+
+			require.NoError(t, s.Del("a"))
+			has, err = s.Has("a")
+			require.NoError(t, err)
+			assert.False(t, has)
+		})
+	}
+}
+
+func TestMemStoreItemsSnapshot(t *testing.T) {
+	s := katalis.NewMemStore[string, int]()
+	require.NoError(t, s.Put("a", 1))
+	require.NoError(t, s.Put("b", 2))
+
+	// Mutating while ranging must not affect the iteration.
+	for k := range s.Items() {
+		require.NoError(t, s.Del(k))
+	}
+
+	has, err := s.Has("a")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
@@ -0,0 +1,101 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// NewWriter returns a writer that streams a large value into the DB under the
+// given key, chunking it as the data arrives so the whole value is never
+// materialized in memory. The value becomes visible to readers only once
+// Close returns, and must be read back with NewReader or GetLarge.
+func (db DB[KT, VT]) NewWriter(key KT) (io.WriteCloser, error) {
+	if err := db.closedErr(); err != nil {
+		return nil, err
+	}
+
+	kb, err := db.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+
+	oldChunks, _, _ := db.largeManifest(kb)
+	return &largeWriter[KT, VT]{
+		db:        db,
+		kb:        kb,
+		buf:       make([]byte, 0, largeChunkSize),
+		oldChunks: oldChunks,
+	}, nil
+}
+
+// NewReader returns a reader that streams the large value stored under the
+// given key, or ErrNotFound if there is none. It is equivalent to GetLarge.
+func (db DB[KT, VT]) NewReader(key KT) (io.ReadCloser, error) {
+	return db.GetLarge(key)
+}
+
+// largeWriter accumulates written data into chunk-sized buffers and flushes
+// each full chunk to the DB, publishing the manifest on Close.
+type largeWriter[KT, VT any] struct {
+	db        DB[KT, VT]
+	kb        []byte
+	buf       []byte
+	chunks    uint32
+	size      uint64
+	oldChunks uint32
+	closed    bool
+}
+
+func (lw *largeWriter[KT, VT]) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		n := min(len(p), largeChunkSize-len(lw.buf))
+		lw.buf = append(lw.buf, p[:n]...)
+		p = p[n:]
+
+		if len(lw.buf) == largeChunkSize {
+			if err := lw.flush(); err != nil {
+				return written - len(p), err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close flushes any buffered data and publishes the manifest, making the
+// value visible to readers. It is idempotent.
+func (lw *largeWriter[KT, VT]) Close() error {
+	if lw.closed {
+		return nil
+	}
+	lw.closed = true
+
+	if len(lw.buf) > 0 {
+		if err := lw.flush(); err != nil {
+			return err
+		}
+	}
+
+	manifest := binary.BigEndian.AppendUint32(nil, lw.chunks)
+	manifest = binary.BigEndian.AppendUint64(manifest, lw.size)
+	if err := lw.db.rawPut(largeManifestKey(lw.kb), manifest); err != nil {
+		return err
+	}
+
+	for i := lw.chunks; i < lw.oldChunks; i++ {
+		if err := lw.db.rawDel(largeChunkKey(lw.kb, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (lw *largeWriter[KT, VT]) flush() error {
+	if err := lw.db.rawPut(largeChunkKey(lw.kb, lw.chunks), lw.buf); err != nil {
+		return err
+	}
+	lw.chunks++
+	lw.size += uint64(len(lw.buf))
+	lw.buf = lw.buf[:0]
+	return nil
+}
@@ -0,0 +1,83 @@
+package katalis_test
+
+import (
+	"io"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriterAndNewReader(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	data := make([]byte, 2<<20+77)
+	rand.New(rand.NewSource(7)).Read(data)
+
+	w, err := db.NewWriter("stream")
+	require.NoError(t, err)
+
+	// Write in small pieces to exercise buffering across chunk boundaries.
+	for chunk := range slices.Chunk(data, 64<<10) {
+		n, err := w.Write(chunk)
+		require.NoError(t, err)
+		require.Equal(t, len(chunk), n)
+	}
+	require.NoError(t, w.Close())
+
+	r, err := db.NewReader("stream")
+	require.NoError(t, err)
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestNewWriterVisibleOnlyAfterClose(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	w, err := db.NewWriter("pending")
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("data"))
+	require.NoError(t, err)
+
+	_, err = db.NewReader("pending")
+	assert.ErrorIs(t, err, katalis.ErrNotFound)
+
+	require.NoError(t, w.Close())
+
+	r, err := db.NewReader("pending")
+	require.NoError(t, err)
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), got)
+}
+
+func TestNewWriterCloseIdempotent(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := katalis.Open(dir+"/test.db", katalis.StringCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	w, err := db.NewWriter("k")
+	require.NoError(t, err)
+
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+}
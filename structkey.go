@@ -0,0 +1,152 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// StructKey returns a reflection-based codec for struct type T that encodes
+// the exported fields in declaration order. Numeric fields use fixed-width,
+// order-preserving encodings (sign bit biased for signed integers, IEEE bits
+// flipped for floats) so encoded keys sort by field precedence. String fields
+// are length-prefixed. Unexported fields are skipped.
+//
+// Supported field types are booleans, integers, floats and strings. StructKey
+// panics if T is not a struct or contains an exported field of an unsupported
+// type.
+func StructKey[T any]() StructKeyCodec[T] {
+	var t T
+
+	rt := reflect.TypeOf(t)
+	if rt == nil || rt.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("katalis: StructKey requires a struct type, got %T", t))
+	}
+
+	var fields []int
+	for i := range rt.NumField() {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if !isSupportedKeyKind(f.Type.Kind()) {
+			panic(fmt.Sprintf("katalis: StructKey field %s.%s has unsupported type %s", rt.Name(), f.Name, f.Type))
+		}
+		fields = append(fields, i)
+	}
+	return StructKeyCodec[T]{fields: fields}
+}
+
+// StructKeyCodec is the codec returned by StructKey.
+type StructKeyCodec[T any] struct {
+	fields []int
+}
+
+// Encode serializes the exported struct fields in declaration order.
+func (sc StructKeyCodec[T]) Encode(t T) ([]byte, error) {
+	var b []byte
+
+	rv := reflect.ValueOf(t)
+	for _, i := range sc.fields {
+		f := rv.Field(i)
+
+		switch f.Kind() {
+		case reflect.Bool:
+			if f.Bool() {
+				b = append(b, 1)
+			} else {
+				b = append(b, 0)
+			}
+
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			b = binary.BigEndian.AppendUint64(b, uint64(f.Int())^(1<<63))
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			b = binary.BigEndian.AppendUint64(b, f.Uint())
+
+		case reflect.Float32, reflect.Float64:
+			b = binary.BigEndian.AppendUint64(b, sortableFloatBits(f.Float()))
+
+		case reflect.String:
+			b = appendFrame(b, []byte(f.String()))
+		}
+	}
+	return b, nil
+}
+
+// Decode deserializes the exported struct fields in declaration order.
+func (sc StructKeyCodec[T]) Decode(b []byte) (t T, err error) {
+	rv := reflect.ValueOf(&t).Elem()
+	for _, i := range sc.fields {
+		f := rv.Field(i)
+
+		switch f.Kind() {
+		case reflect.Bool:
+			if len(b) < 1 {
+				return t, fmt.Errorf("katalis: truncated struct key field %d", i)
+			}
+			f.SetBool(b[0] != 0)
+			b = b[1:]
+
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if len(b) < 8 {
+				return t, fmt.Errorf("katalis: truncated struct key field %d", i)
+			}
+			f.SetInt(int64(binary.BigEndian.Uint64(b) ^ (1 << 63)))
+			b = b[8:]
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if len(b) < 8 {
+				return t, fmt.Errorf("katalis: truncated struct key field %d", i)
+			}
+			f.SetUint(binary.BigEndian.Uint64(b))
+			b = b[8:]
+
+		case reflect.Float32, reflect.Float64:
+			if len(b) < 8 {
+				return t, fmt.Errorf("katalis: truncated struct key field %d", i)
+			}
+			f.SetFloat(floatFromSortableBits(binary.BigEndian.Uint64(b)))
+			b = b[8:]
+
+		case reflect.String:
+			var frame []byte
+			if frame, b, err = readFrame(b); err != nil {
+				return t, err
+			}
+			f.SetString(string(frame))
+		}
+	}
+	return t, nil
+}
+
+func isSupportedKeyKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return true
+	}
+	return false
+}
+
+// sortableFloatBits maps a float to uint64 bits whose big-endian byte order
+// matches the numeric order of the floats.
+func sortableFloatBits(f float64) uint64 {
+	u := math.Float64bits(f)
+	if u&(1<<63) != 0 {
+		return ^u
+	}
+	return u ^ (1 << 63)
+}
+
+// floatFromSortableBits is the inverse of sortableFloatBits.
+func floatFromSortableBits(u uint64) float64 {
+	if u&(1<<63) != 0 {
+		return math.Float64frombits(u ^ (1 << 63))
+	}
+	return math.Float64frombits(^u)
+}
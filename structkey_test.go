@@ -0,0 +1,93 @@
+package katalis_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userKey struct {
+	Tenant string
+	ID     int64
+	Admin  bool
+	Score  float64
+}
+
+func TestStructKeyCodec(t *testing.T) {
+	codec := katalis.StructKey[userKey]()
+
+	tests := []struct {
+		name  string
+		input userKey
+	}{
+		{"zero", userKey{}},
+		{"simple", userKey{Tenant: "acme", ID: 42, Admin: true, Score: 1.5}},
+		{"negative id", userKey{Tenant: "acme", ID: -7, Score: -0.25}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := codec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := codec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+}
+
+func TestStructKeyCodecOrdering(t *testing.T) {
+	type key struct {
+		ID int64
+	}
+	codec := katalis.StructKey[key]()
+
+	ids := []int64{-100, -1, 0, 1, 1000}
+
+	var prev []byte
+	for _, id := range ids {
+		encoded, err := codec.Encode(key{ID: id})
+		require.NoError(t, err)
+
+		if prev != nil {
+			assert.Negative(t, bytes.Compare(prev, encoded))
+		}
+		prev = encoded
+	}
+}
+
+func TestStructKeyCodecSkipsUnexported(t *testing.T) {
+	type key struct {
+		ID     uint64
+		hidden string
+	}
+	codec := katalis.StructKey[key]()
+
+	encoded, err := codec.Encode(key{ID: 9, hidden: "x"})
+	require.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, key{ID: 9}, decoded)
+}
+
+func TestStructKeyPanicsOnUnsupported(t *testing.T) {
+	assert.Panics(t, func() { katalis.StructKey[int]() })
+
+	type bad struct {
+		Data []byte
+	}
+	assert.Panics(t, func() { katalis.StructKey[bad]() })
+}
+
+func TestStructKeyCodecTruncated(t *testing.T) {
+	codec := katalis.StructKey[userKey]()
+
+	_, err := codec.Decode([]byte{0, 1, 2})
+	assert.Error(t, err)
+}
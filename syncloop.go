@@ -0,0 +1,77 @@
+package katalis
+
+import (
+	"sync"
+	"time"
+)
+
+// syncState is the runtime-tunable background sync loop of one store.
+type syncState struct {
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// SetSyncInterval starts, retunes or stops a background loop fsyncing the
+// store every d. Long-running daemons can tighten durability during critical
+// phases and relax it again during bulk loads without reopening the store;
+// it complements the fixed open-time choice of WithDurability. A
+// non-positive d stops the loop. Each run emits EventSync like an explicit
+// Sync call.
+func (db DB[KT, VT]) SetSyncInterval(d time.Duration) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	s := db.syncer
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopLocked()
+	if d <= 0 {
+		return nil
+	}
+
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	go db.runSyncLoop(d, s.stop, s.done)
+	return nil
+}
+
+// runSyncLoop fsyncs the store every d until stop closes.
+func (db DB[KT, VT]) runSyncLoop(d time.Duration, stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			db.emit(Event{Kind: EventSync})
+			db.DB.Sync()
+		}
+	}
+}
+
+// stopSyncLoop shuts the background sync loop down. Called by Close before
+// the store goes away.
+func (db DB[KT, VT]) stopSyncLoop() {
+	if db.syncer == nil {
+		return
+	}
+	db.syncer.mu.Lock()
+	defer db.syncer.mu.Unlock()
+	db.syncer.stopLocked()
+}
+
+// stopLocked stops a running loop and waits it out. The caller must hold
+// s.mu.
+func (s *syncState) stopLocked() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop, s.done = nil, nil
+}
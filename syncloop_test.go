@@ -0,0 +1,81 @@
+package katalis_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSyncIntervalRuns(t *testing.T) {
+	var syncs atomic.Int32
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithEventHook(func(e katalis.Event) {
+			if e.Kind == katalis.EventSync {
+				syncs.Add(1)
+			}
+		}))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.SetSyncInterval(5*time.Millisecond))
+	assert.Eventually(t, func() bool { return syncs.Load() >= 2 },
+		time.Second, 5*time.Millisecond)
+
+	// A non-positive interval stops the loop.
+	require.NoError(t, db.SetSyncInterval(0))
+	n := syncs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, n, syncs.Load())
+}
+
+func TestSetSyncIntervalRetune(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.SetSyncInterval(time.Hour))
+	require.NoError(t, db.SetSyncInterval(time.Minute))
+	require.NoError(t, db.SetSyncInterval(0))
+
+	require.NoError(t, db.Close())
+	assert.ErrorIs(t, db.SetSyncInterval(time.Second), katalis.ErrClosed)
+}
+
+func TestSetSyncIntervalStoppedByClose(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+
+	require.NoError(t, db.SetSyncInterval(time.Millisecond))
+	require.NoError(t, db.Put("key", 1))
+
+	// Close must stop the loop without racing the store teardown.
+	require.NoError(t, db.Close())
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestSetCompactionInterval(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.SetCompactionInterval(time.Hour))
+
+	stats, err := db.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, "every 1h0m0s", stats.CompactionPolicy)
+
+	assert.Error(t, db.SetCompactionInterval(0))
+}
+
+func TestSetCompactionIntervalNoScheduler(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithCompactionPolicy(nil))
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.Error(t, db.SetCompactionInterval(time.Hour))
+}
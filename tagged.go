@@ -0,0 +1,252 @@
+package katalis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// Internal raw-key prefixes of the tag index records. The NUL framing keeps
+// them out of the way of ordinary textual keys.
+var (
+	tagIndexPrefix = []byte("\x00katalis.tag\x00")
+	keyTagsPrefix  = []byte("\x00katalis.tags\x00")
+)
+
+// TaggedDB wraps a DB so records can carry exact-match labels, a lighter
+// alternative to full-text search when all the filtering is by known tags.
+// PutTagged maintains a tag-to-keys index automatically, and ByTag resolves
+// a label with a single index lookup instead of a scan over the records.
+//
+// The index lives in the same store under reserved key prefixes, so the
+// wrapper assumes it owns its keys: writing records whose encoded keys start
+// with a NUL byte leads to undefined behavior.
+type TaggedDB[KT, VT any] struct {
+	db DB[KT, VT]
+	mu sync.Mutex // serializes the index read-modify-writes
+}
+
+// Tagged wraps the DB with an exact-match label index.
+func Tagged[KT, VT any](db DB[KT, VT]) *TaggedDB[KT, VT] {
+	return &TaggedDB[KT, VT]{db: db}
+}
+
+// PutTagged stores the value under key with the given tags, replacing the
+// record's previous tags: stale labels drop the key from their index, new
+// ones gain it. No tags at all untags the record.
+func (td *TaggedDB[KT, VT]) PutTagged(key KT, val VT, tags ...string) error {
+	kb, err := td.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	old, err := td.tags(kb)
+	if err != nil {
+		return err
+	}
+	if err := td.db.Put(key, val); err != nil {
+		return err
+	}
+
+	for _, tag := range old {
+		if !slices.Contains(tags, tag) {
+			if err := td.editIndex(tag, kb, false); err != nil {
+				return err
+			}
+		}
+	}
+	for _, tag := range tags {
+		if err := td.editIndex(tag, kb, true); err != nil {
+			return err
+		}
+	}
+	return td.writeTags(kb, tags)
+}
+
+// Get returns the value for the given key stored in the DB, or an empty
+// value if the key doesn't exist.
+func (td *TaggedDB[KT, VT]) Get(key KT) (VT, error) { return td.db.Get(key) }
+
+// Has returns true if the DB contains the given key.
+func (td *TaggedDB[KT, VT]) Has(key KT) (bool, error) { return td.db.Has(key) }
+
+// Del deletes the record and drops its key from every tag it carried.
+func (td *TaggedDB[KT, VT]) Del(key KT) error {
+	kb, err := td.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	old, err := td.tags(kb)
+	if err != nil {
+		return err
+	}
+	if err := td.db.Del(key); err != nil {
+		return err
+	}
+	for _, tag := range old {
+		if err := td.editIndex(tag, kb, false); err != nil {
+			return err
+		}
+	}
+	return td.writeTags(kb, nil)
+}
+
+// Close closes the underlying DB.
+func (td *TaggedDB[KT, VT]) Close() error { return td.db.Close() }
+
+// Tags returns the tags of the record, nil when the key is untagged or
+// absent.
+func (td *TaggedDB[KT, VT]) Tags(key KT) ([]string, error) {
+	kb, err := td.db.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	return td.tags(kb)
+}
+
+// ByTag returns an iterator over the keys of the records carrying the tag,
+// in encoded-key order. Keys that fail to decode are skipped.
+func (td *TaggedDB[KT, VT]) ByTag(tag string) iter.Seq[KT] {
+	return td.ByAllTags(tag)
+}
+
+// ByAllTags returns an iterator over the keys of the records carrying every
+// one of the given tags, in encoded-key order.
+func (td *TaggedDB[KT, VT]) ByAllTags(tags ...string) iter.Seq[KT] {
+	return func(yield func(KT) bool) {
+		if len(tags) == 0 {
+			return
+		}
+
+		kbs, err := td.indexOf(tags[0])
+		if err != nil {
+			return
+		}
+		for _, tag := range tags[1:] {
+			rest, err := td.indexOf(tag)
+			if err != nil {
+				return
+			}
+			kbs = slices.DeleteFunc(kbs, func(kb []byte) bool {
+				return !slices.ContainsFunc(rest, func(o []byte) bool { return slices.Equal(o, kb) })
+			})
+		}
+
+		slices.SortFunc(kbs, bytes.Compare)
+		for _, kb := range kbs {
+			key, err := td.db.keyCodec.Decode(kb)
+			if err != nil {
+				continue
+			}
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// indexOf returns the encoded keys on the tag's index record.
+func (td *TaggedDB[KT, VT]) indexOf(tag string) ([][]byte, error) {
+	raw, err := td.db.DB.Get(frameKey(tagIndexPrefix, []byte(tag)))
+	if err != nil {
+		return nil, err
+	}
+	return splitFramed(raw), nil
+}
+
+// editIndex adds kb to or removes it from the tag's index record. The caller
+// holds td.mu.
+func (td *TaggedDB[KT, VT]) editIndex(tag string, kb []byte, add bool) error {
+	ik := frameKey(tagIndexPrefix, []byte(tag))
+	raw, err := td.db.DB.Get(ik)
+	if err != nil {
+		return err
+	}
+
+	kbs := splitFramed(raw)
+	i := slices.IndexFunc(kbs, func(p []byte) bool { return slices.Equal(p, kb) })
+	switch {
+	case add && i < 0:
+		kbs = append(kbs, kb)
+	case !add && i >= 0:
+		kbs = slices.Delete(kbs, i, i+1)
+	default:
+		return nil
+	}
+
+	if len(kbs) == 0 {
+		return td.db.rawDel(ik)
+	}
+	return td.db.rawPut(ik, appendFramed(raw[:0], kbs))
+}
+
+// tags returns the tags recorded for the encoded key kb.
+func (td *TaggedDB[KT, VT]) tags(kb []byte) ([]string, error) {
+	raw, err := td.db.DB.Get(frameKey(keyTagsPrefix, kb))
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, t := range splitFramed(raw) {
+		tags = append(tags, string(t))
+	}
+	return tags, nil
+}
+
+// writeTags records the tags of the encoded key kb, deleting the record when
+// none are left. The caller holds td.mu.
+func (td *TaggedDB[KT, VT]) writeTags(kb []byte, tags []string) error {
+	tk := frameKey(keyTagsPrefix, kb)
+	if len(tags) == 0 {
+		return td.db.rawDel(tk)
+	}
+
+	bs := make([][]byte, len(tags))
+	for i, t := range tags {
+		bs[i] = []byte(t)
+	}
+	return td.db.rawPut(tk, appendFramed(nil, bs))
+}
+
+// frameKey returns the raw key of an index record: the reserved prefix
+// followed by the payload.
+func frameKey(prefix, payload []byte) []byte {
+	return append(slices.Clone(prefix), payload...)
+}
+
+// appendFramed appends the byte slices to raw, each with its length in
+// front.
+func appendFramed(raw []byte, bs [][]byte) []byte {
+	for _, b := range bs {
+		raw = binary.AppendUvarint(raw, uint64(len(b)))
+		raw = append(raw, b...)
+	}
+	return raw
+}
+
+// splitFramed parses a framed record back into its byte slices. Malformed
+// tails are dropped.
+func splitFramed(raw []byte) [][]byte {
+	var bs [][]byte
+	for len(raw) > 0 {
+		n, read := binary.Uvarint(raw)
+		if read <= 0 || uint64(len(raw)-read) < n {
+			return bs
+		}
+		bs = append(bs, slices.Clone(raw[read:read+int(n)]))
+		raw = raw[read+int(n):]
+	}
+	return bs
+}
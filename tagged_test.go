@@ -0,0 +1,86 @@
+package katalis_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTagged(t *testing.T) *katalis.TaggedDB[string, string] {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return katalis.Tagged(db)
+}
+
+func TestTaggedByTag(t *testing.T) {
+	td := newTagged(t)
+
+	require.NoError(t, td.PutTagged("a", "alpha", "prod", "eu"))
+	require.NoError(t, td.PutTagged("b", "beta", "prod", "us"))
+	require.NoError(t, td.PutTagged("c", "gamma", "staging", "eu"))
+
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(td.ByTag("prod")))
+	assert.Equal(t, []string{"a", "c"}, slices.Collect(td.ByTag("eu")))
+	assert.Empty(t, slices.Collect(td.ByTag("absent")))
+
+	// The values themselves read back as usual.
+	val, err := td.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "alpha", val)
+}
+
+func TestTaggedByAllTags(t *testing.T) {
+	td := newTagged(t)
+
+	require.NoError(t, td.PutTagged("a", "alpha", "prod", "eu"))
+	require.NoError(t, td.PutTagged("b", "beta", "prod", "us"))
+	require.NoError(t, td.PutTagged("c", "gamma", "staging", "eu"))
+
+	assert.Equal(t, []string{"a"}, slices.Collect(td.ByAllTags("prod", "eu")))
+	assert.Empty(t, slices.Collect(td.ByAllTags("prod", "staging")))
+	assert.Empty(t, slices.Collect(td.ByAllTags()))
+}
+
+func TestTaggedRetag(t *testing.T) {
+	td := newTagged(t)
+
+	require.NoError(t, td.PutTagged("a", "alpha", "prod"))
+	require.NoError(t, td.PutTagged("a", "alpha v2", "staging"))
+
+	assert.Empty(t, slices.Collect(td.ByTag("prod")))
+	assert.Equal(t, []string{"a"}, slices.Collect(td.ByTag("staging")))
+
+	tags, err := td.Tags("a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging"}, tags)
+
+	// No tags at all untags the record.
+	require.NoError(t, td.PutTagged("a", "alpha v3"))
+	assert.Empty(t, slices.Collect(td.ByTag("staging")))
+	tags, err = td.Tags("a")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestTaggedDel(t *testing.T) {
+	td := newTagged(t)
+
+	require.NoError(t, td.PutTagged("a", "alpha", "prod"))
+	require.NoError(t, td.PutTagged("b", "beta", "prod"))
+
+	require.NoError(t, td.Del("a"))
+
+	assert.Equal(t, []string{"b"}, slices.Collect(td.ByTag("prod")))
+	has, err := td.Has("a")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
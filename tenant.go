@@ -0,0 +1,146 @@
+package katalis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// Tenant returns a view of the store scoped to one tenant: every key written
+// through the view is transparently prefixed with the tenant's namespace, and
+// iteration, paging and the accounting methods only see records of that
+// tenant. Views share the underlying store, locks and limits with the parent
+// DB, so they are cheap to create and need no separate Close. Calling Tenant
+// on a view nests the namespaces.
+//
+// The root DB still iterates over every record, tenant-owned ones included,
+// with the namespace bytes visible in their keys.
+func (db DB[KT, VT]) Tenant(id string) DB[KT, VT] {
+	view := db
+	view.rawPrefix = append(slices.Clone(db.rawPrefix), tenantPrefix(id)...)
+
+	// Nested views re-wrap the root codec with the full prefix rather than
+	// stacking one wrapper per level.
+	inner := db.keyCodec
+	if tc, ok := inner.(tenantCodec[KT]); ok {
+		inner = tc.inner
+	}
+	view.keyCodec = tenantCodec[KT]{prefix: view.rawPrefix, inner: inner}
+	return view
+}
+
+// tenantPrefix returns the raw key prefix for the tenant id. The id length
+// goes in front so distinct ids can never produce overlapping namespaces,
+// whatever characters they contain.
+func tenantPrefix(id string) []byte {
+	p := binary.AppendUvarint(nil, uint64(len(id)))
+	return append(p, id...)
+}
+
+// tenantCodec prefixes every encoded key with the tenant namespace.
+type tenantCodec[T any] struct {
+	prefix []byte
+	inner  Codec[T]
+}
+
+func (c tenantCodec[T]) Encode(v T) ([]byte, error) {
+	b, err := c.inner.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(slices.Clone(c.prefix), b...), nil
+}
+
+func (c tenantCodec[T]) Decode(b []byte) (T, error) {
+	if !bytes.HasPrefix(b, c.prefix) {
+		var zero T
+		return zero, fmt.Errorf("katalis: key %x does not belong to this tenant", b)
+	}
+	return c.inner.Decode(b[len(c.prefix):])
+}
+
+// inView reports whether the raw key kb belongs to this view of the store.
+// The root DB sees everything.
+func (db DB[KT, VT]) inView(kb []byte) bool {
+	return len(db.rawPrefix) == 0 || bytes.HasPrefix(kb, db.rawPrefix)
+}
+
+// Records returns the number of records visible in this view. On the root DB
+// it counts the whole store; on a tenant view only that tenant's records.
+func (db DB[KT, VT]) Records() (uint64, error) {
+	if err := db.closedErr(); err != nil {
+		return 0, err
+	}
+
+	var n uint64
+	it := db.DB.Items()
+	for {
+		kb, _, err := it.Next()
+		if isTerminate(err) {
+			return n, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if db.inView(kb) {
+			n++
+		}
+	}
+}
+
+// UsedBytes returns the live data footprint of the records visible in this
+// view, measured the same way as the WithMaxDiskUsage quota.
+func (db DB[KT, VT]) UsedBytes() (int64, error) {
+	if err := db.closedErr(); err != nil {
+		return 0, err
+	}
+
+	var used int64
+	it := db.DB.Items()
+	for {
+		kb, vb, err := it.Next()
+		if isTerminate(err) {
+			return used, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if db.inView(kb) {
+			used += entrySize(kb, vb)
+		}
+	}
+}
+
+// Clear deletes every record visible in this view. On a tenant view it wipes
+// that tenant only; on the root DB it empties the whole store.
+func (db DB[KT, VT]) Clear() error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	// Collect the keys first: deleting while the iterator is live is not
+	// safe with the underlying engine.
+	var keys [][]byte
+	it := db.DB.Items()
+	for {
+		kb, _, err := it.Next()
+		if isTerminate(err) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if db.inView(kb) {
+			keys = append(keys, slices.Clone(kb))
+		}
+	}
+
+	for _, kb := range keys {
+		if err := db.rawDel(kb); err != nil {
+			return opErr("clear", fmt.Sprintf("%x", kb), KindIO, err)
+		}
+		db.countDel()
+	}
+	return nil
+}
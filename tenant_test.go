@@ -0,0 +1,224 @@
+package katalis_test
+
+import (
+	"maps"
+	"path/filepath"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	alice := db.Tenant("alice")
+	bob := db.Tenant("bob")
+
+	require.NoError(t, alice.Put("greeting", "hello from alice"))
+	require.NoError(t, bob.Put("greeting", "hello from bob"))
+
+	// The same key resolves independently per tenant.
+	val, err := alice.Get("greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello from alice", val)
+
+	val, err = bob.Get("greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello from bob", val)
+
+	// The key does not exist outside a tenant namespace.
+	has, err := db.Has("greeting")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// Deleting through one view leaves the other untouched.
+	require.NoError(t, alice.Del("greeting"))
+	has, err = alice.Has("greeting")
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = bob.Has("greeting")
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestTenantIteration(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	alice := db.Tenant("alice")
+	bob := db.Tenant("bob")
+
+	require.NoError(t, alice.Put("a", 1))
+	require.NoError(t, alice.Put("b", 2))
+	require.NoError(t, bob.Put("c", 3))
+
+	// Items only yields the tenant's own records, with clean keys.
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, maps.Collect(alice.Items()))
+	assert.Equal(t, map[string]int{"c": 3}, maps.Collect(bob.Items()))
+
+	// The cursor iterator is scoped the same way.
+	got := map[string]int{}
+	it := alice.Iterator()
+	defer it.Close()
+	for it.Next() {
+		got[it.Key()] = it.Value()
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, got)
+}
+
+func TestTenantPage(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	alice := db.Tenant("alice")
+	require.NoError(t, db.Tenant("bob").Put("noise", 0))
+	for i := range 5 {
+		require.NoError(t, alice.Put(string(rune('a'+i)), i))
+	}
+
+	seen := map[string]int{}
+	var cursor katalis.Cursor
+	for {
+		entries, next, err := alice.Page(cursor, 2)
+		require.NoError(t, err)
+		for _, e := range entries {
+			seen[e.Key] = e.Value
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	assert.Len(t, seen, 5)
+	assert.NotContains(t, seen, "noise")
+}
+
+func TestTenantAccounting(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	alice := db.Tenant("alice")
+	bob := db.Tenant("bob")
+
+	require.NoError(t, alice.Put("a", "one"))
+	require.NoError(t, alice.Put("b", "two"))
+	require.NoError(t, bob.Put("c", "three"))
+
+	n, err := alice.Records()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), n)
+
+	n, err = bob.Records()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+
+	// The root view accounts for every record in the store.
+	n, err = db.Records()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), n)
+
+	aliceUsed, err := alice.UsedBytes()
+	require.NoError(t, err)
+	bobUsed, err := bob.UsedBytes()
+	require.NoError(t, err)
+	total, err := db.UsedBytes()
+	require.NoError(t, err)
+	assert.Positive(t, aliceUsed)
+	assert.Positive(t, bobUsed)
+	assert.Equal(t, total, aliceUsed+bobUsed)
+}
+
+func TestTenantClear(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	alice := db.Tenant("alice")
+	bob := db.Tenant("bob")
+
+	require.NoError(t, alice.Put("a", "one"))
+	require.NoError(t, alice.Put("b", "two"))
+	require.NoError(t, bob.Put("c", "three"))
+
+	require.NoError(t, alice.Clear())
+
+	n, err := alice.Records()
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	val, err := bob.Get("c")
+	require.NoError(t, err)
+	assert.Equal(t, "three", val)
+}
+
+func TestTenantNested(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	staging := db.Tenant("acme").Tenant("staging")
+	prod := db.Tenant("acme").Tenant("prod")
+
+	require.NoError(t, staging.Put("cfg", "debug"))
+	require.NoError(t, prod.Put("cfg", "release"))
+
+	val, err := staging.Get("cfg")
+	require.NoError(t, err)
+	assert.Equal(t, "debug", val)
+
+	val, err = prod.Get("cfg")
+	require.NoError(t, err)
+	assert.Equal(t, "release", val)
+
+	// The parent tenant sees both environments' records.
+	n, err := db.Tenant("acme").Records()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), n)
+}
+
+func TestTenantIDsNeverOverlap(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	db, err := katalis.Open(dbPath, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// "a" with key "b/k" must not leak into tenant "a/b" or vice versa.
+	require.NoError(t, db.Tenant("a").Put("b/k", "outer"))
+	require.NoError(t, db.Tenant("a/b").Put("k", "inner"))
+
+	n, err := db.Tenant("a").Records()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+
+	n, err = db.Tenant("a/b").Records()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+}
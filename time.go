@@ -0,0 +1,26 @@
+package katalis
+
+import "time"
+
+// TimeCodec encodes times as big-endian nanoseconds since the Unix epoch.
+// The sign bit is flipped so that encoded keys sort chronologically as raw
+// bytes, including times before the epoch. The time zone is not preserved:
+// decoded times are in the local time zone.
+var TimeCodec = timeCodec{}
+
+// DurationCodec encodes durations as big-endian int64 nanoseconds.
+var DurationCodec = NumericCodec[time.Duration]{}
+
+type timeCodec struct{}
+
+func (tc timeCodec) Encode(t time.Time) ([]byte, error) {
+	return Uint64Codec.Encode(uint64(t.UnixNano()) ^ (1 << 63))
+}
+
+func (tc timeCodec) Decode(b []byte) (time.Time, error) {
+	u, err := Uint64Codec.Decode(b)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(u^(1<<63))), nil
+}
@@ -0,0 +1,94 @@
+package katalis_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeCodec(t *testing.T) {
+	tests := []struct {
+		name  string
+		input time.Time
+	}{
+		{"epoch", time.Unix(0, 0)},
+		{"now", time.Now()},
+		{"before epoch", time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"far future", time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := katalis.TimeCodec.Encode(tt.input)
+			require.NoError(t, err)
+			assert.Len(t, encoded, 8)
+
+			decoded, err := katalis.TimeCodec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.True(t, tt.input.Equal(decoded))
+		})
+	}
+}
+
+func TestTimeCodecSortable(t *testing.T) {
+	times := []time.Time{
+		time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(0, 0),
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var prev []byte
+	for _, tm := range times {
+		encoded, err := katalis.TimeCodec.Encode(tm)
+		require.NoError(t, err)
+
+		if prev != nil {
+			assert.Negative(t, bytes.Compare(prev, encoded),
+				"encoding of %v should sort before %v", prev, tm)
+		}
+		prev = encoded
+	}
+}
+
+func TestDurationCodec(t *testing.T) {
+	tests := []struct {
+		name  string
+		input time.Duration
+	}{
+		{"zero", 0},
+		{"second", time.Second},
+		{"negative", -time.Hour},
+		{"max", time.Duration(math.MaxInt64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := katalis.DurationCodec.Encode(tt.input)
+			require.NoError(t, err)
+			assert.Len(t, encoded, 8)
+
+			decoded, err := katalis.DurationCodec.Decode(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+}
+
+func TestNumericCodec(t *testing.T) {
+	type epochMillis int64
+	codec := katalis.Numeric[epochMillis]()
+
+	encoded, err := codec.Encode(epochMillis(1700000000000))
+	require.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, epochMillis(1700000000000), decoded)
+}
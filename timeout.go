@@ -0,0 +1,89 @@
+package katalis
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrTimeout is returned by record operations that exceeded the deadline set
+// with WithOpTimeout. The stalled disk access keeps running in the
+// background; the timeout only unblocks the caller.
+var ErrTimeout = errors.New("katalis: operation timed out")
+
+// WithOpTimeout bounds every core record operation (Get, Put, Del, Has and
+// the helpers built on them) to the given deadline, returning ErrTimeout
+// instead of hanging when the disk stalls, as SD cards and network mounts
+// like to do. Zero disables the guard; context-aware calls remain the better
+// tool where a caller can thread one through.
+func WithOpTimeout(d time.Duration) OpenOption {
+	return func(c *openConfig) { c.opTimeout = d }
+}
+
+// engineCall runs fn under the DB's operation deadline. fn keeps running
+// after a timeout, so callers must hand it arguments that outlive the pooled
+// encode buffers.
+func engineCall[T any](d time.Duration, fn func() (T, error)) (T, error) {
+	type outcome struct {
+		v   T
+		err error
+	}
+
+	ch := make(chan outcome, 1)
+	go func() {
+		v, err := fn()
+		ch <- outcome{v: v, err: err}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case o := <-ch:
+		return o.v, o.err
+	case <-timer.C:
+		var zero T
+		return zero, ErrTimeout
+	}
+}
+
+// engineGet is the engine's Get bounded by the operation deadline.
+func (db DB[KT, VT]) engineGet(kb []byte) ([]byte, error) {
+	if db.opTimeout <= 0 {
+		return db.DB.Get(kb)
+	}
+	kb = slices.Clone(kb)
+	return engineCall(db.opTimeout, func() ([]byte, error) { return db.DB.Get(kb) })
+}
+
+// enginePut is the engine's Put bounded by the operation deadline.
+func (db DB[KT, VT]) enginePut(kb, vb []byte) error {
+	if db.opTimeout <= 0 {
+		return db.DB.Put(kb, vb)
+	}
+	kb, vb = slices.Clone(kb), slices.Clone(vb)
+	_, err := engineCall(db.opTimeout, func() (struct{}, error) {
+		return struct{}{}, db.DB.Put(kb, vb)
+	})
+	return err
+}
+
+// engineDelete is the engine's Delete bounded by the operation deadline.
+func (db DB[KT, VT]) engineDelete(kb []byte) error {
+	if db.opTimeout <= 0 {
+		return db.DB.Delete(kb)
+	}
+	kb = slices.Clone(kb)
+	_, err := engineCall(db.opTimeout, func() (struct{}, error) {
+		return struct{}{}, db.DB.Delete(kb)
+	})
+	return err
+}
+
+// engineHas is the engine's Has bounded by the operation deadline.
+func (db DB[KT, VT]) engineHas(kb []byte) (bool, error) {
+	if db.opTimeout <= 0 {
+		return db.DB.Has(kb)
+	}
+	kb = slices.Clone(kb)
+	return engineCall(db.opTimeout, func() (bool, error) { return db.DB.Has(kb) })
+}
@@ -0,0 +1,55 @@
+package katalis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpTimeoutGenerousDeadline(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithOpTimeout(time.Minute))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 42))
+
+	v, err := db.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	has, err := db.Has("key")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	require.NoError(t, db.Del("key"))
+}
+
+func TestOpTimeoutExpires(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec,
+		katalis.WithOpTimeout(time.Nanosecond))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// A nanosecond deadline fires before the engine goroutine gets
+	// scheduled; retry a few times in case it wins a race once.
+	for range 50 {
+		if _, err = db.Get("key"); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, katalis.ErrTimeout)
+}
+
+func TestOpTimeoutDisabledByDefault(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", 1))
+	_, err = db.Get("key")
+	require.NoError(t, err)
+}
@@ -0,0 +1,181 @@
+// Package timeseries implements a lightweight metrics store on top of
+// katalis, for collecting numeric samples at the edge. Samples are keyed by
+// series name and an order-preserving timestamp encoding, with range reads,
+// time-bucket downsampling and retention pruning built in.
+package timeseries
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/NicoNex/katalis"
+)
+
+// Point is one sample of a series.
+type Point struct {
+	T time.Time
+	V float64
+}
+
+// Agg selects how Downsample collapses the samples of a bucket.
+type Agg int
+
+const (
+	Mean Agg = iota
+	Sum
+	Min
+	Max
+	Count
+)
+
+// Store is a time-series store backed by a katalis DB.
+type Store struct {
+	db katalis.DB[[]byte, []byte]
+}
+
+// Open opens or creates a time-series store at the given path. The store
+// must be closed after use, by calling Close.
+func Open(path string) (*Store, error) {
+	db, err := katalis.Open(path, katalis.BytesCodec, katalis.BytesCodec)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying DB.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Append records sample v for the series at time t. A series holds at most
+// one sample per nanosecond: appending at the same instant overwrites.
+func (s *Store) Append(series string, t time.Time, v float64) error {
+	return s.db.Put(sampleKey(series, t),
+		binary.BigEndian.AppendUint64(nil, math.Float64bits(v)))
+}
+
+// Range returns the samples of the series with from <= t < to, sorted by
+// time. Only the instant is stored, so timestamps come back in UTC.
+func (s *Store) Range(series string, from, to time.Time) ([]Point, error) {
+	prefix := seriesPrefix(series)
+
+	var points []Point
+	for kb, vb := range s.db.RawItems() {
+		t, ok := sampleTime(prefix, kb)
+		if !ok || t.Before(from) || !t.Before(to) {
+			continue
+		}
+		if len(vb) != 8 {
+			return nil, fmt.Errorf("timeseries: malformed sample at %v", t)
+		}
+		points = append(points, Point{
+			T: t,
+			V: math.Float64frombits(binary.BigEndian.Uint64(vb)),
+		})
+	}
+
+	slices.SortFunc(points, func(a, b Point) int { return a.T.Compare(b.T) })
+	return points, nil
+}
+
+// Downsample buckets the samples with from <= t < to into windows of the
+// given step and collapses each bucket with agg, returning one point per
+// non-empty bucket, timestamped at the bucket start and sorted by time.
+func (s *Store) Downsample(series string, from, to time.Time, step time.Duration, agg Agg) ([]Point, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("timeseries: downsample step must be positive, got %v", step)
+	}
+	samples, err := s.Range(series, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type bucket struct {
+		sum, min, max float64
+		n             int
+	}
+	buckets := make(map[time.Time]*bucket)
+	for _, p := range samples {
+		start := from.Add(p.T.Sub(from) / step * step)
+		b, ok := buckets[start]
+		if !ok {
+			b = &bucket{min: p.V, max: p.V}
+			buckets[start] = b
+		}
+		b.sum += p.V
+		b.min = math.Min(b.min, p.V)
+		b.max = math.Max(b.max, p.V)
+		b.n++
+	}
+
+	points := make([]Point, 0, len(buckets))
+	for start, b := range buckets {
+		var v float64
+		switch agg {
+		case Mean:
+			v = b.sum / float64(b.n)
+		case Sum:
+			v = b.sum
+		case Min:
+			v = b.min
+		case Max:
+			v = b.max
+		case Count:
+			v = float64(b.n)
+		default:
+			return nil, fmt.Errorf("timeseries: unknown aggregation %d", agg)
+		}
+		points = append(points, Point{T: start, V: v})
+	}
+
+	slices.SortFunc(points, func(a, b Point) int { return a.T.Compare(b.T) })
+	return points, nil
+}
+
+// Prune deletes the samples of the series older than before, returning how
+// many were dropped. Run it periodically to enforce a retention window.
+func (s *Store) Prune(series string, before time.Time) (int, error) {
+	prefix := seriesPrefix(series)
+
+	var stale [][]byte
+	for kb := range s.db.RawItems() {
+		if t, ok := sampleTime(prefix, kb); ok && t.Before(before) {
+			stale = append(stale, slices.Clone(kb))
+		}
+	}
+
+	for _, kb := range stale {
+		if err := s.db.Del(kb); err != nil {
+			return 0, err
+		}
+	}
+	return len(stale), nil
+}
+
+// seriesPrefix returns the raw key prefix of the series. The name length
+// goes in front so one series name can never prefix another.
+func seriesPrefix(series string) []byte {
+	p := binary.AppendUvarint(nil, uint64(len(series)))
+	return append(p, series...)
+}
+
+// sampleKey returns the raw key of the series' sample at time t. The
+// timestamp bits are flipped so the encoded keys compare lexically in time
+// order.
+func sampleKey(series string, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(seriesPrefix(series), uint64(t.UnixNano())^(1<<63))
+}
+
+// sampleTime parses the timestamp out of a raw key, reporting whether kb is
+// a sample of the series identified by prefix.
+func sampleTime(prefix, kb []byte) (time.Time, bool) {
+	if len(kb) != len(prefix)+8 || !slices.Equal(kb[:len(prefix)], prefix) {
+		return time.Time{}, false
+	}
+	nanos := int64(binary.BigEndian.Uint64(kb[len(prefix):]) ^ (1 << 63))
+	return time.Unix(0, nanos).UTC(), true
+}
@@ -0,0 +1,123 @@
+package timeseries_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoNex/katalis/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2026, 8, 30, 12, 0, 0, 0, time.UTC)
+
+func openStore(t *testing.T) *timeseries.Store {
+	t.Helper()
+
+	s, err := timeseries.Open(t.TempDir() + "/ts.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestAppendAndRange(t *testing.T) {
+	s := openStore(t)
+
+	// Appended out of order, read back sorted.
+	require.NoError(t, s.Append("cpu", t0.Add(2*time.Second), 0.7))
+	require.NoError(t, s.Append("cpu", t0, 0.5))
+	require.NoError(t, s.Append("cpu", t0.Add(time.Second), 0.6))
+	require.NoError(t, s.Append("mem", t0, 123))
+
+	points, err := s.Range("cpu", t0, t0.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, points, 3)
+	assert.Equal(t, []timeseries.Point{
+		{T: t0, V: 0.5},
+		{T: t0.Add(time.Second), V: 0.6},
+		{T: t0.Add(2 * time.Second), V: 0.7},
+	}, points)
+}
+
+func TestRangeBounds(t *testing.T) {
+	s := openStore(t)
+
+	for i := range 5 {
+		require.NoError(t, s.Append("cpu", t0.Add(time.Duration(i)*time.Second), float64(i)))
+	}
+
+	// from is inclusive, to is exclusive.
+	points, err := s.Range("cpu", t0.Add(time.Second), t0.Add(3*time.Second))
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Equal(t, 1.0, points[0].V)
+	assert.Equal(t, 2.0, points[1].V)
+}
+
+func TestAppendSameInstantOverwrites(t *testing.T) {
+	s := openStore(t)
+
+	require.NoError(t, s.Append("cpu", t0, 0.5))
+	require.NoError(t, s.Append("cpu", t0, 0.9))
+
+	points, err := s.Range("cpu", t0, t0.Add(time.Second))
+	require.NoError(t, err)
+	require.Len(t, points, 1)
+	assert.Equal(t, 0.9, points[0].V)
+}
+
+func TestDownsample(t *testing.T) {
+	s := openStore(t)
+
+	// Two samples per 10s bucket, plus an empty bucket in the middle.
+	for i, v := range []float64{1, 3, 0, 0, 5, 7} {
+		if v == 0 {
+			continue
+		}
+		require.NoError(t, s.Append("cpu", t0.Add(time.Duration(i)*5*time.Second), v))
+	}
+
+	points, err := s.Downsample("cpu", t0, t0.Add(time.Minute), 10*time.Second, timeseries.Mean)
+	require.NoError(t, err)
+	assert.Equal(t, []timeseries.Point{
+		{T: t0, V: 2},
+		{T: t0.Add(20 * time.Second), V: 6},
+	}, points)
+
+	points, err = s.Downsample("cpu", t0, t0.Add(time.Minute), 10*time.Second, timeseries.Max)
+	require.NoError(t, err)
+	assert.Equal(t, []timeseries.Point{
+		{T: t0, V: 3},
+		{T: t0.Add(20 * time.Second), V: 7},
+	}, points)
+
+	points, err = s.Downsample("cpu", t0, t0.Add(time.Minute), 30*time.Second, timeseries.Count)
+	require.NoError(t, err)
+	assert.Equal(t, []timeseries.Point{{T: t0, V: 4}}, points)
+
+	_, err = s.Downsample("cpu", t0, t0.Add(time.Minute), 0, timeseries.Mean)
+	assert.Error(t, err)
+}
+
+func TestPrune(t *testing.T) {
+	s := openStore(t)
+
+	for i := range 10 {
+		require.NoError(t, s.Append("cpu", t0.Add(time.Duration(i)*time.Second), float64(i)))
+	}
+	require.NoError(t, s.Append("mem", t0, 123))
+
+	n, err := s.Prune("cpu", t0.Add(5*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	points, err := s.Range("cpu", t0, t0.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, points, 5)
+	assert.Equal(t, 5.0, points[0].V)
+
+	// Other series keep their retention.
+	points, err = s.Range("mem", t0, t0.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, points, 1)
+}
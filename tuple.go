@@ -0,0 +1,144 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Pair is a composite key or value made of two components.
+type Pair[A, B any] struct {
+	A A
+	B B
+}
+
+// Triple is a composite key or value made of three components.
+type Triple[A, B, C any] struct {
+	A A
+	B B
+	C C
+}
+
+// Tuple2 returns a codec for Pair[A, B] that frames each component with a
+// big-endian length prefix, so composite keys are unambiguous regardless of
+// the inner encodings. With fixed-width inner codecs the encoded bytes
+// preserve the component-wise ordering.
+func Tuple2[A, B any](ca Codec[A], cb Codec[B]) Tuple2Codec[A, B] {
+	return Tuple2Codec[A, B]{ca: ca, cb: cb}
+}
+
+// Tuple3 is like Tuple2 but for three components.
+func Tuple3[A, B, C any](ca Codec[A], cb Codec[B], cc Codec[C]) Tuple3Codec[A, B, C] {
+	return Tuple3Codec[A, B, C]{ca: ca, cb: cb, cc: cc}
+}
+
+// Tuple2Codec is the codec returned by Tuple2.
+type Tuple2Codec[A, B any] struct {
+	ca Codec[A]
+	cb Codec[B]
+}
+
+// Encode serializes the pair as two length-prefixed frames.
+func (tc Tuple2Codec[A, B]) Encode(p Pair[A, B]) ([]byte, error) {
+	ab, err := tc.ca.Encode(p.A)
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err := tc.cb.Encode(p.B)
+	if err != nil {
+		return nil, err
+	}
+	return appendFrame(appendFrame(nil, ab), bb), nil
+}
+
+// Decode deserializes the pair from two length-prefixed frames.
+func (tc Tuple2Codec[A, B]) Decode(b []byte) (p Pair[A, B], err error) {
+	ab, rest, err := readFrame(b)
+	if err != nil {
+		return p, err
+	}
+
+	bb, _, err := readFrame(rest)
+	if err != nil {
+		return p, err
+	}
+
+	if p.A, err = tc.ca.Decode(ab); err != nil {
+		return p, err
+	}
+	p.B, err = tc.cb.Decode(bb)
+	return p, err
+}
+
+// Tuple3Codec is the codec returned by Tuple3.
+type Tuple3Codec[A, B, C any] struct {
+	ca Codec[A]
+	cb Codec[B]
+	cc Codec[C]
+}
+
+// Encode serializes the triple as three length-prefixed frames.
+func (tc Tuple3Codec[A, B, C]) Encode(t Triple[A, B, C]) ([]byte, error) {
+	ab, err := tc.ca.Encode(t.A)
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err := tc.cb.Encode(t.B)
+	if err != nil {
+		return nil, err
+	}
+
+	cb, err := tc.cc.Encode(t.C)
+	if err != nil {
+		return nil, err
+	}
+	return appendFrame(appendFrame(appendFrame(nil, ab), bb), cb), nil
+}
+
+// Decode deserializes the triple from three length-prefixed frames.
+func (tc Tuple3Codec[A, B, C]) Decode(b []byte) (t Triple[A, B, C], err error) {
+	ab, rest, err := readFrame(b)
+	if err != nil {
+		return t, err
+	}
+
+	bb, rest, err := readFrame(rest)
+	if err != nil {
+		return t, err
+	}
+
+	cb, _, err := readFrame(rest)
+	if err != nil {
+		return t, err
+	}
+
+	if t.A, err = tc.ca.Decode(ab); err != nil {
+		return t, err
+	}
+	if t.B, err = tc.cb.Decode(bb); err != nil {
+		return t, err
+	}
+	t.C, err = tc.cc.Decode(cb)
+	return t, err
+}
+
+// appendFrame appends b to dst prefixed with its big-endian uint32 length.
+func appendFrame(dst, b []byte) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(b)))
+	return append(dst, b...)
+}
+
+// readFrame reads a length-prefixed frame from b and returns the frame
+// contents along with the remaining bytes.
+func readFrame(b []byte) (frame, rest []byte, err error) {
+	if len(b) < 4 {
+		return nil, nil, fmt.Errorf("katalis: truncated frame header: %d bytes", len(b))
+	}
+
+	size := binary.BigEndian.Uint32(b)
+	if uint32(len(b)-4) < size {
+		return nil, nil, fmt.Errorf("katalis: truncated frame: want %d bytes, have %d", size, len(b)-4)
+	}
+	return b[4 : 4+size], b[4+size:], nil
+}
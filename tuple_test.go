@@ -0,0 +1,86 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTuple2Codec(t *testing.T) {
+	codec := katalis.Tuple2(katalis.StringCodec, katalis.Uint64Codec)
+
+	tests := []struct {
+		name  string
+		input katalis.Pair[string, uint64]
+	}{
+		{"zero", katalis.Pair[string, uint64]{}},
+		{"simple", katalis.Pair[string, uint64]{A: "tenant-1", B: 42}},
+		{"empty first", katalis.Pair[string, uint64]{A: "", B: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := codec.Encode(tt.input)
+			require.NoError(t, err)
+
+			decoded, err := codec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.input, decoded)
+		})
+	}
+}
+
+func TestTuple2CodecUnambiguous(t *testing.T) {
+	codec := katalis.Tuple2(katalis.StringCodec, katalis.StringCodec)
+
+	// Pairs whose naive concatenation would collide must encode differently.
+	a, err := codec.Encode(katalis.Pair[string, string]{A: "ab", B: "c"})
+	require.NoError(t, err)
+
+	b, err := codec.Encode(katalis.Pair[string, string]{A: "a", B: "bc"})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a, b)
+}
+
+func TestTuple3Codec(t *testing.T) {
+	codec := katalis.Tuple3(katalis.StringCodec, katalis.Uint64Codec, katalis.StringCodec)
+	input := katalis.Triple[string, uint64, string]{A: "eu-west", B: 99, C: "user"}
+
+	encoded, err := codec.Encode(input)
+	require.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, input, decoded)
+}
+
+func TestTupleCodecTruncated(t *testing.T) {
+	codec := katalis.Tuple2(katalis.StringCodec, katalis.StringCodec)
+
+	_, err := codec.Decode([]byte{0, 0})
+	assert.Error(t, err)
+
+	_, err = codec.Decode([]byte{0, 0, 0, 5, 'a'})
+	assert.Error(t, err)
+}
+
+func TestTuple2CodecInDB(t *testing.T) {
+	dir := t.TempDir()
+	keyCodec := katalis.Tuple2(katalis.StringCodec, katalis.Uint64Codec)
+
+	db, err := katalis.Open(dir+"/test.db", keyCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	key := katalis.Pair[string, uint64]{A: "tenant-1", B: 42}
+	require.NoError(t, db.Put(key, "alice"))
+
+	val, err := db.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", val)
+}
@@ -0,0 +1,300 @@
+package katalis
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/akrylysov/pogreb"
+)
+
+// Tx runs fn inside a crash-safe transaction. Writes staged through the Tx
+// become visible atomically: on commit they are first recorded in a
+// write-ahead log next to the store, then applied and synced, and an
+// interrupted commit is replayed the next time the DB is opened. If fn
+// returns an error the staged writes are discarded and the error returned.
+//
+// Transactions are serialized with each other, so reads inside fn observe a
+// state no other transaction is mutating concurrently. Writes done with
+// plain Put and Del bypass this serialization.
+func (db DB[KT, VT]) Tx(fn func(tx *Tx[KT, VT]) error) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+
+	tx := &Tx[KT, VT]{db: db, staged: make(map[string]stagedOp)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.commit()
+}
+
+// Tx stages the reads and writes of a single transaction. It is only valid
+// within the callback passed to DB.Tx.
+type Tx[KT, VT any] struct {
+	db     DB[KT, VT]
+	staged map[string]stagedOp
+}
+
+// stagedOp is a pending write: either an encoded value or a deletion.
+type stagedOp struct {
+	val []byte
+	del bool
+}
+
+// Get returns the value for the given key as it would be after the
+// transaction committed.
+func (tx *Tx[KT, VT]) Get(key KT) (res VT, err error) {
+	kb, err := tx.db.keyCodec.Encode(key)
+	if err != nil {
+		return res, err
+	}
+
+	if op, ok := tx.staged[string(kb)]; ok {
+		if op.del {
+			return res, nil
+		}
+		return tx.db.valCodec.Decode(op.val)
+	}
+	return tx.db.Get(key)
+}
+
+// Has returns true if the key would exist after the transaction committed.
+func (tx *Tx[KT, VT]) Has(key KT) (bool, error) {
+	kb, err := tx.db.keyCodec.Encode(key)
+	if err != nil {
+		return false, err
+	}
+
+	if op, ok := tx.staged[string(kb)]; ok {
+		return !op.del, nil
+	}
+	return tx.db.Has(key)
+}
+
+// Put stages the value for the given key to be written on commit.
+func (tx *Tx[KT, VT]) Put(key KT, val VT) error {
+	kb, err := tx.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	vb, err := tx.db.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+	tx.staged[string(kb)] = stagedOp{val: vb}
+	return nil
+}
+
+// Del stages the deletion of the given key to be applied on commit.
+func (tx *Tx[KT, VT]) Del(key KT) error {
+	kb, err := tx.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+	tx.staged[string(kb)] = stagedOp{del: true}
+	return nil
+}
+
+// WAL record tags. A log without the trailing commit tag is an interrupted
+// write and is discarded on replay.
+const (
+	walTagPut    = 1
+	walTagDel    = 2
+	walTagCommit = 0xff
+)
+
+// walPath returns the location of the transaction write-ahead log. It lives
+// next to the store directory rather than inside it, so pogreb's own crash
+// recovery never touches it.
+func walPath(path string) string {
+	return path + ".wal"
+}
+
+// commit durably records the staged writes in the WAL, applies them to the
+// store and removes the log.
+func (tx *Tx[KT, VT]) commit() error {
+	if len(tx.staged) == 0 {
+		return nil
+	}
+	// Reject a degraded store before the WAL is written, so the blocked
+	// writes are not replayed behind the caller's back on the next open.
+	if err := tx.db.degradedErr(); err != nil {
+		return err
+	}
+	// Validate the quota against the whole staged set for the same reason: a
+	// commit rejected halfway through would leave its WAL behind.
+	if err := tx.quotaErr(); err != nil {
+		return err
+	}
+
+	var buf []byte
+	for key, op := range tx.staged {
+		if op.del {
+			buf = appendFrame(append(buf, walTagDel), []byte(key))
+			continue
+		}
+		buf = appendFrame(append(buf, walTagPut), []byte(key))
+		buf = appendFrame(buf, op.val)
+	}
+	buf = append(buf, walTagCommit)
+
+	wal := walPath(tx.db.path)
+	if err := writeFileSync(wal, buf); err != nil {
+		return err
+	}
+
+	if err := tx.db.applyStaged(tx.staged); err != nil {
+		return err
+	}
+
+	if err := tx.db.DB.Sync(); err != nil {
+		return err
+	}
+	return os.Remove(wal)
+}
+
+// replayWAL reapplies a committed WAL left behind by an interrupted commit.
+// Incomplete logs are discarded.
+func replayWAL(path string, pg *pogreb.DB) error {
+	b, err := os.ReadFile(walPath(path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	staged, complete := parseWAL(b)
+	if complete {
+		if err := applyWAL(pg, staged); err != nil {
+			return err
+		}
+		if err := pg.Sync(); err != nil {
+			return err
+		}
+	}
+	return os.Remove(walPath(path))
+}
+
+// parseWAL decodes the staged operations of a WAL, reporting whether the log
+// carries the commit tag.
+func parseWAL(b []byte) (staged map[string]stagedOp, complete bool) {
+	staged = make(map[string]stagedOp)
+	for len(b) > 0 {
+		tag := b[0]
+		b = b[1:]
+
+		if tag == walTagCommit {
+			return staged, true
+		}
+
+		key, rest, err := readFrame(b)
+		if err != nil {
+			return staged, false
+		}
+		b = rest
+
+		switch tag {
+		case walTagPut:
+			val, rest, err := readFrame(b)
+			if err != nil {
+				return staged, false
+			}
+			b = rest
+			staged[string(key)] = stagedOp{val: val}
+
+		case walTagDel:
+			staged[string(key)] = stagedOp{del: true}
+
+		default:
+			return staged, false
+		}
+	}
+	return staged, false
+}
+
+// quotaErr reports whether committing the staged set would push the store
+// past its disk usage quota.
+func (tx *Tx[KT, VT]) quotaErr() error {
+	q := tx.db.quota
+	if q == nil {
+		return nil
+	}
+
+	var delta int64
+	for key, op := range tx.staged {
+		if op.del {
+			delta -= tx.db.deleteFootprint([]byte(key))
+			continue
+		}
+		delta += tx.db.quotaDelta([]byte(key), op.val)
+	}
+	if q.used.Load()+delta > q.limit {
+		return opErr("tx", "", KindQuota,
+			fmt.Errorf("%d bytes in use, limit is %d", q.used.Load(), q.limit))
+	}
+	return nil
+}
+
+// applyStaged applies the staged operations of a committed transaction
+// through the shared write path, so the store's bookkeeping sees them.
+// Deletions go first: with the quota validated against the whole set, no
+// interleaving of the puts can then trip it on a transient overshoot.
+func (db DB[KT, VT]) applyStaged(staged map[string]stagedOp) error {
+	for key, op := range staged {
+		if op.del {
+			if err := db.rawDel([]byte(key)); err != nil {
+				return err
+			}
+		}
+	}
+	for key, op := range staged {
+		if !op.del {
+			if err := db.rawPut([]byte(key), op.val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyWAL applies staged operations to the raw engine. It runs during open,
+// before the bloom filter and quota are seeded from the store, so it does not
+// go through the shared write path.
+func applyWAL(pg *pogreb.DB, staged map[string]stagedOp) error {
+	for key, op := range staged {
+		if op.del {
+			if err := pg.Delete([]byte(key)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := pg.Put([]byte(key), op.val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFileSync writes b to path and syncs the file to stable storage.
+func writeFileSync(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("katalis: syncing WAL: %w", err)
+	}
+	return f.Close()
+}
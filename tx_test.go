@@ -0,0 +1,157 @@
+package katalis_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxCommit(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("balance:a", 100))
+	require.NoError(t, db.Put("balance:b", 0))
+
+	err = db.Tx(func(tx *katalis.Tx[string, int]) error {
+		a, err := tx.Get("balance:a")
+		if err != nil {
+			return err
+		}
+		if err := tx.Put("balance:a", a-30); err != nil {
+			return err
+		}
+		return tx.Put("balance:b", 30)
+	})
+	require.NoError(t, err)
+
+	a, err := db.Get("balance:a")
+	require.NoError(t, err)
+	assert.Equal(t, 70, a)
+
+	b, err := db.Get("balance:b")
+	require.NoError(t, err)
+	assert.Equal(t, 30, b)
+
+	// The WAL is removed once the commit is fully applied.
+	assert.NoFileExists(t, path+".wal")
+}
+
+func TestTxRollbackOnError(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("k", 1))
+
+	boom := errors.New("boom")
+	err = db.Tx(func(tx *katalis.Tx[string, int]) error {
+		if err := tx.Put("k", 2); err != nil {
+			return err
+		}
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+
+	val, err := db.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func TestTxReadsSeeStagedWrites(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("k", 1))
+
+	err = db.Tx(func(tx *katalis.Tx[string, int]) error {
+		require.NoError(t, tx.Put("k", 2))
+
+		val, err := tx.Get("k")
+		require.NoError(t, err)
+		assert.Equal(t, 2, val)
+
+		require.NoError(t, tx.Del("k"))
+
+		has, err := tx.Has("k")
+		require.NoError(t, err)
+		assert.False(t, has)
+		return nil
+	})
+	require.NoError(t, err)
+
+	has, err := db.Has("k")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestTxWALReplayedOnOpen(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("stale", "old"))
+	require.NoError(t, db.Close())
+
+	// Simulate a crash after the WAL was committed but before it was applied
+	// by planting a committed WAL next to the store.
+	wal := buildCommittedWAL(t, "stale", "new")
+	require.NoError(t, os.WriteFile(path+".wal", wal, 0644))
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	val, err := db.Get("stale")
+	require.NoError(t, err)
+	assert.Equal(t, "new", val)
+	assert.NoFileExists(t, path+".wal")
+}
+
+func TestTxIncompleteWALDiscarded(t *testing.T) {
+	path := t.TempDir() + "/test.db"
+
+	db, err := katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Put("k", "committed"))
+	require.NoError(t, db.Close())
+
+	// A WAL without the trailing commit tag is an interrupted write.
+	wal := buildCommittedWAL(t, "k", "uncommitted")
+	require.NoError(t, os.WriteFile(path+".wal", wal[:len(wal)-1], 0644))
+
+	db, err = katalis.Open(path, katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	val, err := db.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, "committed", val)
+	assert.NoFileExists(t, path+".wal")
+}
+
+// buildCommittedWAL builds the WAL bytes a committed single-put transaction
+// would leave behind.
+func buildCommittedWAL(t *testing.T, key, val string) []byte {
+	t.Helper()
+
+	var b []byte
+	b = append(b, 1) // put tag
+	b = append(b, 0, 0, 0, byte(len(key)))
+	b = append(b, key...)
+	b = append(b, 0, 0, 0, byte(len(val)))
+	b = append(b, val...)
+	return append(b, 0xff) // commit tag
+}
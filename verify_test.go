@@ -0,0 +1,60 @@
+package katalis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCleanStore(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range 10 {
+		require.NoError(t, db.Put(string(rune('a'+i)), i))
+	}
+
+	report, err := db.Verify(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, report.Ok())
+	assert.Equal(t, uint64(10), report.Records)
+	assert.Zero(t, report.Corrupted)
+	assert.Empty(t, report.Errors)
+}
+
+func TestVerifyReportsUndecodableRecords(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("good", 1))
+
+	// Plant a record whose value cannot decode as int.
+	require.NoError(t, db.DB.Put([]byte("bad"), []byte{1, 2}))
+
+	report, err := db.Verify(context.Background())
+	require.NoError(t, err)
+
+	assert.False(t, report.Ok())
+	assert.Equal(t, uint64(2), report.Records)
+	assert.Equal(t, uint64(1), report.Corrupted)
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0].Error(), "626164") // hex of "bad"
+}
+
+func TestVerifyCanceled(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = db.Verify(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,125 @@
+package katalis
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrConflict is returned by PutIfVersion when the record's version changed
+// since it was read.
+var ErrConflict = errors.New("katalis: version conflict")
+
+// VersionedDB wraps a DB so every record carries a monotonically increasing
+// version, enabling optimistic concurrency control: read a value with
+// GetVersioned, then write it back with PutIfVersion, which fails with
+// ErrConflict if someone else updated the record in between.
+//
+// The version is stored as an 8-byte prefix of the record, so records written
+// through the wrapper must also be read through it.
+type VersionedDB[KT, VT any] struct {
+	db DB[KT, VT]
+	mu sync.Mutex // serializes the check-and-set in PutIfVersion
+}
+
+// Versioned wraps the DB with per-record optimistic versioning.
+func Versioned[KT, VT any](db DB[KT, VT]) *VersionedDB[KT, VT] {
+	return &VersionedDB[KT, VT]{db: db}
+}
+
+// GetVersioned returns the value and the version of the record, or version 0
+// with the zero value if the key doesn't exist.
+func (v *VersionedDB[KT, VT]) GetVersioned(key KT) (res VT, version uint64, err error) {
+	kb, err := v.db.keyCodec.Encode(key)
+	if err != nil {
+		return res, 0, err
+	}
+	return v.get(kb)
+}
+
+// Get returns the value for the given key, discarding the version.
+func (v *VersionedDB[KT, VT]) Get(key KT) (VT, error) {
+	res, _, err := v.GetVersioned(key)
+	return res, err
+}
+
+// Put writes the value unconditionally, bumping the record's version.
+func (v *VersionedDB[KT, VT]) Put(key KT, val VT) error {
+	kb, err := v.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	_, version, err := v.get(kb)
+	if err != nil {
+		return err
+	}
+	return v.put(kb, val, version+1)
+}
+
+// PutIfVersion writes the value only if the record still has the given
+// version, returning ErrConflict otherwise. Use version 0 to create a record
+// only if the key doesn't exist yet.
+func (v *VersionedDB[KT, VT]) PutIfVersion(key KT, val VT, version uint64) error {
+	kb, err := v.db.keyCodec.Encode(key)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	_, current, err := v.get(kb)
+	if err != nil {
+		return err
+	}
+	if current != version {
+		return fmt.Errorf("%w: have version %d, want %d", ErrConflict, current, version)
+	}
+	return v.put(kb, val, current+1)
+}
+
+// Del deletes the record for the given key.
+func (v *VersionedDB[KT, VT]) Del(key KT) error {
+	return v.db.Del(key)
+}
+
+// Has returns true if the DB contains the given key.
+func (v *VersionedDB[KT, VT]) Has(key KT) (bool, error) {
+	return v.db.Has(key)
+}
+
+// Close closes the underlying DB.
+func (v *VersionedDB[KT, VT]) Close() error {
+	return v.db.Close()
+}
+
+func (v *VersionedDB[KT, VT]) get(kb []byte) (res VT, version uint64, err error) {
+	has, err := v.db.DB.Has(kb)
+	if err != nil || !has {
+		return res, 0, err
+	}
+
+	b, err := v.db.DB.Get(kb)
+	if err != nil {
+		return res, 0, err
+	}
+	if len(b) < 8 {
+		return res, 0, fmt.Errorf("katalis: record too short for version prefix: %d bytes", len(b))
+	}
+
+	res, err = v.db.valCodec.Decode(b[8:])
+	return res, binary.BigEndian.Uint64(b), err
+}
+
+func (v *VersionedDB[KT, VT]) put(kb []byte, val VT, version uint64) error {
+	vb, err := v.db.valCodec.Encode(val)
+	if err != nil {
+		return err
+	}
+	return v.db.rawPut(kb, append(binary.BigEndian.AppendUint64(nil, version), vb...))
+}
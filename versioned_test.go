@@ -0,0 +1,81 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openVersioned(t *testing.T) *katalis.VersionedDB[string, string] {
+	t.Helper()
+
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+
+	v := katalis.Versioned(db)
+	t.Cleanup(func() { v.Close() })
+	return v
+}
+
+func TestVersionedPutAndGet(t *testing.T) {
+	v := openVersioned(t)
+
+	require.NoError(t, v.Put("k", "one"))
+
+	val, version, err := v.GetVersioned("k")
+	require.NoError(t, err)
+	assert.Equal(t, "one", val)
+	assert.Equal(t, uint64(1), version)
+
+	require.NoError(t, v.Put("k", "two"))
+
+	val, version, err = v.GetVersioned("k")
+	require.NoError(t, err)
+	assert.Equal(t, "two", val)
+	assert.Equal(t, uint64(2), version)
+}
+
+func TestVersionedMissingKey(t *testing.T) {
+	v := openVersioned(t)
+
+	val, version, err := v.GetVersioned("missing")
+	require.NoError(t, err)
+	assert.Empty(t, val)
+	assert.Zero(t, version)
+}
+
+func TestPutIfVersion(t *testing.T) {
+	v := openVersioned(t)
+
+	// Version 0 creates the record only if absent.
+	require.NoError(t, v.PutIfVersion("k", "first", 0))
+	assert.ErrorIs(t, v.PutIfVersion("k", "again", 0), katalis.ErrConflict)
+
+	_, version, err := v.GetVersioned("k")
+	require.NoError(t, err)
+
+	require.NoError(t, v.PutIfVersion("k", "second", version))
+
+	// The stale version now conflicts.
+	assert.ErrorIs(t, v.PutIfVersion("k", "stale", version), katalis.ErrConflict)
+
+	val, err := v.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, "second", val)
+}
+
+func TestVersionedDel(t *testing.T) {
+	v := openVersioned(t)
+
+	require.NoError(t, v.Put("k", "v"))
+	require.NoError(t, v.Del("k"))
+
+	has, err := v.Has("k")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// After deletion the record can be recreated from version 0.
+	require.NoError(t, v.PutIfVersion("k", "fresh", 0))
+}
@@ -0,0 +1,29 @@
+package katalis
+
+// View calls fn with the raw stored bytes of key, bypassing the value codec
+// entirely. Useful when the value only needs to be hashed or forwarded and
+// decoding it would be wasted work. The slice must not be retained or
+// modified after fn returns. If the key does not exist View returns an Error
+// of kind KindNotFound without calling fn.
+func (db DB[KT, VT]) View(key KT, fn func(raw []byte) error) error {
+	if err := db.closedErr(); err != nil {
+		return err
+	}
+
+	// Encode the key to []byte.
+	kbuf := getBuf()
+	defer putBuf(kbuf)
+	kb, err := encodeInto(db.keyCodec, kbuf, key)
+	if err != nil {
+		return opErr("view", key, KindEncodeKey, err)
+	}
+
+	b, err := db.DB.Get(kb)
+	if err != nil {
+		return opErr("view", key, KindIO, err)
+	}
+	if b == nil {
+		return opErr("view", key, KindNotFound, nil)
+	}
+	return fn(b)
+}
@@ -0,0 +1,50 @@
+package katalis_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestView(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", "value"))
+
+	var raw []byte
+	require.NoError(t, db.View("key", func(b []byte) error {
+		raw = append(raw, b...)
+		return nil
+	}))
+	assert.Equal(t, []byte("value"), raw)
+}
+
+func TestViewMissingKey(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	called := false
+	err = db.View("missing", func(b []byte) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, katalis.ErrNotFound)
+	assert.False(t, called)
+}
+
+func TestViewPropagatesFnError(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.StringCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("key", "value"))
+
+	boom := errors.New("boom")
+	assert.ErrorIs(t, db.View("key", func([]byte) error { return boom }), boom)
+}
@@ -0,0 +1,72 @@
+package katalis_test
+
+import (
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarm(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	warmed, err := db.Warm([]string{"a", "b", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, warmed)
+}
+
+func TestWarmPrefix(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("user:1", 1))
+	require.NoError(t, db.Put("user:2", 2))
+	require.NoError(t, db.Put("order:1", 3))
+
+	warmed, err := db.WarmPrefix([]byte("user:"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, warmed)
+
+	// A nil prefix touches everything.
+	warmed, err = db.WarmPrefix(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, warmed)
+}
+
+func TestWarmCached(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Put("a", 1))
+	require.NoError(t, db.Put("b", 2))
+
+	cached := katalis.Cached(db, 16)
+	warmed, err := cached.Warm([]string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, warmed)
+
+	// Warmed entries come from the LRU: delete underneath and read again.
+	require.NoError(t, db.Del("a"))
+	v, err := cached.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestWarmClosed(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.StringCodec, katalis.IntCodec)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = db.Warm([]string{"a"})
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+	_, err = db.WarmPrefix(nil)
+	assert.ErrorIs(t, err, katalis.ErrClosed)
+}
@@ -0,0 +1,303 @@
+// Package webui is a small data browser for a katalis store, mountable on any
+// mux as an http.Handler. It lists keys with prefix search, shows values
+// decoded by a selectable codec, lets teammates edit or delete records from
+// the browser and graphs store statistics over time.
+//
+// It is a development and operations tool: prefix search scans the store and
+// the handler performs no authentication, so do not mount it on a public
+// address.
+package webui
+
+import (
+	"embed"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NicoNex/katalis"
+)
+
+//go:embed index.html
+var templates embed.FS
+
+// defaultLimit is how many records a page shows when the request has no
+// limit parameter.
+const defaultLimit = 50
+
+// DisplayCodec converts between raw record bytes and the text shown in and
+// accepted from the browser.
+type DisplayCodec struct {
+	// Render turns raw bytes into display text.
+	Render func([]byte) (string, error)
+	// Parse turns submitted text back into raw bytes. A nil Parse makes
+	// the codec display-only: the UI still shows values but refuses edits.
+	Parse func(string) ([]byte, error)
+}
+
+// Handler serves the data browser. Create one with New.
+type Handler struct {
+	db  katalis.DB[[]byte, []byte]
+	mux *http.ServeMux
+	tpl *template.Template
+
+	mu     sync.RWMutex
+	codecs map[string]DisplayCodec
+}
+
+// New returns a Handler for db with the string, hex and int64 display codecs
+// registered. Register more with RegisterCodec.
+func New(db katalis.DB[[]byte, []byte]) *Handler {
+	h := &Handler{
+		db:  db,
+		mux: http.NewServeMux(),
+		tpl: template.Must(template.ParseFS(templates, "index.html")),
+		codecs: map[string]DisplayCodec{
+			"string": {
+				Render: func(b []byte) (string, error) { return string(b), nil },
+				Parse:  func(s string) ([]byte, error) { return []byte(s), nil },
+			},
+			"hex": {
+				Render: func(b []byte) (string, error) { return fmt.Sprintf("%x", b), nil },
+				Parse: func(s string) ([]byte, error) {
+					b := make([]byte, len(s)/2)
+					_, err := fmt.Sscanf(s, "%x", &b)
+					return b, err
+				},
+			},
+			"int64": {
+				Render: func(b []byte) (string, error) {
+					if len(b) != 8 {
+						return "", fmt.Errorf("int64 values are 8 bytes, got %d", len(b))
+					}
+					return strconv.FormatInt(int64(binary.BigEndian.Uint64(b)), 10), nil
+				},
+				Parse: func(s string) ([]byte, error) {
+					n, err := strconv.ParseInt(s, 10, 64)
+					if err != nil {
+						return nil, err
+					}
+					return binary.BigEndian.AppendUint64(nil, uint64(n)), nil
+				},
+			},
+		},
+	}
+	h.mux.HandleFunc("GET /{$}", h.handleIndex)
+	h.mux.HandleFunc("POST /put", h.handlePut)
+	h.mux.HandleFunc("POST /del", h.handleDel)
+	h.mux.HandleFunc("GET /stats.json", h.handleStats)
+	return h
+}
+
+// RegisterCodec makes a display codec selectable in the UI under the given
+// name, replacing any codec already registered under it.
+func (h *Handler) RegisterCodec(name string, c DisplayCodec) {
+	h.mu.Lock()
+	h.codecs[name] = c
+	h.mu.Unlock()
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) codec(name string) (DisplayCodec, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	c, ok := h.codecs[name]
+	if !ok {
+		return DisplayCodec{}, fmt.Errorf("unknown codec %q", name)
+	}
+	return c, nil
+}
+
+func (h *Handler) codecNames() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	names := make([]string, 0, len(h.codecs))
+	for name := range h.codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// row is one record as the template sees it.
+type row struct {
+	Key      string
+	Value    string
+	ValueErr string
+}
+
+// indexData is the template payload for the browser page.
+type indexData struct {
+	Prefix     string
+	KeyCodec   string
+	ValueCodec string
+	Codecs     []string
+	Rows       []row
+	Truncated  bool
+	Error      string
+}
+
+func (h *Handler) handleIndex(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	data := indexData{
+		Prefix:     q.Get("prefix"),
+		KeyCodec:   q.Get("kc"),
+		ValueCodec: q.Get("vc"),
+		Codecs:     h.codecNames(),
+		Error:      q.Get("error"),
+	}
+	if data.KeyCodec == "" {
+		data.KeyCodec = "string"
+	}
+	if data.ValueCodec == "" {
+		data.ValueCodec = "string"
+	}
+
+	limit := defaultLimit
+	if raw := q.Get("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	kc, err := h.codec(data.KeyCodec)
+	if err == nil {
+		_, err = h.codec(data.ValueCodec)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vc, _ := h.codec(data.ValueCodec)
+
+	for entry, err := range h.db.AllItems() {
+		if err != nil {
+			data.Error = err.Error()
+			break
+		}
+		key, err := kc.Render(entry.Key)
+		if err != nil {
+			continue
+		}
+		if !strings.HasPrefix(key, data.Prefix) {
+			continue
+		}
+		if len(data.Rows) == limit {
+			data.Truncated = true
+			break
+		}
+
+		rw := row{Key: key}
+		if rw.Value, err = vc.Render(entry.Value); err != nil {
+			rw.ValueErr = err.Error()
+		}
+		data.Rows = append(data.Rows, rw)
+	}
+	sort.Slice(data.Rows, func(i, j int) bool { return data.Rows[i].Key < data.Rows[j].Key })
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tpl.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// redirectBack sends the browser back to the listing it came from, carrying
+// err (if any) so the page can show it.
+func redirectBack(w http.ResponseWriter, r *http.Request, err error) {
+	q := make(map[string][]string)
+	for _, key := range []string{"prefix", "kc", "vc", "limit"} {
+		if v := r.FormValue(key); v != "" {
+			q[key] = []string{v}
+		}
+	}
+	if err != nil {
+		q["error"] = []string{err.Error()}
+	}
+
+	u := *r.URL
+	u.Path = "./"
+	u.RawQuery = ""
+	vals := u.Query()
+	for k, vs := range q {
+		vals[k] = vs
+	}
+	u.RawQuery = vals.Encode()
+	http.Redirect(w, r, u.String(), http.StatusSeeOther)
+}
+
+// record parses the key (and optionally the value) out of a submitted form.
+func (h *Handler) record(r *http.Request, withValue bool) (key, val []byte, err error) {
+	kc, err := h.codec(r.FormValue("kc"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if kc.Parse == nil {
+		return nil, nil, fmt.Errorf("codec %q is display-only", r.FormValue("kc"))
+	}
+	if key, err = kc.Parse(r.FormValue("key")); err != nil {
+		return nil, nil, fmt.Errorf("key: %w", err)
+	}
+	if !withValue {
+		return key, nil, nil
+	}
+
+	vc, err := h.codec(r.FormValue("vc"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if vc.Parse == nil {
+		return nil, nil, fmt.Errorf("codec %q is display-only", r.FormValue("vc"))
+	}
+	if val, err = vc.Parse(r.FormValue("value")); err != nil {
+		return nil, nil, fmt.Errorf("value: %w", err)
+	}
+	return key, val, nil
+}
+
+func (h *Handler) handlePut(w http.ResponseWriter, r *http.Request) {
+	key, val, err := h.record(r, true)
+	if err == nil {
+		err = h.db.Put(key, val)
+	}
+	redirectBack(w, r, err)
+}
+
+func (h *Handler) handleDel(w http.ResponseWriter, r *http.Request) {
+	key, _, err := h.record(r, false)
+	if err == nil {
+		err = h.db.Del(key)
+	}
+	redirectBack(w, r, err)
+}
+
+func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
+	size, err := h.db.FileSize()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reclaimable, err := h.db.ReclaimableBytes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Records          uint32 `json:"records"`
+		FileSizeBytes    int64  `json:"file_size_bytes"`
+		ReclaimableBytes int64  `json:"reclaimable_bytes"`
+	}{
+		Records:          h.db.Count(),
+		FileSizeBytes:    size,
+		ReclaimableBytes: reclaimable,
+	})
+}
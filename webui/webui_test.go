@@ -0,0 +1,131 @@
+package webui_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NicoNex/katalis"
+	"github.com/NicoNex/katalis/webui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUI(t *testing.T) (katalis.DB[[]byte, []byte], *httptest.Server) {
+	t.Helper()
+
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	srv := httptest.NewServer(webui.New(db))
+	t.Cleanup(srv.Close)
+	return db, srv
+}
+
+func get(t *testing.T, url string) string {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestIndexListsKeys(t *testing.T) {
+	db, srv := newUI(t)
+	require.NoError(t, db.Put([]byte("user:1"), []byte("ada")))
+	require.NoError(t, db.Put([]byte("group:1"), []byte("ops")))
+
+	body := get(t, srv.URL+"/")
+	assert.Contains(t, body, "user:1")
+	assert.Contains(t, body, "ada")
+	assert.Contains(t, body, "group:1")
+}
+
+func TestPrefixSearch(t *testing.T) {
+	db, srv := newUI(t)
+	require.NoError(t, db.Put([]byte("user:1"), []byte("a")))
+	require.NoError(t, db.Put([]byte("group:1"), []byte("b")))
+
+	body := get(t, srv.URL+"/?prefix=user:")
+	assert.Contains(t, body, "user:1")
+	assert.NotContains(t, body, "group:1")
+}
+
+func TestEditAndDelete(t *testing.T) {
+	db, srv := newUI(t)
+	require.NoError(t, db.Put([]byte("name"), []byte("ada")))
+
+	// The test client must not follow the redirect back to the listing.
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	form := url.Values{"key": {"name"}, "value": {"grace"}, "kc": {"string"}, "vc": {"string"}}
+	resp, err := client.PostForm(srv.URL+"/put", form)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+
+	val, err := db.Get([]byte("name"))
+	require.NoError(t, err)
+	assert.Equal(t, "grace", string(val))
+
+	resp, err = client.PostForm(srv.URL+"/del", url.Values{"key": {"name"}, "kc": {"string"}})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+
+	has, err := db.Has([]byte("name"))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestInt64Display(t *testing.T) {
+	db, srv := newUI(t)
+	vb, err := katalis.Int64Codec.Encode(42)
+	require.NoError(t, err)
+	require.NoError(t, db.Put([]byte("counter"), vb))
+
+	body := get(t, srv.URL+"/?vc=int64")
+	assert.Contains(t, body, "42")
+}
+
+func TestRegisterCodec(t *testing.T) {
+	db, err := katalis.Open(t.TempDir()+"/test.db", katalis.BytesCodec, katalis.BytesCodec)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Put([]byte("k"), []byte("v")))
+
+	ui := webui.New(db)
+	ui.RegisterCodec("upper", webui.DisplayCodec{
+		Render: func(b []byte) (string, error) { return strings.ToUpper(string(b)), nil },
+	})
+	srv := httptest.NewServer(ui)
+	defer srv.Close()
+
+	body := get(t, srv.URL+"/?vc=upper")
+	assert.Contains(t, body, "V")
+}
+
+func TestStatsJSON(t *testing.T) {
+	db, srv := newUI(t)
+	require.NoError(t, db.Put([]byte("a"), []byte("1")))
+
+	var stats struct {
+		Records       uint32 `json:"records"`
+		FileSizeBytes int64  `json:"file_size_bytes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(get(t, srv.URL+"/stats.json")), &stats))
+	assert.Equal(t, uint32(1), stats.Records)
+	assert.Positive(t, stats.FileSizeBytes)
+}